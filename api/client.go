@@ -1,14 +1,14 @@
 package api
 
 import (
+	"context"
 	"time"
 
 	"github.com/spf13/viper"
-	"github.com/zalando/go-keyring"
 
+	"github.com/eliziario/jira-lib/pkg/credentials"
 	"github.com/eliziario/jira-lib/pkg/jira"
 	"github.com/eliziario/jira-lib/pkg/jira/filter"
-	"github.com/eliziario/jira-lib/pkg/netrc"
 )
 
 const clientTimeout = 15 * time.Second
@@ -32,15 +32,11 @@ func Client(config jira.Config) *jira.Client {
 		config.APIToken = viper.GetString("api_token")
 	}
 	if config.APIToken == "" {
-		netrcConfig, _ := netrc.Read(config.Server, config.Login)
-		if netrcConfig != nil {
-			config.APIToken = netrcConfig.Password
+		provider := credentials.Chain(credentials.Netrc{}, credentials.Keyring{})
+		if creds, err := provider.Credentials(context.Background(), config.Server, config.Login); err == nil {
+			config.APIToken = creds.Token
 		}
 	}
-	if config.APIToken == "" {
-		secret, _ := keyring.Get("jira-cli", config.Login)
-		config.APIToken = secret
-	}
 	if config.AuthType == nil {
 		authType := jira.AuthType(viper.GetString("auth_type"))
 		config.AuthType = &authType
@@ -73,12 +69,20 @@ func Client(config jira.Config) *jira.Client {
 
 // NewClient creates a new Jira client without global state.
 // This is the recommended way to create a client for library usage.
+//
+// If the API token is not set, it is resolved on the first request using the
+// default credential providers, see credentials.Default. Pass
+// jira.WithCredentialProvider to use other providers.
 func NewClient(config jira.Config, opts ...jira.ClientFunc) *jira.Client {
 	// Apply default options
 	defaultOpts := []jira.ClientFunc{
 		jira.WithTimeout(clientTimeout),
 	}
 	
+	if config.APIToken == "" && needsAPIToken(config.AuthType) {
+		defaultOpts = append(defaultOpts, jira.WithCredentialProvider(credentials.Default()))
+	}
+	
 	if config.Insecure != nil {
 		defaultOpts = append(defaultOpts, jira.WithInsecureTLS(*config.Insecure))
 	}
@@ -89,6 +93,18 @@ func NewClient(config jira.Config, opts ...jira.ClientFunc) *jira.Client {
 	return jira.NewClient(config, allOpts...)
 }
 
+// needsAPIToken checks if the auth type authenticates with the API token.
+func needsAPIToken(at *jira.AuthType) bool {
+	if at == nil {
+		return true
+	}
+	switch at.String() {
+	case string(jira.AuthTypeBasic), string(jira.AuthTypeBearer):
+		return true
+	}
+	return false
+}
+
 // DefaultClient returns default jira client.
 func DefaultClient(debug bool) *jira.Client {
 	return Client(jira.Config{Debug: debug})
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -285,7 +286,7 @@ func (app *Application) searchIssues(args []string) {
 			summary,
 		)
 	}
-	fmt.Printf("\nTotal: %d issues\n", results.Total)
+	fmt.Printf("\nShowing %d issues\n", len(results.Issues))
 }
 
 func (app *Application) viewIssue(args []string) {
@@ -642,7 +643,8 @@ func (app *Application) bulkOperations(args []string) {
 
 func (app *Application) handleError(context string, err error) {
 	// Type assert to get more detailed error info
-	if jiraErr, ok := err.(*jira.ErrUnexpectedResponse); ok {
+	var jiraErr *jira.ErrUnexpectedResponse
+	if errors.As(err, &jiraErr) {
 		fmt.Printf("Error: %s\n", context)
 		fmt.Printf("Status: %s\n", jiraErr.Status)
 		fmt.Printf("Details: %s\n", jiraErr.Body.String())
@@ -134,7 +134,7 @@ func listIssues(client *lib.JiraClient, project string) {
 		)
 	}
 
-	fmt.Printf("\nTotal: %d issues\n", results.Total)
+	fmt.Printf("\nShowing %d issues\n", len(results.Issues))
 }
 
 func createIssue(client *lib.JiraClient, project string) {
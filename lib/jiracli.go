@@ -4,16 +4,24 @@ package lib
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"iter"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/eliziario/jira-lib/pkg/config"
+	"github.com/eliziario/jira-lib/pkg/credentials"
 	"github.com/eliziario/jira-lib/pkg/jira"
 	"github.com/eliziario/jira-lib/pkg/jira/filter"
 )
 
+// searchPageSize is the number of issues requested per page when paginating.
+const searchPageSize = 100
+
 // ClientConfig holds the configuration for creating a Jira client.
 type ClientConfig struct {
 	// Server is the base URL of your Jira instance (required)
@@ -22,52 +30,136 @@ type ClientConfig struct {
 	// Login is the username or email for authentication (required)
 	Login string
 	
-	// APIToken is the API token or password for authentication (required)
+	// APIToken is the API token or password for authentication (required
+	// unless Credentials is set)
 	APIToken string
 	
+	// Credentials resolves the login and API token on the first request when
+	// APIToken is empty, eg: credentials.Chain(credentials.Env{}, credentials.Keyring{})
+	// (optional)
+	Credentials credentials.Provider
+	
 	// AuthType specifies the authentication type (optional, defaults to "basic")
-	// Possible values: "basic", "bearer", "mtls"
+	// Possible values: "basic", "bearer", "mtls", "oauth2"
 	AuthType string
 	
 	// Insecure allows connections to servers with invalid certificates (optional)
 	Insecure bool
 	
 	// Debug enables debug logging (optional)
+	// Requests are dumped to stderr unless a Logger is provided
 	Debug bool
 	
 	// Timeout specifies the HTTP client timeout (optional, defaults to 15s)
 	Timeout time.Duration
 	
-	// InstallationType specifies if it's "Cloud" or "Local" (optional, defaults to "Cloud")
+	// InstallationType specifies if it's "Cloud" or "Local" (optional, detected
+	// from the server info on first use if empty, "Cloud" if the detection fails)
 	InstallationType string
 	
 	// MTLSConfig holds mTLS configuration if AuthType is "mtls"
 	MTLSConfig *MTLSConfig
+	
+	// TokenSource supplies access tokens if AuthType is "oauth2".
+	// Login and APIToken are not required in that case.
+	TokenSource jira.TokenSource
+	
+	// CloudID of the site to access with OAuth 2.0 tokens. Requests are sent
+	// to the Atlassian API gateway, so Server is not required when set.
+	CloudID string
+
+	// RetryPolicy enables automatic retries of throttled and transiently
+	// failed requests (optional, retries are disabled by default)
+	RetryPolicy *jira.RetryPolicy
+
+	// RateLimiter throttles all requests sent by the client (optional).
+	// The same limiter can be shared by multiple clients.
+	RateLimiter *jira.RateLimiter
+
+	// JQLBounding decides how unbounded search queries are handled on
+	// Jira cloud (optional, queries are sent as is by default)
+	JQLBounding *jira.JQLBoundingPolicy
+
+	// Cache caches responses of endpoints that rarely change, eg: fields and
+	// projects (optional, responses are not cached by default)
+	Cache *jira.CachePolicy
+
+	// HTTPClient is used to send requests instead of the default one (optional)
+	HTTPClient *http.Client
+
+	// Middlewares wrap the transport, eg: for tracing or metrics (optional)
+	Middlewares []jira.Middleware
+
+	// Logger logs requests, retries and failures with secrets redacted (optional)
+	Logger *slog.Logger
+
+	// LogOptions configures log levels and redacted fields (optional)
+	LogOptions *jira.LogOptions
+
+	// Project is the project key used when none is given (optional)
+	Project string
+
+	// BoardID is the board used when none is given (optional)
+	BoardID int
+
+	// CustomFields lets created and edited issues set custom fields by name
+	// through CustomFields of the request. They are used unless the request
+	// sets its own with WithCustomFields (optional)
+	CustomFields []jira.IssueTypeField
 }
 
 // MTLSConfig holds mTLS authentication configuration.
+// Certificates can be given as file paths, PEM bytes or a parsed certificate.
 type MTLSConfig struct {
 	CaCert     string
 	ClientCert string
 	ClientKey  string
+	
+	CaCertPEM     []byte
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	
+	// Certificate is used instead of the client cert and key if set
+	Certificate *tls.Certificate
+	
+	// UseSystemRoots trusts the system root CAs in addition to the CA cert
+	UseSystemRoots bool
 }
 
 // JiraClient wraps the underlying jira.Client with convenience methods.
 type JiraClient struct {
 	client           *jira.Client
 	installationType string
+	project          string
+	boardID          int
+	customFields     []jira.IssueTypeField
 }
 
 // NewClient creates a new Jira client for library usage.
 func NewClient(config ClientConfig) (*JiraClient, error) {
-	if config.Server == "" {
+	if config.Server == "" && config.CloudID == "" {
 		return nil, fmt.Errorf("server URL is required")
 	}
-	if config.Login == "" {
-		return nil, fmt.Errorf("login is required")
-	}
-	if config.APIToken == "" {
-		return nil, fmt.Errorf("API token is required")
+	switch config.AuthType {
+	case string(jira.AuthTypeOAuth2):
+		if config.TokenSource == nil {
+			return nil, fmt.Errorf("token source is required")
+		}
+	case string(jira.AuthTypeMTLS):
+		if config.MTLSConfig == nil {
+			return nil, fmt.Errorf("mTLS config is required")
+		}
+	default:
+		// Credentials are resolved on the first request.
+		if config.APIToken == "" && config.Credentials != nil {
+			break
+		}
+		if config.Login == "" {
+			return nil, fmt.Errorf("login is required")
+		}
+		if config.APIToken == "" {
+			return nil, fmt.Errorf("API token is required")
+		}
 	}
 	
 	// Set defaults
@@ -77,7 +169,7 @@ func NewClient(config ClientConfig) (*JiraClient, error) {
 	if config.Timeout == 0 {
 		config.Timeout = 15 * time.Second
 	}
-	if config.InstallationType == "" {
+	if config.InstallationType == "" && config.CloudID != "" {
 		config.InstallationType = jira.InstallationTypeCloud
 	}
 	
@@ -89,100 +181,334 @@ func NewClient(config ClientConfig) (*JiraClient, error) {
 		AuthType: &authType,
 		Insecure: &config.Insecure,
 		Debug:    config.Debug,
+		
+		TokenSource: config.TokenSource,
+		CloudID:     config.CloudID,
 	}
 	
 	// Add mTLS config if provided
 	if config.MTLSConfig != nil {
 		jiraConfig.MTLSConfig = jira.MTLSConfig{
-			CaCert:     config.MTLSConfig.CaCert,
-			ClientCert: config.MTLSConfig.ClientCert,
-			ClientKey:  config.MTLSConfig.ClientKey,
+			CaCert:         config.MTLSConfig.CaCert,
+			ClientCert:     config.MTLSConfig.ClientCert,
+			ClientKey:      config.MTLSConfig.ClientKey,
+			CaCertPEM:      config.MTLSConfig.CaCertPEM,
+			ClientCertPEM:  config.MTLSConfig.ClientCertPEM,
+			ClientKeyPEM:   config.MTLSConfig.ClientKeyPEM,
+			Certificate:    config.MTLSConfig.Certificate,
+			UseSystemRoots: config.MTLSConfig.UseSystemRoots,
 		}
 	}
 	
-	client := jira.NewClient(
-		jiraConfig,
+	opts := []jira.ClientFunc{
 		jira.WithTimeout(config.Timeout),
 		jira.WithInsecureTLS(config.Insecure),
-	)
+	}
+	if config.RetryPolicy != nil {
+		opts = append(opts, jira.WithRetryPolicy(*config.RetryPolicy))
+	}
+	if config.RateLimiter != nil {
+		opts = append(opts, jira.WithRateLimiter(config.RateLimiter))
+	}
+	if config.JQLBounding != nil {
+		opts = append(opts, jira.WithJQLBoundingPolicy(*config.JQLBounding))
+	}
+	if config.Cache != nil {
+		opts = append(opts, jira.WithCache(*config.Cache))
+	}
+	if config.APIToken == "" && config.Credentials != nil {
+		opts = append(opts, jira.WithCredentialProvider(config.Credentials))
+	}
+	if config.HTTPClient != nil {
+		opts = append(opts, jira.WithHTTPClient(config.HTTPClient))
+	}
+	if len(config.Middlewares) > 0 {
+		opts = append(opts, jira.WithMiddleware(config.Middlewares...))
+	}
+	if config.Logger != nil {
+		opts = append(opts, jira.WithLogger(config.Logger))
+	}
+	if config.LogOptions != nil {
+		opts = append(opts, jira.WithLogOptions(*config.LogOptions))
+	}
+
+	client, err := jira.New(jiraConfig, opts...)
+	if err != nil {
+		return nil, err
+	}
 	
 	return &JiraClient{
 		client:           client,
 		installationType: config.InstallationType,
+		project:          config.Project,
+		boardID:          config.BoardID,
+		customFields:     config.CustomFields,
 	}, nil
 }
 
+// NewClientFromProfile creates a new Jira client from a profile of the default
+// config file, see config.LoadProfile. An empty name selects the default profile.
+func NewClientFromProfile(name string) (*JiraClient, error) {
+	profile, err := config.LoadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(ClientConfigFromProfile(profile))
+}
+
+// ClientConfigFromProfile converts a profile to a client config. Options that
+// can't be set in a config file, eg: a TokenSource, can be added before
+// calling NewClient. The API token is resolved with the default credential
+// providers if the profile doesn't have one.
+func ClientConfigFromProfile(p *config.Profile) ClientConfig {
+	cfg := ClientConfig{
+		Server:           p.Server,
+		Login:            p.Login,
+		APIToken:         p.APIToken,
+		AuthType:         p.AuthType,
+		Insecure:         p.Insecure,
+		Timeout:          p.Timeout,
+		InstallationType: p.Installation,
+		CloudID:          p.CloudID,
+		Project:          p.Project,
+		BoardID:          p.Board,
+		CustomFields:     p.IssueTypeFields(),
+	}
+	if p.APIToken == "" {
+		cfg.Credentials = credentials.Default()
+	}
+	if p.MTLS != nil {
+		cfg.MTLSConfig = &MTLSConfig{
+			CaCert:     p.MTLS.CaCert,
+			ClientCert: p.MTLS.ClientCert,
+			ClientKey:  p.MTLS.ClientKey,
+		}
+	}
+	return cfg
+}
+
 // GetIssue retrieves a single issue by key.
+// Use filters from the issue filter package to select fields and expand options.
 func (c *JiraClient) GetIssue(key string, opts ...filter.Filter) (*jira.Issue, error) {
-	if c.installationType == jira.InstallationTypeLocal {
-		return c.client.GetIssueV2(key, opts...)
+	return c.GetIssueContext(context.Background(), key, opts...)
+}
+
+// GetIssueContext is the same as GetIssue but accepts a context.
+func (c *JiraClient) GetIssueContext(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error) {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return c.client.GetIssue(key, opts...)
+	if local {
+		return c.client.GetIssueV2Context(ctx, key, opts...)
+	}
+	return c.client.GetIssueContext(ctx, key, opts...)
 }
 
 // SearchIssues searches for issues using JQL.
-func (c *JiraClient) SearchIssues(jql string, from, limit uint) (*jira.SearchResult, error) {
-	if c.installationType == jira.InstallationTypeLocal {
-		return c.client.SearchV2(jql, from, limit)
+// Use filters from the search filter package to select fields and expand options.
+func (c *JiraClient) SearchIssues(jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error) {
+	return c.SearchIssuesContext(context.Background(), jql, from, limit, opts...)
+}
+
+// SearchIssuesContext is the same as SearchIssues but accepts a context.
+func (c *JiraClient) SearchIssuesContext(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error) {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if local {
+		return c.client.SearchV2Context(ctx, jql, from, limit, opts...)
 	}
-	return c.client.Search(jql, from, limit)
+	return c.client.SearchContext(ctx, jql, from, limit, opts...)
 }
 
 // CreateIssue creates a new issue.
+// The default project and custom fields are used if the request has none.
+// The request is not modified.
 func (c *JiraClient) CreateIssue(request *jira.CreateRequest) (*jira.CreateResponse, error) {
-	if c.installationType == jira.InstallationTypeLocal {
-		return c.client.CreateV2(request)
+	return c.CreateIssueContext(context.Background(), request)
+}
+
+// CreateIssueContext is the same as CreateIssue but accepts a context.
+func (c *JiraClient) CreateIssueContext(ctx context.Context, request *jira.CreateRequest) (*jira.CreateResponse, error) {
+	req := *request
+	if req.Project == "" {
+		req.Project = c.project
+	}
+	if len(c.customFields) > 0 && !req.HasCustomFields() {
+		req.WithCustomFields(c.customFields)
+	}
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return c.client.Create(request)
+	if local {
+		return c.client.CreateV2Context(ctx, &req)
+	}
+	return c.client.CreateContext(ctx, &req)
 }
 
 // UpdateIssue updates an existing issue.
+// The default custom fields are used if the request has none.
+// The request is not modified.
 func (c *JiraClient) UpdateIssue(key string, request *jira.EditRequest) error {
-	// The jira package only has Edit method, no EditV2
-	return c.client.Edit(key, request)
+	return c.UpdateIssueContext(context.Background(), key, request)
+}
+
+// UpdateIssueContext is the same as UpdateIssue but accepts a context.
+func (c *JiraClient) UpdateIssueContext(ctx context.Context, key string, request *jira.EditRequest) error {
+	req := *request
+	if len(c.customFields) > 0 && !req.HasCustomFields() {
+		req.WithCustomFields(c.customFields)
+	}
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return err
+	}
+	if local {
+		return c.client.EditContext(ctx, key, &req)
+	}
+	return c.client.EditV3Context(ctx, key, &req)
 }
 
 // DeleteIssue deletes an issue.
 func (c *JiraClient) DeleteIssue(key string, cascade bool) error {
-	return c.client.DeleteIssue(key, cascade)
+	return c.DeleteIssueContext(context.Background(), key, cascade)
+}
+
+// DeleteIssueContext is the same as DeleteIssue but accepts a context.
+func (c *JiraClient) DeleteIssueContext(ctx context.Context, key string, cascade bool) error {
+	return c.client.DeleteIssueContext(ctx, key, cascade)
 }
 
 // AssignIssue assigns an issue to a user.
 func (c *JiraClient) AssignIssue(key string, assignee string) error {
-	if c.installationType == jira.InstallationTypeLocal {
-		return c.client.AssignIssueV2(key, assignee)
+	return c.AssignIssueContext(context.Background(), key, assignee)
+}
+
+// AssignIssueContext is the same as AssignIssue but accepts a context.
+func (c *JiraClient) AssignIssueContext(ctx context.Context, key string, assignee string) error {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return err
 	}
-	return c.client.AssignIssue(key, assignee)
+	if local {
+		return c.client.AssignIssueV2Context(ctx, key, assignee)
+	}
+	return c.client.AssignIssueContext(ctx, key, assignee)
 }
 
 // TransitionIssue transitions an issue to a new status.
 func (c *JiraClient) TransitionIssue(key string, request *jira.TransitionRequest) error {
-	_, err := c.client.Transition(key, request)
+	return c.TransitionIssueContext(context.Background(), key, request)
+}
+
+// TransitionIssueContext is the same as TransitionIssue but accepts a context.
+func (c *JiraClient) TransitionIssueContext(ctx context.Context, key string, request *jira.TransitionRequest) error {
+	_, err := c.client.TransitionContext(ctx, key, request)
 	return err
 }
 
 // AddComment adds a comment to an issue.
+// The comment is CommonMark, it's converted to the format of the server.
 func (c *JiraClient) AddComment(key string, comment string, internal bool) error {
-	return c.client.AddIssueComment(key, comment, internal)
+	return c.AddCommentContext(context.Background(), key, comment, internal)
+}
+
+// AddCommentContext is the same as AddComment but accepts a context.
+func (c *JiraClient) AddCommentContext(ctx context.Context, key string, comment string, internal bool) error {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return err
+	}
+	if local {
+		return c.client.AddIssueCommentContext(ctx, key, comment, internal)
+	}
+	return c.client.AddIssueCommentV3Context(ctx, key, comment, internal)
 }
 
 // GetTransitions gets available transitions for an issue.
 func (c *JiraClient) GetTransitions(key string) ([]*jira.Transition, error) {
-	if c.installationType == jira.InstallationTypeLocal {
-		return c.client.TransitionsV2(key)
+	return c.GetTransitionsContext(context.Background(), key)
+}
+
+// GetTransitionsContext is the same as GetTransitions but accepts a context.
+func (c *JiraClient) GetTransitionsContext(ctx context.Context, key string) ([]*jira.Transition, error) {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
 	}
-	return c.client.Transitions(key)
+	if local {
+		return c.client.TransitionsV2Context(ctx, key)
+	}
+	return c.client.TransitionsContext(ctx, key)
+}
+
+// GetCreateMeta gets the issue types that can be created in a project.
+// Jira Server 9 and above only lists issue types, without their fields.
+func (c *JiraClient) GetCreateMeta(request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error) {
+	return c.GetCreateMetaContext(context.Background(), request)
+}
+
+// GetCreateMetaContext is the same as GetCreateMeta but accepts a context.
+func (c *JiraClient) GetCreateMetaContext(ctx context.Context, request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error) {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !local {
+		return c.client.GetCreateMetaContext(ctx, request)
+	}
+	
+	caps, err := c.client.CapabilitiesContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !caps.IssueTypeCreateMeta {
+		return c.client.GetCreateMetaContext(ctx, request)
+	}
+	
+	res, err := c.client.GetCreateMetaForJiraServerV9Context(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	
+	var out jira.CreateMetaResponse
+	out.Projects = make([]struct {
+		Key        string                      `json:"key"`
+		Name       string                      `json:"name"`
+		IssueTypes []*jira.CreateMetaIssueType `json:"issuetypes"`
+	}, 1)
+	out.Projects[0].Key = request.Projects
+	for _, v := range res.Values {
+		out.Projects[0].IssueTypes = append(out.Projects[0].IssueTypes, &jira.CreateMetaIssueType{
+			IssueType: jira.IssueType{ID: v.ID, Name: v.Name, Subtask: v.Subtask},
+		})
+	}
+	
+	return &out, nil
 }
 
 // GetProjects lists all accessible projects.
 func (c *JiraClient) GetProjects() ([]*jira.Project, error) {
-	return c.client.Project()
+	return c.GetProjectsContext(context.Background())
+}
+
+// GetProjectsContext is the same as GetProjects but accepts a context.
+func (c *JiraClient) GetProjectsContext(ctx context.Context) ([]*jira.Project, error) {
+	return c.client.ProjectContext(ctx)
 }
 
 // GetProject gets a single project by key.
 func (c *JiraClient) GetProject(key string) (*jira.Project, error) {
+	return c.GetProjectContext(context.Background(), key)
+}
+
+// GetProjectContext is the same as GetProject but accepts a context.
+func (c *JiraClient) GetProjectContext(ctx context.Context, key string) (*jira.Project, error) {
 	// ProjectDetails doesn't exist, need to filter from all projects
-	projects, err := c.client.Project()
+	projects, err := c.client.ProjectContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -191,45 +517,120 @@ func (c *JiraClient) GetProject(key string) (*jira.Project, error) {
 			return project, nil
 		}
 	}
-	return nil, fmt.Errorf("project %s not found", key)
+	return nil, fmt.Errorf("project %s: %w", key, jira.ErrNotFound)
 }
 
 // GetBoards lists boards for a project.
+// The default project is used if project is empty.
 func (c *JiraClient) GetBoards(project string, boardType string) (*jira.BoardResult, error) {
-	return c.client.Boards(project, boardType)
+	return c.GetBoardsContext(context.Background(), project, boardType)
+}
+
+// GetBoardsContext is the same as GetBoards but accepts a context.
+func (c *JiraClient) GetBoardsContext(ctx context.Context, project string, boardType string) (*jira.BoardResult, error) {
+	if project == "" {
+		project = c.project
+	}
+	return c.client.BoardsContext(ctx, project, boardType)
 }
 
 // GetSprints lists sprints.
+// The default board is used if boardID is zero.
 func (c *JiraClient) GetSprints(boardID int, state string, from, limit int) (*jira.SprintResult, error) {
-	return c.client.Sprints(boardID, state, from, limit)
+	return c.GetSprintsContext(context.Background(), boardID, state, from, limit)
+}
+
+// GetSprintsContext is the same as GetSprints but accepts a context.
+func (c *JiraClient) GetSprintsContext(ctx context.Context, boardID int, state string, from, limit int) (*jira.SprintResult, error) {
+	if boardID == 0 {
+		boardID = c.boardID
+	}
+	return c.client.SprintsContext(ctx, boardID, state, from, limit)
 }
 
 // GetSprintIssues lists issues in a sprint.
 func (c *JiraClient) GetSprintIssues(sprintID int, jql string, from, limit uint) (*jira.SearchResult, error) {
-	return c.client.SprintIssues(sprintID, jql, from, limit)
+	return c.GetSprintIssuesContext(context.Background(), sprintID, jql, from, limit)
+}
+
+// GetSprintIssuesContext is the same as GetSprintIssues but accepts a context.
+func (c *JiraClient) GetSprintIssuesContext(ctx context.Context, sprintID int, jql string, from, limit uint) (*jira.SearchResult, error) {
+	return c.client.SprintIssuesContext(ctx, sprintID, jql, from, limit)
 }
 
 // GetEpics searches for epics using JQL.
 // For board-specific epics, construct appropriate JQL query.
+// The default project is used if project is empty.
 func (c *JiraClient) GetEpics(project string, from, limit uint) (*jira.SearchResult, error) {
+	return c.GetEpicsContext(context.Background(), project, from, limit)
+}
+
+// GetEpicsContext is the same as GetEpics but accepts a context.
+func (c *JiraClient) GetEpicsContext(ctx context.Context, project string, from, limit uint) (*jira.SearchResult, error) {
+	if project == "" {
+		project = c.project
+	}
 	// Search for epics using JQL
 	jql := fmt.Sprintf("project = %s AND issuetype = Epic", project)
-	return c.SearchIssues(jql, from, limit)
+	return c.SearchIssuesContext(ctx, jql, from, limit)
 }
 
 // GetEpicIssues lists issues in an epic.
 func (c *JiraClient) GetEpicIssues(epicKey, jql string, from, limit uint) (*jira.SearchResult, error) {
-	return c.client.EpicIssues(epicKey, jql, from, limit)
+	return c.GetEpicIssuesContext(context.Background(), epicKey, jql, from, limit)
+}
+
+// GetEpicIssuesContext is the same as GetEpicIssues but accepts a context.
+func (c *JiraClient) GetEpicIssuesContext(ctx context.Context, epicKey, jql string, from, limit uint) (*jira.SearchResult, error) {
+	return c.client.EpicIssuesContext(ctx, epicKey, jql, from, limit)
 }
 
 // GetMyself gets information about the authenticated user.
 func (c *JiraClient) GetMyself() (*jira.Me, error) {
-	return c.client.Me()
+	return c.GetMyselfContext(context.Background())
+}
+
+// GetMyselfContext is the same as GetMyself but accepts a context.
+func (c *JiraClient) GetMyselfContext(ctx context.Context) (*jira.Me, error) {
+	return c.client.MeContext(ctx)
 }
 
 // GetServerInfo gets server information.
 func (c *JiraClient) GetServerInfo() (*jira.ServerInfo, error) {
-	return c.client.ServerInfo()
+	return c.GetServerInfoContext(context.Background())
+}
+
+// GetServerInfoContext is the same as GetServerInfo but accepts a context.
+func (c *JiraClient) GetServerInfoContext(ctx context.Context) (*jira.ServerInfo, error) {
+	return c.client.ServerInfoContext(ctx)
+}
+
+// Capabilities returns the detected installation type and features of the server.
+func (c *JiraClient) Capabilities() (*jira.Capabilities, error) {
+	return c.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext is the same as Capabilities but accepts a context.
+func (c *JiraClient) CapabilitiesContext(ctx context.Context) (*jira.Capabilities, error) {
+	return c.client.CapabilitiesContext(ctx)
+}
+
+// isLocal reports whether the server is an on-premise installation.
+// The installation type is detected from the server info unless configured.
+// Servers are assumed to be cloud instances if the detection fails, eg: when
+// the server info is not accessible, and the detection is retried next time.
+func (c *JiraClient) isLocal(ctx context.Context) (bool, error) {
+	if c.installationType != "" {
+		return c.installationType == jira.InstallationTypeLocal, nil
+	}
+	caps, err := c.client.CapabilitiesContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		return false, nil
+	}
+	return !caps.IsCloud(), nil
 }
 
 // GetRawClient returns the underlying jira.Client for advanced usage.
@@ -238,6 +639,41 @@ func (c *JiraClient) GetRawClient() *jira.Client {
 	return c.client
 }
 
+// IterateIssues streams all issues matching the JQL, fetching them page by page
+// as the iteration progresses. Cloud instances are paged using nextPageToken
+// and local installations using startAt. Iteration stops on the first error
+// or when the context is cancelled.
+func (c *JiraClient) IterateIssues(ctx context.Context, jql string, opts ...filter.Filter) iter.Seq2[*jira.Issue, error] {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return func(yield func(*jira.Issue, error) bool) {
+			yield(nil, err)
+		}
+	}
+	if local {
+		return c.client.SearchIterV2(ctx, jql, searchPageSize, opts...)
+	}
+	return c.client.SearchIter(ctx, jql, searchPageSize, opts...)
+}
+
+// Count returns the number of issues matching the JQL without fetching them.
+// Cloud instances return an approximate count, local installations an exact one.
+func (c *JiraClient) Count(jql string) (int, error) {
+	return c.CountContext(context.Background(), jql)
+}
+
+// CountContext is the same as Count but accepts a context.
+func (c *JiraClient) CountContext(ctx context.Context, jql string) (int, error) {
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if local {
+		return c.client.CountV2Context(ctx, jql)
+	}
+	return c.client.CountContext(ctx, jql)
+}
+
 // GetAllIssuesOptions contains options for fetching all issues.
 type GetAllIssuesOptions struct {
 	// Project filters by project key (optional)
@@ -259,11 +695,21 @@ type GetAllIssuesOptions struct {
 	
 	// OrderBy specifies the field to order by (default: "created DESC")
 	OrderBy string
+	
+	// Progress is called after each fetched issue with the number of issues
+	// fetched so far and the expected total (optional). The total is counted
+	// upfront, capped by MaxResults, and is approximate on cloud instances.
+	Progress func(fetched, total int)
 }
 
 // GetAllIssues fetches all issues with optional filtering.
 // This method handles pagination automatically to retrieve all matching issues.
 func (c *JiraClient) GetAllIssues(options GetAllIssuesOptions) ([]*jira.Issue, error) {
+	return c.GetAllIssuesContext(context.Background(), options)
+}
+
+// GetAllIssuesContext is the same as GetAllIssues but accepts a context.
+func (c *JiraClient) GetAllIssuesContext(ctx context.Context, options GetAllIssuesOptions) ([]*jira.Issue, error) {
 	// Build JQL query
 	var jqlParts []string
 	
@@ -299,44 +745,37 @@ func (c *JiraClient) GetAllIssues(options GetAllIssuesOptions) ([]*jira.Issue, e
 		jql += " ORDER BY created DESC"
 	}
 	
+	// Count matching issues upfront to report progress
+	var total int
+	if options.Progress != nil {
+		var err error
+		if total, err = c.CountContext(ctx, jql); err != nil {
+			return nil, fmt.Errorf("failed to count issues: %w", err)
+		}
+		if options.MaxResults > 0 && total > options.MaxResults {
+			total = options.MaxResults
+		}
+	}
+	
 	// Fetch all issues with pagination
 	var allIssues []*jira.Issue
-	const batchSize = 100
-	var startAt uint = 0
-	totalFetched := 0
 	
-	for {
-		// Fetch a batch of issues
-		results, err := c.SearchIssues(jql, startAt, batchSize)
+	for issue, err := range c.IterateIssues(ctx, jql) {
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch issues at offset %d: %w", startAt, err)
+			return nil, fmt.Errorf("failed to fetch issues at offset %d: %w", len(allIssues), err)
 		}
 		
-		// Add issues to our collection
-		allIssues = append(allIssues, results.Issues...)
-		totalFetched += len(results.Issues)
-		
-		// Check if we've reached the limit (if set)
-		if options.MaxResults > 0 && totalFetched >= options.MaxResults {
-			// Trim to exact max results
-			if len(allIssues) > options.MaxResults {
-				allIssues = allIssues[:options.MaxResults]
-			}
-			break
-		}
+		allIssues = append(allIssues, issue)
 		
-		// Check if we've fetched all issues
-		if startAt+uint(len(results.Issues)) >= uint(results.Total) {
-			break
+		if options.Progress != nil {
+			// The count is approximate, never report less than fetched
+			options.Progress(len(allIssues), max(total, len(allIssues)))
 		}
 		
-		// No more issues returned
-		if len(results.Issues) == 0 {
+		// Check if we've reached the limit (if set)
+		if options.MaxResults > 0 && len(allIssues) >= options.MaxResults {
 			break
 		}
-		
-		// Prepare for next batch
-		startAt += batchSize
 	}
 	
 	return allIssues, nil
@@ -344,6 +783,11 @@ func (c *JiraClient) GetAllIssues(options GetAllIssuesOptions) ([]*jira.Issue, e
 
 // GetIssuesByDateRange fetches issues created or updated within a date range.
 func (c *JiraClient) GetIssuesByDateRange(startDate, endDate string, dateField string) ([]*jira.Issue, error) {
+	return c.GetIssuesByDateRangeContext(context.Background(), startDate, endDate, dateField)
+}
+
+// GetIssuesByDateRangeContext is the same as GetIssuesByDateRange but accepts a context.
+func (c *JiraClient) GetIssuesByDateRangeContext(ctx context.Context, startDate, endDate string, dateField string) ([]*jira.Issue, error) {
 	if dateField == "" {
 		dateField = "created"
 	}
@@ -352,22 +796,12 @@ func (c *JiraClient) GetIssuesByDateRange(startDate, endDate string, dateField s
 		dateField, startDate, dateField, endDate, dateField)
 	
 	var allIssues []*jira.Issue
-	const batchSize = 100
-	var startAt uint = 0
 	
-	for {
-		results, err := c.SearchIssues(jql, startAt, batchSize)
+	for issue, err := range c.IterateIssues(ctx, jql) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch issues: %w", err)
 		}
-		
-		allIssues = append(allIssues, results.Issues...)
-		
-		if startAt+uint(len(results.Issues)) >= uint(results.Total) || len(results.Issues) == 0 {
-			break
-		}
-		
-		startAt += batchSize
+		allIssues = append(allIssues, issue)
 	}
 	
 	return allIssues, nil
@@ -375,13 +809,18 @@ func (c *JiraClient) GetIssuesByDateRange(startDate, endDate string, dateField s
 
 // GetRecentIssues fetches issues from the last N days.
 func (c *JiraClient) GetRecentIssues(days int, project string) ([]*jira.Issue, error) {
+	return c.GetRecentIssuesContext(context.Background(), days, project)
+}
+
+// GetRecentIssuesContext is the same as GetRecentIssues but accepts a context.
+func (c *JiraClient) GetRecentIssuesContext(ctx context.Context, days int, project string) ([]*jira.Issue, error) {
 	options := GetAllIssuesOptions{
 		Project:   project,
 		StartDate: fmt.Sprintf("-%dd", days),
 		DateField: "created",
 		OrderBy:   "created DESC",
 	}
-	return c.GetAllIssues(options)
+	return c.GetAllIssuesContext(ctx, options)
 }
 
 // StatusChange represents a status transition in issue history.
@@ -448,8 +887,13 @@ type HistoryItem struct {
 // GetIssueStatusChanges retrieves all status changes for an issue.
 // It fetches the issue with its changelog and extracts status transitions.
 func (c *JiraClient) GetIssueStatusChanges(issueKey string) ([]StatusChange, error) {
+	return c.GetIssueStatusChangesContext(context.Background(), issueKey)
+}
+
+// GetIssueStatusChangesContext is the same as GetIssueStatusChanges but accepts a context.
+func (c *JiraClient) GetIssueStatusChangesContext(ctx context.Context, issueKey string) ([]StatusChange, error) {
 	// Fetch issue with expanded changelog
-	issueWithHistory, err := c.getIssueWithChangelog(issueKey)
+	issueWithHistory, err := c.getIssueWithChangelog(ctx, issueKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch issue with changelog: %w", err)
 	}
@@ -493,7 +937,7 @@ func (c *JiraClient) GetIssueStatusChanges(issueKey string) ([]StatusChange, err
 		// Check if we need to fetch more history (pagination)
 		if issueWithHistory.Changelog.Total > issueWithHistory.Changelog.StartAt+issueWithHistory.Changelog.MaxResults {
 			// Fetch additional history pages
-			additionalChanges, err := c.fetchAdditionalHistory(issueKey, issueWithHistory.Changelog.MaxResults)
+			additionalChanges, err := c.fetchAdditionalHistory(ctx, issueKey, issueWithHistory.Changelog.MaxResults)
 			if err == nil {
 				statusChanges = append(statusChanges, additionalChanges...)
 			}
@@ -534,14 +978,16 @@ func (c *JiraClient) GetIssueStatusChanges(issueKey string) ([]StatusChange, err
 }
 
 // getIssueWithChangelog fetches an issue with its changelog expanded.
-func (c *JiraClient) getIssueWithChangelog(issueKey string) (*IssueWithChangelog, error) {
+func (c *JiraClient) getIssueWithChangelog(ctx context.Context, issueKey string) (*IssueWithChangelog, error) {
 	path := fmt.Sprintf("/issue/%s?expand=changelog", issueKey)
 	
-	ctx := context.Background()
-	var httpRes *http.Response
-	var err error
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
+	}
 	
-	if c.installationType == jira.InstallationTypeLocal {
+	var httpRes *http.Response
+	if local {
 		httpRes, err = c.client.GetV2(ctx, path, nil)
 	} else {
 		httpRes, err = c.client.Get(ctx, path, nil)
@@ -551,12 +997,12 @@ func (c *JiraClient) getIssueWithChangelog(issueKey string) (*IssueWithChangelog
 		return nil, err
 	}
 	if httpRes == nil {
-		return nil, fmt.Errorf("empty response")
+		return nil, jira.ErrEmptyResponse
 	}
 	defer httpRes.Body.Close()
 	
 	if httpRes.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", httpRes.StatusCode)
+		return nil, jira.NewErrUnexpectedResponse(httpRes)
 	}
 	
 	var issue IssueWithChangelog
@@ -569,10 +1015,14 @@ func (c *JiraClient) getIssueWithChangelog(issueKey string) (*IssueWithChangelog
 }
 
 // fetchAdditionalHistory fetches additional history pages if changelog is paginated.
-func (c *JiraClient) fetchAdditionalHistory(issueKey string, startAt int) ([]StatusChange, error) {
+func (c *JiraClient) fetchAdditionalHistory(ctx context.Context, issueKey string, startAt int) ([]StatusChange, error) {
 	var allChanges []StatusChange
 	currentStart := startAt
-	ctx := context.Background()
+	
+	local, err := c.isLocal(ctx)
+	if err != nil {
+		return nil, err
+	}
 	
 	for {
 		path := fmt.Sprintf("/issue/%s/changelog?startAt=%d", issueKey, currentStart)
@@ -580,7 +1030,7 @@ func (c *JiraClient) fetchAdditionalHistory(issueKey string, startAt int) ([]Sta
 		var httpRes *http.Response
 		var err error
 		
-		if c.installationType == jira.InstallationTypeLocal {
+		if local {
 			httpRes, err = c.client.GetV2(ctx, path, nil)
 		} else {
 			httpRes, err = c.client.Get(ctx, path, nil)
@@ -590,12 +1040,12 @@ func (c *JiraClient) fetchAdditionalHistory(issueKey string, startAt int) ([]Sta
 			return allChanges, err
 		}
 		if httpRes == nil {
-			return allChanges, fmt.Errorf("empty response")
+			return allChanges, jira.ErrEmptyResponse
 		}
 		defer httpRes.Body.Close()
 		
 		if httpRes.StatusCode != http.StatusOK {
-			return allChanges, fmt.Errorf("unexpected status code: %d", httpRes.StatusCode)
+			return allChanges, jira.NewErrUnexpectedResponse(httpRes)
 		}
 		
 		var changelog IssueChangelog
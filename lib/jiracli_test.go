@@ -1,9 +1,18 @@
 package lib
 
 import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/eliziario/jira-lib/pkg/config"
+	"github.com/eliziario/jira-lib/pkg/credentials"
+	"github.com/eliziario/jira-lib/pkg/jira"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,22 +89,55 @@ func TestNewClient(t *testing.T) {
 			},
 			wantErr: false,
 		},
-		// Skip MTLS test as it tries to load actual certificates
-		// {
-		// 	name: "with mtls config",
-		// 	config: ClientConfig{
-		// 		Server:   "https://jira.local.com",
-		// 		Login:    "username",
-		// 		APIToken: "token",
-		// 		AuthType: "mtls",
-		// 		MTLSConfig: &MTLSConfig{
-		// 			CaCert:     "/path/to/ca.crt",
-		// 			ClientCert: "/path/to/client.crt",
-		// 			ClientKey:  "/path/to/client.key",
-		// 		},
-		// 	},
-		// 	wantErr: false,
-		// },
+		{
+			name: "with credentials provider",
+			config: ClientConfig{
+				Server:      "https://test.atlassian.net",
+				Credentials: credentials.Static{Login: "test@example.com", Token: "test-token"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "with oauth2 auth",
+			config: ClientConfig{
+				AuthType:    "oauth2",
+				CloudID:     "cloud-123",
+				TokenSource: jira.StaticTokenSource(&jira.Token{AccessToken: "access"}),
+			},
+			wantErr: false,
+		},
+		{
+			name: "oauth2 without token source",
+			config: ClientConfig{
+				AuthType: "oauth2",
+				CloudID:  "cloud-123",
+			},
+			wantErr: true,
+			errMsg:  "token source is required",
+		},
+		{
+			name: "with mtls missing certificates",
+			config: ClientConfig{
+				Server:   "https://jira.local.com",
+				Login:    "username",
+				AuthType: "mtls",
+				MTLSConfig: &MTLSConfig{
+					CaCert:     "/path/to/ca.crt",
+					ClientCert: "/path/to/client.crt",
+					ClientKey:  "/path/to/client.key",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "mtls without config",
+			config: ClientConfig{
+				Server:   "https://jira.local.com",
+				AuthType: "mtls",
+			},
+			wantErr: true,
+			errMsg:  "mTLS config is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,10 +156,13 @@ func TestNewClient(t *testing.T) {
 				assert.NotNil(t, client.GetRawClient())
 				
 				// Verify installation type is set correctly
-				if tt.config.InstallationType != "" {
+				switch {
+				case tt.config.InstallationType != "":
 					assert.Equal(t, tt.config.InstallationType, client.installationType)
-				} else {
+				case tt.config.CloudID != "":
 					assert.Equal(t, "Cloud", client.installationType)
+				default:
+					assert.Empty(t, client.installationType)
 				}
 			}
 		})
@@ -136,6 +181,253 @@ func TestClientConfigDefaults(t *testing.T) {
 	assert.NotNil(t, client)
 	
 	// Check defaults were applied
-	assert.Equal(t, "Cloud", client.installationType)
+	// Installation type is detected on first use
+	assert.Empty(t, client.installationType)
 	// AuthType default is checked internally as "basic"
-}
\ No newline at end of file
+}
+func TestNewClientResolvesCredentialsLazily(t *testing.T) {
+	var resolved int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "test@example.com", login)
+		assert.Equal(t, "test-token", token)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"key": "TEST-1"}`))
+	}))
+	defer server.Close()
+
+	provider := credentials.ProviderFunc(func(ctx context.Context, srv, login string) (*credentials.Credentials, error) {
+		resolved++
+		return &credentials.Credentials{Login: "test@example.com", Token: "test-token"}, nil
+	})
+
+	client, err := NewClient(ClientConfig{Server: server.URL, InstallationType: "Cloud", Credentials: provider})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+
+	for range 2 {
+		_, err = client.GetIssue("TEST-1")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, resolved)
+
+	// Missing credentials fail the requests.
+	client, err = NewClient(ClientConfig{Server: server.URL, InstallationType: "Cloud", Login: "test@example.com", Credentials: credentials.Chain()})
+	assert.NoError(t, err)
+
+	_, err = client.GetIssue("TEST-1")
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
+
+func TestGetAllIssuesFollowsPageToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("nextPageToken") == "" {
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-1"}, {"key": "TEST-2"}], "nextPageToken": "next"}`))
+		} else {
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-3"}], "isLast": true}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		Server:           server.URL,
+		Login:            "test@example.com",
+		APIToken:         "test-token",
+		InstallationType: jira.InstallationTypeCloud,
+	})
+	assert.NoError(t, err)
+
+	issues, err := client.GetAllIssues(GetAllIssuesOptions{Project: "TEST"})
+	assert.NoError(t, err)
+	assert.Len(t, issues, 3)
+
+	issues, err = client.GetAllIssues(GetAllIssuesOptions{Project: "TEST", MaxResults: 2})
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+}
+
+func TestGetAllIssuesReportsProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/rest/api/3/search/approximate-count":
+			_, _ = w.Write([]byte(`{"count": 3}`))
+		case "/rest/api/3/search/jql":
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-1"}, {"key": "TEST-2"}, {"key": "TEST-3"}], "isLast": true}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		Server:           server.URL,
+		Login:            "test@example.com",
+		APIToken:         "test-token",
+		InstallationType: jira.InstallationTypeCloud,
+	})
+	assert.NoError(t, err)
+
+	count, err := client.Count("project = TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	var progress [][2]int
+	_, err = client.GetAllIssues(GetAllIssuesOptions{
+		Project:    "TEST",
+		MaxResults: 2,
+		Progress: func(fetched, total int) {
+			progress = append(progress, [2]int{fetched, total})
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{1, 2}, {2, 2}}, progress)
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	var body []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/rest/agile/1.0/board/42/sprint":
+			_, _ = w.Write([]byte(`{"values": [{"id": 1, "name": "Sprint 1"}], "isLast": true}`))
+		case "/rest/api/3/issue":
+			body, _ = io.ReadAll(r.Body)
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id": "10001", "key": "TEST-1"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	data := "profiles:\n" +
+		"  work:\n" +
+		"    server: " + server.URL + "\n" +
+		"    login: test@example.com\n" +
+		"    api_token: test-token\n" +
+		"    installation: Cloud\n" +
+		"    project: TEST\n" +
+		"    board: 42\n" +
+		"    custom_fields:\n" +
+		"      story-points:\n" +
+		"        id: customfield_10016\n" +
+		"        type: number\n"
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	t.Setenv(config.EnvConfigFile, path)
+	t.Setenv(config.EnvProfile, "")
+
+	client, err := NewClientFromProfile("work")
+	assert.NoError(t, err)
+
+	sprints, err := client.GetSprints(0, "", 0, 50)
+	assert.NoError(t, err)
+	assert.Len(t, sprints.Sprints, 1)
+
+	req := &jira.CreateRequest{
+		IssueType:    "Story",
+		Summary:      "Test",
+		CustomFields: map[string]string{"story-points": "5"},
+	}
+	res, err := client.CreateIssue(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-1", res.Key)
+	assert.Contains(t, string(body), `"project":{"key":"TEST"}`)
+	assert.Contains(t, string(body), `"customfield_10016":5`)
+
+	// The request is not modified.
+	assert.Empty(t, req.Project)
+	assert.False(t, req.HasCustomFields())
+
+	// The custom fields of the request are kept.
+	req.WithCustomFields([]jira.IssueTypeField{{Name: "Story Points", Key: "customfield_10024"}})
+	_, err = client.CreateIssue(req)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"customfield_10024":"5"`)
+	assert.NotContains(t, string(body), "customfield_10016")
+
+	_, err = NewClientFromProfile("missing")
+	assert.ErrorIs(t, err, config.ErrProfileNotFound)
+}
+
+func TestDetectInstallationType(t *testing.T) {
+	var infoRequests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			infoRequests++
+			_, _ = w.Write([]byte(`{"version": "9.4.0", "versionNumbers": [9, 4, 0], "deploymentType": "DataCenter"}`))
+		case "/rest/api/2/issue/TEST-1":
+			_, _ = w.Write([]byte(`{"key": "TEST-1"}`))
+		case "/rest/api/2/issue/createmeta/TEST/issuetypes":
+			_, _ = w.Write([]byte(`{"values": [{"id": "1", "name": "Bug"}, {"id": "2", "name": "Sub-task", "subtask": true}]}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		Server:   server.URL,
+		Login:    "test@example.com",
+		APIToken: "test-token",
+	})
+	assert.NoError(t, err)
+
+	issue, err := client.GetIssue("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-1", issue.Key)
+
+	meta, err := client.GetCreateMeta(&jira.CreateMetaRequest{Projects: "TEST"})
+	assert.NoError(t, err)
+	assert.Len(t, meta.Projects, 1)
+	assert.Equal(t, "TEST", meta.Projects[0].Key)
+	assert.Len(t, meta.Projects[0].IssueTypes, 2)
+	assert.True(t, meta.Projects[0].IssueTypes[1].Subtask)
+
+	caps, err := client.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, jira.InstallationTypeLocal, caps.InstallationType)
+	assert.Equal(t, 1, infoRequests)
+}
+
+func TestDetectInstallationTypeFallsBackToCloud(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			w.WriteHeader(http.StatusForbidden)
+		case "/rest/api/3/issue/TEST-1":
+			_, _ = w.Write([]byte(`{"key": "TEST-1"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(ClientConfig{
+		Server:   server.URL,
+		Login:    "test@example.com",
+		APIToken: "test-token",
+	})
+	assert.NoError(t, err)
+
+	issue, err := client.GetIssue("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-1", issue.Key)
+}
@@ -0,0 +1,432 @@
+// Package mocks provides fakes of the lib client interfaces for unit tests.
+//
+// Each mock has a function field per method. Methods without a context call
+// the function of their Context variant with context.Background(), so only
+// the Context variants need to be set. Calling a method whose function is
+// nil returns ErrNotMocked.
+//
+//	client := &mocks.Client{}
+//	client.GetIssueFunc = func(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error) {
+//		return &jira.Issue{Key: key}, nil
+//	}
+//
+//	report := NewReport(client) // accepts a lib.Client
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/eliziario/jira-lib/lib"
+	"github.com/eliziario/jira-lib/pkg/jira"
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// ErrNotMocked is returned by methods whose function isn't set.
+var ErrNotMocked = fmt.Errorf("mocks: method not mocked")
+
+func notMocked(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotMocked, method)
+}
+
+var (
+	_ lib.Client         = (*Client)(nil)
+	_ lib.IssueService   = (*IssueService)(nil)
+	_ lib.SearchService  = (*SearchService)(nil)
+	_ lib.SprintService  = (*SprintService)(nil)
+	_ lib.ProjectService = (*ProjectService)(nil)
+	_ lib.UserService    = (*UserService)(nil)
+)
+
+// Client is a mock of lib.Client.
+type Client struct {
+	IssueService
+	SearchService
+	SprintService
+	ProjectService
+	UserService
+
+	GetServerInfoFunc func(ctx context.Context) (*jira.ServerInfo, error)
+	CapabilitiesFunc  func(ctx context.Context) (*jira.Capabilities, error)
+}
+
+// GetServerInfo calls GetServerInfoFunc.
+func (m *Client) GetServerInfo() (*jira.ServerInfo, error) {
+	return m.GetServerInfoContext(context.Background())
+}
+
+// GetServerInfoContext calls GetServerInfoFunc.
+func (m *Client) GetServerInfoContext(ctx context.Context) (*jira.ServerInfo, error) {
+	if m.GetServerInfoFunc == nil {
+		return nil, notMocked("GetServerInfo")
+	}
+	return m.GetServerInfoFunc(ctx)
+}
+
+// Capabilities calls CapabilitiesFunc.
+func (m *Client) Capabilities() (*jira.Capabilities, error) {
+	return m.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext calls CapabilitiesFunc.
+func (m *Client) CapabilitiesContext(ctx context.Context) (*jira.Capabilities, error) {
+	if m.CapabilitiesFunc == nil {
+		return nil, notMocked("Capabilities")
+	}
+	return m.CapabilitiesFunc(ctx)
+}
+
+// IssueService is a mock of lib.IssueService.
+type IssueService struct {
+	GetIssueFunc              func(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error)
+	CreateIssueFunc           func(ctx context.Context, request *jira.CreateRequest) (*jira.CreateResponse, error)
+	UpdateIssueFunc           func(ctx context.Context, key string, request *jira.EditRequest) error
+	DeleteIssueFunc           func(ctx context.Context, key string, cascade bool) error
+	AssignIssueFunc           func(ctx context.Context, key string, assignee string) error
+	TransitionIssueFunc       func(ctx context.Context, key string, request *jira.TransitionRequest) error
+	AddCommentFunc            func(ctx context.Context, key string, comment string, internal bool) error
+	GetTransitionsFunc        func(ctx context.Context, key string) ([]*jira.Transition, error)
+	GetCreateMetaFunc         func(ctx context.Context, request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error)
+	GetIssueStatusChangesFunc func(ctx context.Context, issueKey string) ([]lib.StatusChange, error)
+}
+
+// GetIssue calls GetIssueFunc.
+func (m *IssueService) GetIssue(key string, opts ...filter.Filter) (*jira.Issue, error) {
+	return m.GetIssueContext(context.Background(), key, opts...)
+}
+
+// GetIssueContext calls GetIssueFunc.
+func (m *IssueService) GetIssueContext(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error) {
+	if m.GetIssueFunc == nil {
+		return nil, notMocked("GetIssue")
+	}
+	return m.GetIssueFunc(ctx, key, opts...)
+}
+
+// CreateIssue calls CreateIssueFunc.
+func (m *IssueService) CreateIssue(request *jira.CreateRequest) (*jira.CreateResponse, error) {
+	return m.CreateIssueContext(context.Background(), request)
+}
+
+// CreateIssueContext calls CreateIssueFunc.
+func (m *IssueService) CreateIssueContext(ctx context.Context, request *jira.CreateRequest) (*jira.CreateResponse, error) {
+	if m.CreateIssueFunc == nil {
+		return nil, notMocked("CreateIssue")
+	}
+	return m.CreateIssueFunc(ctx, request)
+}
+
+// UpdateIssue calls UpdateIssueFunc.
+func (m *IssueService) UpdateIssue(key string, request *jira.EditRequest) error {
+	return m.UpdateIssueContext(context.Background(), key, request)
+}
+
+// UpdateIssueContext calls UpdateIssueFunc.
+func (m *IssueService) UpdateIssueContext(ctx context.Context, key string, request *jira.EditRequest) error {
+	if m.UpdateIssueFunc == nil {
+		return notMocked("UpdateIssue")
+	}
+	return m.UpdateIssueFunc(ctx, key, request)
+}
+
+// DeleteIssue calls DeleteIssueFunc.
+func (m *IssueService) DeleteIssue(key string, cascade bool) error {
+	return m.DeleteIssueContext(context.Background(), key, cascade)
+}
+
+// DeleteIssueContext calls DeleteIssueFunc.
+func (m *IssueService) DeleteIssueContext(ctx context.Context, key string, cascade bool) error {
+	if m.DeleteIssueFunc == nil {
+		return notMocked("DeleteIssue")
+	}
+	return m.DeleteIssueFunc(ctx, key, cascade)
+}
+
+// AssignIssue calls AssignIssueFunc.
+func (m *IssueService) AssignIssue(key string, assignee string) error {
+	return m.AssignIssueContext(context.Background(), key, assignee)
+}
+
+// AssignIssueContext calls AssignIssueFunc.
+func (m *IssueService) AssignIssueContext(ctx context.Context, key string, assignee string) error {
+	if m.AssignIssueFunc == nil {
+		return notMocked("AssignIssue")
+	}
+	return m.AssignIssueFunc(ctx, key, assignee)
+}
+
+// TransitionIssue calls TransitionIssueFunc.
+func (m *IssueService) TransitionIssue(key string, request *jira.TransitionRequest) error {
+	return m.TransitionIssueContext(context.Background(), key, request)
+}
+
+// TransitionIssueContext calls TransitionIssueFunc.
+func (m *IssueService) TransitionIssueContext(ctx context.Context, key string, request *jira.TransitionRequest) error {
+	if m.TransitionIssueFunc == nil {
+		return notMocked("TransitionIssue")
+	}
+	return m.TransitionIssueFunc(ctx, key, request)
+}
+
+// AddComment calls AddCommentFunc.
+func (m *IssueService) AddComment(key string, comment string, internal bool) error {
+	return m.AddCommentContext(context.Background(), key, comment, internal)
+}
+
+// AddCommentContext calls AddCommentFunc.
+func (m *IssueService) AddCommentContext(ctx context.Context, key string, comment string, internal bool) error {
+	if m.AddCommentFunc == nil {
+		return notMocked("AddComment")
+	}
+	return m.AddCommentFunc(ctx, key, comment, internal)
+}
+
+// GetTransitions calls GetTransitionsFunc.
+func (m *IssueService) GetTransitions(key string) ([]*jira.Transition, error) {
+	return m.GetTransitionsContext(context.Background(), key)
+}
+
+// GetTransitionsContext calls GetTransitionsFunc.
+func (m *IssueService) GetTransitionsContext(ctx context.Context, key string) ([]*jira.Transition, error) {
+	if m.GetTransitionsFunc == nil {
+		return nil, notMocked("GetTransitions")
+	}
+	return m.GetTransitionsFunc(ctx, key)
+}
+
+// GetCreateMeta calls GetCreateMetaFunc.
+func (m *IssueService) GetCreateMeta(request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error) {
+	return m.GetCreateMetaContext(context.Background(), request)
+}
+
+// GetCreateMetaContext calls GetCreateMetaFunc.
+func (m *IssueService) GetCreateMetaContext(ctx context.Context, request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error) {
+	if m.GetCreateMetaFunc == nil {
+		return nil, notMocked("GetCreateMeta")
+	}
+	return m.GetCreateMetaFunc(ctx, request)
+}
+
+// GetIssueStatusChanges calls GetIssueStatusChangesFunc.
+func (m *IssueService) GetIssueStatusChanges(issueKey string) ([]lib.StatusChange, error) {
+	return m.GetIssueStatusChangesContext(context.Background(), issueKey)
+}
+
+// GetIssueStatusChangesContext calls GetIssueStatusChangesFunc.
+func (m *IssueService) GetIssueStatusChangesContext(ctx context.Context, issueKey string) ([]lib.StatusChange, error) {
+	if m.GetIssueStatusChangesFunc == nil {
+		return nil, notMocked("GetIssueStatusChanges")
+	}
+	return m.GetIssueStatusChangesFunc(ctx, issueKey)
+}
+
+// SearchService is a mock of lib.SearchService.
+type SearchService struct {
+	SearchIssuesFunc         func(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error)
+	IterateIssuesFunc        func(ctx context.Context, jql string, opts ...filter.Filter) iter.Seq2[*jira.Issue, error]
+	CountFunc                func(ctx context.Context, jql string) (int, error)
+	GetAllIssuesFunc         func(ctx context.Context, options lib.GetAllIssuesOptions) ([]*jira.Issue, error)
+	GetIssuesByDateRangeFunc func(ctx context.Context, startDate, endDate string, dateField string) ([]*jira.Issue, error)
+	GetRecentIssuesFunc      func(ctx context.Context, days int, project string) ([]*jira.Issue, error)
+}
+
+// SearchIssues calls SearchIssuesFunc.
+func (m *SearchService) SearchIssues(jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error) {
+	return m.SearchIssuesContext(context.Background(), jql, from, limit, opts...)
+}
+
+// SearchIssuesContext calls SearchIssuesFunc.
+func (m *SearchService) SearchIssuesContext(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error) {
+	if m.SearchIssuesFunc == nil {
+		return nil, notMocked("SearchIssues")
+	}
+	return m.SearchIssuesFunc(ctx, jql, from, limit, opts...)
+}
+
+// IterateIssues calls IterateIssuesFunc. If it isn't set, the iteration
+// yields ErrNotMocked.
+func (m *SearchService) IterateIssues(ctx context.Context, jql string, opts ...filter.Filter) iter.Seq2[*jira.Issue, error] {
+	if m.IterateIssuesFunc == nil {
+		return func(yield func(*jira.Issue, error) bool) {
+			yield(nil, notMocked("IterateIssues"))
+		}
+	}
+	return m.IterateIssuesFunc(ctx, jql, opts...)
+}
+
+// Count calls CountFunc.
+func (m *SearchService) Count(jql string) (int, error) {
+	return m.CountContext(context.Background(), jql)
+}
+
+// CountContext calls CountFunc.
+func (m *SearchService) CountContext(ctx context.Context, jql string) (int, error) {
+	if m.CountFunc == nil {
+		return 0, notMocked("Count")
+	}
+	return m.CountFunc(ctx, jql)
+}
+
+// GetAllIssues calls GetAllIssuesFunc.
+func (m *SearchService) GetAllIssues(options lib.GetAllIssuesOptions) ([]*jira.Issue, error) {
+	return m.GetAllIssuesContext(context.Background(), options)
+}
+
+// GetAllIssuesContext calls GetAllIssuesFunc.
+func (m *SearchService) GetAllIssuesContext(ctx context.Context, options lib.GetAllIssuesOptions) ([]*jira.Issue, error) {
+	if m.GetAllIssuesFunc == nil {
+		return nil, notMocked("GetAllIssues")
+	}
+	return m.GetAllIssuesFunc(ctx, options)
+}
+
+// GetIssuesByDateRange calls GetIssuesByDateRangeFunc.
+func (m *SearchService) GetIssuesByDateRange(startDate, endDate string, dateField string) ([]*jira.Issue, error) {
+	return m.GetIssuesByDateRangeContext(context.Background(), startDate, endDate, dateField)
+}
+
+// GetIssuesByDateRangeContext calls GetIssuesByDateRangeFunc.
+func (m *SearchService) GetIssuesByDateRangeContext(ctx context.Context, startDate, endDate string, dateField string) ([]*jira.Issue, error) {
+	if m.GetIssuesByDateRangeFunc == nil {
+		return nil, notMocked("GetIssuesByDateRange")
+	}
+	return m.GetIssuesByDateRangeFunc(ctx, startDate, endDate, dateField)
+}
+
+// GetRecentIssues calls GetRecentIssuesFunc.
+func (m *SearchService) GetRecentIssues(days int, project string) ([]*jira.Issue, error) {
+	return m.GetRecentIssuesContext(context.Background(), days, project)
+}
+
+// GetRecentIssuesContext calls GetRecentIssuesFunc.
+func (m *SearchService) GetRecentIssuesContext(ctx context.Context, days int, project string) ([]*jira.Issue, error) {
+	if m.GetRecentIssuesFunc == nil {
+		return nil, notMocked("GetRecentIssues")
+	}
+	return m.GetRecentIssuesFunc(ctx, days, project)
+}
+
+// SprintService is a mock of lib.SprintService.
+type SprintService struct {
+	GetBoardsFunc       func(ctx context.Context, project string, boardType string) (*jira.BoardResult, error)
+	GetSprintsFunc      func(ctx context.Context, boardID int, state string, from, limit int) (*jira.SprintResult, error)
+	GetSprintIssuesFunc func(ctx context.Context, sprintID int, jql string, from, limit uint) (*jira.SearchResult, error)
+	GetEpicsFunc        func(ctx context.Context, project string, from, limit uint) (*jira.SearchResult, error)
+	GetEpicIssuesFunc   func(ctx context.Context, epicKey, jql string, from, limit uint) (*jira.SearchResult, error)
+}
+
+// GetBoards calls GetBoardsFunc.
+func (m *SprintService) GetBoards(project string, boardType string) (*jira.BoardResult, error) {
+	return m.GetBoardsContext(context.Background(), project, boardType)
+}
+
+// GetBoardsContext calls GetBoardsFunc.
+func (m *SprintService) GetBoardsContext(ctx context.Context, project string, boardType string) (*jira.BoardResult, error) {
+	if m.GetBoardsFunc == nil {
+		return nil, notMocked("GetBoards")
+	}
+	return m.GetBoardsFunc(ctx, project, boardType)
+}
+
+// GetSprints calls GetSprintsFunc.
+func (m *SprintService) GetSprints(boardID int, state string, from, limit int) (*jira.SprintResult, error) {
+	return m.GetSprintsContext(context.Background(), boardID, state, from, limit)
+}
+
+// GetSprintsContext calls GetSprintsFunc.
+func (m *SprintService) GetSprintsContext(ctx context.Context, boardID int, state string, from, limit int) (*jira.SprintResult, error) {
+	if m.GetSprintsFunc == nil {
+		return nil, notMocked("GetSprints")
+	}
+	return m.GetSprintsFunc(ctx, boardID, state, from, limit)
+}
+
+// GetSprintIssues calls GetSprintIssuesFunc.
+func (m *SprintService) GetSprintIssues(sprintID int, jql string, from, limit uint) (*jira.SearchResult, error) {
+	return m.GetSprintIssuesContext(context.Background(), sprintID, jql, from, limit)
+}
+
+// GetSprintIssuesContext calls GetSprintIssuesFunc.
+func (m *SprintService) GetSprintIssuesContext(ctx context.Context, sprintID int, jql string, from, limit uint) (*jira.SearchResult, error) {
+	if m.GetSprintIssuesFunc == nil {
+		return nil, notMocked("GetSprintIssues")
+	}
+	return m.GetSprintIssuesFunc(ctx, sprintID, jql, from, limit)
+}
+
+// GetEpics calls GetEpicsFunc.
+func (m *SprintService) GetEpics(project string, from, limit uint) (*jira.SearchResult, error) {
+	return m.GetEpicsContext(context.Background(), project, from, limit)
+}
+
+// GetEpicsContext calls GetEpicsFunc.
+func (m *SprintService) GetEpicsContext(ctx context.Context, project string, from, limit uint) (*jira.SearchResult, error) {
+	if m.GetEpicsFunc == nil {
+		return nil, notMocked("GetEpics")
+	}
+	return m.GetEpicsFunc(ctx, project, from, limit)
+}
+
+// GetEpicIssues calls GetEpicIssuesFunc.
+func (m *SprintService) GetEpicIssues(epicKey, jql string, from, limit uint) (*jira.SearchResult, error) {
+	return m.GetEpicIssuesContext(context.Background(), epicKey, jql, from, limit)
+}
+
+// GetEpicIssuesContext calls GetEpicIssuesFunc.
+func (m *SprintService) GetEpicIssuesContext(ctx context.Context, epicKey, jql string, from, limit uint) (*jira.SearchResult, error) {
+	if m.GetEpicIssuesFunc == nil {
+		return nil, notMocked("GetEpicIssues")
+	}
+	return m.GetEpicIssuesFunc(ctx, epicKey, jql, from, limit)
+}
+
+// ProjectService is a mock of lib.ProjectService.
+type ProjectService struct {
+	GetProjectsFunc func(ctx context.Context) ([]*jira.Project, error)
+	GetProjectFunc  func(ctx context.Context, key string) (*jira.Project, error)
+}
+
+// GetProjects calls GetProjectsFunc.
+func (m *ProjectService) GetProjects() ([]*jira.Project, error) {
+	return m.GetProjectsContext(context.Background())
+}
+
+// GetProjectsContext calls GetProjectsFunc.
+func (m *ProjectService) GetProjectsContext(ctx context.Context) ([]*jira.Project, error) {
+	if m.GetProjectsFunc == nil {
+		return nil, notMocked("GetProjects")
+	}
+	return m.GetProjectsFunc(ctx)
+}
+
+// GetProject calls GetProjectFunc.
+func (m *ProjectService) GetProject(key string) (*jira.Project, error) {
+	return m.GetProjectContext(context.Background(), key)
+}
+
+// GetProjectContext calls GetProjectFunc.
+func (m *ProjectService) GetProjectContext(ctx context.Context, key string) (*jira.Project, error) {
+	if m.GetProjectFunc == nil {
+		return nil, notMocked("GetProject")
+	}
+	return m.GetProjectFunc(ctx, key)
+}
+
+// UserService is a mock of lib.UserService.
+type UserService struct {
+	GetMyselfFunc func(ctx context.Context) (*jira.Me, error)
+}
+
+// GetMyself calls GetMyselfFunc.
+func (m *UserService) GetMyself() (*jira.Me, error) {
+	return m.GetMyselfContext(context.Background())
+}
+
+// GetMyselfContext calls GetMyselfFunc.
+func (m *UserService) GetMyselfContext(ctx context.Context) (*jira.Me, error) {
+	if m.GetMyselfFunc == nil {
+		return nil, notMocked("GetMyself")
+	}
+	return m.GetMyselfFunc(ctx)
+}
@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/lib"
+	"github.com/eliziario/jira-lib/pkg/jira"
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// openBugs is an example of code depending on a service instead of lib.JiraClient.
+func openBugs(s lib.SearchService, project string) (int, error) {
+	return s.Count("project = " + project + " AND type = Bug AND resolution IS EMPTY")
+}
+
+func TestClient(t *testing.T) {
+	type ctxKey struct{}
+
+	var gotKey string
+	client := &Client{}
+	client.GetIssueFunc = func(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error) {
+		gotKey = key
+		assert.Nil(t, ctx.Value(ctxKey{}))
+		return &jira.Issue{Key: key}, nil
+	}
+	client.CountFunc = func(_ context.Context, jql string) (int, error) {
+		assert.Equal(t, "project = TEST AND type = Bug AND resolution IS EMPTY", jql)
+		return 3, nil
+	}
+	client.GetMyselfFunc = func(ctx context.Context) (*jira.Me, error) {
+		assert.Equal(t, "value", ctx.Value(ctxKey{}))
+		return &jira.Me{Name: "Jane Doe"}, nil
+	}
+
+	iss, err := client.GetIssue("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-1", iss.Key)
+	assert.Equal(t, "TEST-1", gotKey)
+
+	n, err := openBugs(client, "TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	me, err := client.GetMyselfContext(context.WithValue(context.Background(), ctxKey{}, "value"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", me.Name)
+}
+
+func TestNotMocked(t *testing.T) {
+	client := &Client{}
+
+	_, err := client.GetProject("TEST")
+	assert.ErrorIs(t, err, ErrNotMocked)
+	assert.EqualError(t, err, "mocks: method not mocked: GetProject")
+
+	assert.ErrorIs(t, client.AssignIssue("TEST-1", "jane"), ErrNotMocked)
+
+	_, err = client.Capabilities()
+	assert.ErrorIs(t, err, ErrNotMocked)
+
+	for iss, err := range client.IterateIssues(context.Background(), "project = TEST") {
+		assert.Nil(t, iss)
+		assert.ErrorIs(t, err, ErrNotMocked)
+	}
+}
@@ -0,0 +1,94 @@
+package lib
+
+import (
+	"context"
+	"iter"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// IssueService reads and modifies single issues.
+type IssueService interface {
+	GetIssue(key string, opts ...filter.Filter) (*jira.Issue, error)
+	GetIssueContext(ctx context.Context, key string, opts ...filter.Filter) (*jira.Issue, error)
+	CreateIssue(request *jira.CreateRequest) (*jira.CreateResponse, error)
+	CreateIssueContext(ctx context.Context, request *jira.CreateRequest) (*jira.CreateResponse, error)
+	UpdateIssue(key string, request *jira.EditRequest) error
+	UpdateIssueContext(ctx context.Context, key string, request *jira.EditRequest) error
+	DeleteIssue(key string, cascade bool) error
+	DeleteIssueContext(ctx context.Context, key string, cascade bool) error
+	AssignIssue(key string, assignee string) error
+	AssignIssueContext(ctx context.Context, key string, assignee string) error
+	TransitionIssue(key string, request *jira.TransitionRequest) error
+	TransitionIssueContext(ctx context.Context, key string, request *jira.TransitionRequest) error
+	AddComment(key string, comment string, internal bool) error
+	AddCommentContext(ctx context.Context, key string, comment string, internal bool) error
+	GetTransitions(key string) ([]*jira.Transition, error)
+	GetTransitionsContext(ctx context.Context, key string) ([]*jira.Transition, error)
+	GetCreateMeta(request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error)
+	GetCreateMetaContext(ctx context.Context, request *jira.CreateMetaRequest) (*jira.CreateMetaResponse, error)
+	GetIssueStatusChanges(issueKey string) ([]StatusChange, error)
+	GetIssueStatusChangesContext(ctx context.Context, issueKey string) ([]StatusChange, error)
+}
+
+// SearchService searches issues with JQL.
+type SearchService interface {
+	SearchIssues(jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error)
+	SearchIssuesContext(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*jira.SearchResult, error)
+	IterateIssues(ctx context.Context, jql string, opts ...filter.Filter) iter.Seq2[*jira.Issue, error]
+	Count(jql string) (int, error)
+	CountContext(ctx context.Context, jql string) (int, error)
+	GetAllIssues(options GetAllIssuesOptions) ([]*jira.Issue, error)
+	GetAllIssuesContext(ctx context.Context, options GetAllIssuesOptions) ([]*jira.Issue, error)
+	GetIssuesByDateRange(startDate, endDate string, dateField string) ([]*jira.Issue, error)
+	GetIssuesByDateRangeContext(ctx context.Context, startDate, endDate string, dateField string) ([]*jira.Issue, error)
+	GetRecentIssues(days int, project string) ([]*jira.Issue, error)
+	GetRecentIssuesContext(ctx context.Context, days int, project string) ([]*jira.Issue, error)
+}
+
+// SprintService lists boards, sprints and epics, and the issues in them.
+type SprintService interface {
+	GetBoards(project string, boardType string) (*jira.BoardResult, error)
+	GetBoardsContext(ctx context.Context, project string, boardType string) (*jira.BoardResult, error)
+	GetSprints(boardID int, state string, from, limit int) (*jira.SprintResult, error)
+	GetSprintsContext(ctx context.Context, boardID int, state string, from, limit int) (*jira.SprintResult, error)
+	GetSprintIssues(sprintID int, jql string, from, limit uint) (*jira.SearchResult, error)
+	GetSprintIssuesContext(ctx context.Context, sprintID int, jql string, from, limit uint) (*jira.SearchResult, error)
+	GetEpics(project string, from, limit uint) (*jira.SearchResult, error)
+	GetEpicsContext(ctx context.Context, project string, from, limit uint) (*jira.SearchResult, error)
+	GetEpicIssues(epicKey, jql string, from, limit uint) (*jira.SearchResult, error)
+	GetEpicIssuesContext(ctx context.Context, epicKey, jql string, from, limit uint) (*jira.SearchResult, error)
+}
+
+// ProjectService lists projects.
+type ProjectService interface {
+	GetProjects() ([]*jira.Project, error)
+	GetProjectsContext(ctx context.Context) ([]*jira.Project, error)
+	GetProject(key string) (*jira.Project, error)
+	GetProjectContext(ctx context.Context, key string) (*jira.Project, error)
+}
+
+// UserService gets the authenticated user.
+type UserService interface {
+	GetMyself() (*jira.Me, error)
+	GetMyselfContext(ctx context.Context) (*jira.Me, error)
+}
+
+// Client is implemented by JiraClient. Depend on it, or on one of the
+// services it groups, to replace the client with a fake in tests, see
+// the mocks package.
+type Client interface {
+	IssueService
+	SearchService
+	SprintService
+	ProjectService
+	UserService
+
+	GetServerInfo() (*jira.ServerInfo, error)
+	GetServerInfoContext(ctx context.Context) (*jira.ServerInfo, error)
+	Capabilities() (*jira.Capabilities, error)
+	CapabilitiesContext(ctx context.Context) (*jira.Capabilities, error)
+}
+
+var _ Client = (*JiraClient)(nil)
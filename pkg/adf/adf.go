@@ -12,34 +12,65 @@ type NodeType string
 const (
 	NodeTypeParent  = NodeType("parent")
 	NodeTypeChild   = NodeType("child")
+	NodeTypeInline  = NodeType("inline")
 	NodeTypeUnknown = NodeType("unknown")
 
-	NodeBlockquote  = NodeType("blockquote")
-	NodeBulletList  = NodeType("bulletList")
-	NodeCodeBlock   = NodeType("codeBlock")
-	NodeHeading     = NodeType("heading")
-	NodeOrderedList = NodeType("orderedList")
-	NodePanel       = NodeType("panel")
-	NodeParagraph   = NodeType("paragraph")
-	NodeTable       = NodeType("table")
-	NodeMedia       = NodeType("media")
-
-	ChildNodeText        = NodeType("text")
-	ChildNodeListItem    = NodeType("listItem")
-	ChildNodeTableRow    = NodeType("tableRow")
-	ChildNodeTableHeader = NodeType("tableHeader")
-	ChildNodeTableCell   = NodeType("tableCell")
-
-	InlineNodeCard      = NodeType("inlineCard")
-	InlineNodeEmoji     = NodeType("emoji")
-	InlineNodeMention   = NodeType("mention")
-	InlineNodeHardBreak = NodeType("hardBreak")
-
-	MarkEm     = NodeType("em")
-	MarkLink   = NodeType("link")
-	MarkCode   = NodeType("code")
-	MarkStrike = NodeType("strike")
-	MarkStrong = NodeType("strong")
+	NodeBlockquote      = NodeType("blockquote")
+	NodeBulletList      = NodeType("bulletList")
+	NodeCodeBlock       = NodeType("codeBlock")
+	NodeHeading         = NodeType("heading")
+	NodeOrderedList     = NodeType("orderedList")
+	NodePanel           = NodeType("panel")
+	NodeParagraph       = NodeType("paragraph")
+	NodeTable           = NodeType("table")
+	NodeMedia           = NodeType("media")
+	NodeRule            = NodeType("rule")
+	NodeExpand          = NodeType("expand")
+	NodeNestedExpand    = NodeType("nestedExpand")
+	NodeMediaSingle     = NodeType("mediaSingle")
+	NodeMediaGroup      = NodeType("mediaGroup")
+	NodeTaskList        = NodeType("taskList")
+	NodeDecisionList    = NodeType("decisionList")
+	NodeLayoutSection   = NodeType("layoutSection")
+	NodeBlockCard       = NodeType("blockCard")
+	NodeEmbedCard       = NodeType("embedCard")
+	NodeExtension       = NodeType("extension")
+	NodeBodiedExtension = NodeType("bodiedExtension")
+
+	ChildNodeText         = NodeType("text")
+	ChildNodeListItem     = NodeType("listItem")
+	ChildNodeTableRow     = NodeType("tableRow")
+	ChildNodeTableHeader  = NodeType("tableHeader")
+	ChildNodeTableCell    = NodeType("tableCell")
+	ChildNodeTaskItem     = NodeType("taskItem")
+	ChildNodeDecisionItem = NodeType("decisionItem")
+	ChildNodeLayoutColumn = NodeType("layoutColumn")
+	ChildNodeCaption      = NodeType("caption")
+
+	InlineNodeCard        = NodeType("inlineCard")
+	InlineNodeEmoji       = NodeType("emoji")
+	InlineNodeMention     = NodeType("mention")
+	InlineNodeHardBreak   = NodeType("hardBreak")
+	InlineNodeDate        = NodeType("date")
+	InlineNodeStatus      = NodeType("status")
+	InlineNodePlaceholder = NodeType("placeholder")
+	InlineNodeMediaInline = NodeType("mediaInline")
+	InlineNodeExtension   = NodeType("inlineExtension")
+
+	MarkEm              = NodeType("em")
+	MarkLink            = NodeType("link")
+	MarkCode            = NodeType("code")
+	MarkStrike          = NodeType("strike")
+	MarkStrong          = NodeType("strong")
+	MarkUnderline       = NodeType("underline")
+	MarkSubSup          = NodeType("subsup")
+	MarkTextColor       = NodeType("textColor")
+	MarkBackgroundColor = NodeType("backgroundColor")
+	MarkAnnotation      = NodeType("annotation")
+	MarkBorder          = NodeType("border")
+	MarkAlignment       = NodeType("alignment")
+	MarkIndentation     = NodeType("indentation")
+	MarkBreakout        = NodeType("breakout")
 )
 
 // TagOpener is a tag opener.
@@ -135,6 +166,18 @@ func ParentNodes() []NodeType {
 		NodeParagraph,
 		NodeTable,
 		NodeMedia,
+		NodeRule,
+		NodeExpand,
+		NodeNestedExpand,
+		NodeMediaSingle,
+		NodeMediaGroup,
+		NodeTaskList,
+		NodeDecisionList,
+		NodeLayoutSection,
+		NodeBlockCard,
+		NodeEmbedCard,
+		NodeExtension,
+		NodeBodiedExtension,
 	}
 }
 
@@ -146,6 +189,25 @@ func ChildNodes() []NodeType {
 		ChildNodeTableRow,
 		ChildNodeTableHeader,
 		ChildNodeTableCell,
+		ChildNodeTaskItem,
+		ChildNodeDecisionItem,
+		ChildNodeLayoutColumn,
+		ChildNodeCaption,
+	}
+}
+
+// InlineNodes returns supported ADF inline nodes other than text.
+func InlineNodes() []NodeType {
+	return []NodeType{
+		InlineNodeCard,
+		InlineNodeEmoji,
+		InlineNodeMention,
+		InlineNodeHardBreak,
+		InlineNodeDate,
+		InlineNodeStatus,
+		InlineNodePlaceholder,
+		InlineNodeMediaInline,
+		InlineNodeExtension,
 	}
 }
 
@@ -159,6 +221,11 @@ func IsChildNode(identifier NodeType) bool {
 	return slices.Contains(ChildNodes(), identifier)
 }
 
+// IsInlineNode checks if the node is an inline node.
+func IsInlineNode(identifier NodeType) bool {
+	return slices.Contains(InlineNodes(), identifier)
+}
+
 // GetADFNodeType returns the type of ADF node.
 func GetADFNodeType(identifier NodeType) NodeType {
 	if IsParentNode(identifier) {
@@ -167,9 +234,36 @@ func GetADFNodeType(identifier NodeType) NodeType {
 	if IsChildNode(identifier) {
 		return NodeTypeChild
 	}
+	if IsInlineNode(identifier) {
+		return NodeTypeInline
+	}
 	return NodeTypeUnknown
 }
 
+// UnknownNodeTranslator is implemented by translators that render nodes
+// of unknown types, eg: nodes added to the spec after this package. The
+// content is the translation of the children of the node.
+type UnknownNodeTranslator interface {
+	Unknown(n *Node, content string) string
+}
+
+// TextTranslator is implemented by translators that handle the text of
+// text nodes, eg: to escape it. Otherwise, the text is trimmed and angle
+// brackets are replaced.
+type TextTranslator interface {
+	Text(s string) string
+}
+
+// UnknownNodeFunc renders a node of an unknown type from the translation
+// of its children.
+type UnknownNodeFunc func(n *Node, content string) string
+
+// KeepUnknownContent keeps the content of unknown nodes.
+func KeepUnknownContent(_ *Node, content string) string { return content }
+
+// SkipUnknown drops unknown nodes along with their content.
+func SkipUnknown(*Node, string) string { return "" }
+
 // Translator transforms ADF to a new format.
 type Translator struct {
 	doc *ADF
@@ -202,6 +296,19 @@ func (a *Translator) walk() {
 }
 
 func (a *Translator) visit(n *Node, depth int) {
+	if u, ok := a.tsl.(UnknownNodeTranslator); ok && GetADFNodeType(n.NodeType) == NodeTypeUnknown {
+		buf := a.buf
+		a.buf = new(strings.Builder)
+		for _, child := range n.Content {
+			a.visit(child, depth+1)
+		}
+		content := a.buf.String()
+		a.buf = buf
+
+		a.buf.WriteString(u.Unknown(n, content))
+		return
+	}
+
 	a.buf.WriteString(a.tsl.Open(n, depth))
 
 	for _, child := range n.Content {
@@ -219,7 +326,11 @@ func (a *Translator) visit(n *Node, depth int) {
 			}
 		}
 
-		tag.WriteString(sanitize(n.Text))
+		if t, ok := a.tsl.(TextTranslator); ok {
+			tag.WriteString(t.Text(n.Text))
+		} else {
+			tag.WriteString(sanitize(n.Text))
+		}
 
 		// Close tags in reverse order.
 		for i := len(opened) - 1; i >= 0; i-- {
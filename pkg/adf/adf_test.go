@@ -2,6 +2,7 @@ package adf
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -39,3 +40,84 @@ func TestADFReplaceAll(t *testing.T) {
 	assert.False(t, strings.Contains(string(dump), "Prefix:"))
 	assert.True(t, strings.Contains(string(dump), "Replaced:"))
 }
+
+const nodesDoc = `{"version": 1, "type": "doc", "content": [
+	{"type": "expand", "attrs": {"title": "Details"}, "content": [
+		{"type": "paragraph", "content": [{"type": "text", "text": "Hidden text"}]},
+		{"type": "nestedExpand", "attrs": {"title": "More"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Nested"}]}]}
+	]},
+	{"type": "taskList", "attrs": {"localId": "1"}, "content": [
+		{"type": "taskItem", "attrs": {"localId": "2", "state": "DONE"}, "content": [{"type": "text", "text": "Write tests"}]},
+		{"type": "taskList", "attrs": {"localId": "3"}, "content": [
+			{"type": "taskItem", "attrs": {"localId": "4", "state": "TODO"}, "content": [{"type": "text", "text": "Review"}]}
+		]}
+	]},
+	{"type": "decisionList", "attrs": {"localId": "5"}, "content": [
+		{"type": "decisionItem", "attrs": {"localId": "6", "state": "DECIDED"}, "content": [{"type": "text", "text": "Ship on Friday"}]}
+	]},
+	{"type": "paragraph", "content": [
+		{"type": "text", "text": "Status"},
+		{"type": "status", "attrs": {"text": "IN PROGRESS", "color": "blue"}},
+		{"type": "text", "text": "due"},
+		{"type": "date", "attrs": {"timestamp": "1735689600000"}},
+		{"type": "placeholder", "attrs": {"text": "Type something"}},
+		{"type": "mention", "attrs": {"id": "5b10a2844c20165700ede21g", "text": "@Jane"}},
+		{"type": "emoji", "attrs": {"shortName": ":tada:"}}
+	]},
+	{"type": "rule"},
+	{"type": "mediaSingle", "content": [
+		{"type": "media", "attrs": {"type": "file", "id": "abc", "collection": "jira"}},
+		{"type": "caption", "content": [{"type": "text", "text": "Screenshot"}]}
+	]},
+	{"type": "layoutSection", "content": [
+		{"type": "layoutColumn", "attrs": {"width": 50}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Left"}]}]},
+		{"type": "layoutColumn", "attrs": {"width": 50}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Right"}]}]}
+	]},
+	{"type": "blockCard", "attrs": {"url": "https://example.com/card"}},
+	{"type": "extension", "attrs": {"extensionKey": "toc", "extensionType": "com.atlassian.confluence.macro.core", "text": "Table of contents"}},
+	{"type": "futureNode", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "From the future"}]}]}
+]}`
+
+func TestMarkdownTranslatorNodes(t *testing.T) {
+	var doc ADF
+	assert.NoError(t, json.Unmarshal([]byte(nodesDoc), &doc))
+
+	tr := NewTranslator(&doc, NewMarkdownTranslator())
+	expected := "**Details**\n\nHidden text\n\n**More**\n\nNested\n\n- [x] Write tests\n\t- [ ] Review\n\n- ✓ Ship on Friday\n\n" +
+		"Status [IN PROGRESS] due 2025-01-01  @Jane :tada: \n\n---\n\n[attachment]\n_Screenshot_\n\nLeft\n\nRight\n\n" +
+		"📍 https://example.com/card\n\nTable of contents\n\nFrom the future\n\n"
+	assert.Equal(t, expected, tr.Translate())
+
+	tr = NewTranslator(&doc, NewJiraMarkdownTranslator())
+	expected = "\n{panel:title=Details}\nHidden text\n\n\n{panel:title=More}\nNested\n\n{panel}\n{panel}\n" +
+		"- [x] Write tests\n\t- [ ] Review\n\n- ✓ Ship on Friday\n\n" +
+		"Status [IN PROGRESS] due 2025-01-01  @Jane :tada: \n\n---\n\n[attachment]\n_Screenshot_\n\nLeft\n\nRight\n\n" +
+		"📍 https://example.com/card\n\nTable of contents\n\nFrom the future\n\n"
+	assert.Equal(t, expected, tr.Translate())
+}
+
+func TestMarkdownTranslatorFallback(t *testing.T) {
+	doc := &ADF{Version: 1, DocType: "doc", Content: []*Node{
+		{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: "Before"}}}},
+		{NodeType: "futureNode", Content: []*Node{
+			{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: "Inside"}}}},
+		}},
+	}}
+
+	tr := NewTranslator(doc, NewMarkdownTranslator(WithMarkdownFallback(SkipUnknown)))
+	assert.Equal(t, "Before\n\n", tr.Translate())
+
+	placeholder := func(n *Node, content string) string {
+		return fmt.Sprintf("[%s]\n%s", n.NodeType, content)
+	}
+	tr = NewTranslator(doc, NewJiraMarkdownTranslator(WithMarkdownFallback(placeholder)))
+	assert.Equal(t, "Before\n\n[futureNode]\nInside\n\n", tr.Translate())
+}
+
+func TestGetADFNodeType(t *testing.T) {
+	assert.Equal(t, NodeTypeParent, GetADFNodeType(NodeExpand))
+	assert.Equal(t, NodeTypeParent, GetADFNodeType(NodeRule))
+	assert.Equal(t, NodeTypeChild, GetADFNodeType(ChildNodeTaskItem))
+	assert.Equal(t, NodeTypeInline, GetADFNodeType(InlineNodeStatus))
+	assert.Equal(t, NodeTypeUnknown, GetADFNodeType("futureNode"))
+}
@@ -0,0 +1,470 @@
+package adf
+
+import (
+	"slices"
+	"strings"
+)
+
+// PanelType is the type of a panel, it sets its color and icon.
+type PanelType string
+
+// Panel types.
+const (
+	PanelInfo    = PanelType("info")
+	PanelNote    = PanelType("note")
+	PanelSuccess = PanelType("success")
+	PanelWarning = PanelType("warning")
+	PanelError   = PanelType("error")
+)
+
+// Nodes allowed in containers, other nodes are converted, see contain.
+var (
+	blockquoteContent = []NodeType{NodeParagraph, NodeBulletList, NodeOrderedList, NodeCodeBlock}
+	panelContent      = []NodeType{NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeRule}
+)
+
+// DocBuilder builds an ADF document, eg:
+//
+//	doc := adf.Doc().
+//		Heading(2, "Deployment").
+//		Paragraph(adf.Text("Released").Bold(), adf.Text(" by "), adf.Mention(accountID)).
+//		BulletList(adf.Item(adf.Text("API")), adf.Item(adf.Text("Web"))).
+//		CodeBlock("go", src).
+//		Build()
+//
+// The built documents are valid ADF. Values the spec doesn't allow
+// are adjusted, eg: the level of headings is limited to 1-6, unknown
+// panel types fall back to info, invalid colors are dropped and empty
+// lists, tables and rows are left out.
+type DocBuilder struct {
+	content []*Node
+}
+
+// Doc starts building a document.
+func Doc() *DocBuilder {
+	return &DocBuilder{content: []*Node{}}
+}
+
+// Build returns the document.
+func (b *DocBuilder) Build() *ADF {
+	return &ADF{Version: 1, DocType: "doc", Content: slices.Clone(b.content)}
+}
+
+// Append adds blocks to the document.
+func (b *DocBuilder) Append(blocks ...Block) *DocBuilder {
+	for _, block := range blocks {
+		if block.node != nil {
+			b.content = append(b.content, block.node)
+		}
+	}
+	return b
+}
+
+// Heading adds a heading of level 1 to 6.
+func (b *DocBuilder) Heading(level int, text string) *DocBuilder {
+	return b.Append(Heading(level, text))
+}
+
+// Paragraph adds a paragraph.
+func (b *DocBuilder) Paragraph(content ...Inline) *DocBuilder {
+	return b.Append(Paragraph(content...))
+}
+
+// BulletList adds a bullet list.
+func (b *DocBuilder) BulletList(items ...*ListItem) *DocBuilder {
+	return b.Append(BulletList(items...))
+}
+
+// OrderedList adds a numbered list.
+func (b *DocBuilder) OrderedList(items ...*ListItem) *DocBuilder {
+	return b.Append(OrderedList(items...))
+}
+
+// CodeBlock adds a code block. The language is optional.
+func (b *DocBuilder) CodeBlock(language, src string) *DocBuilder {
+	return b.Append(CodeBlock(language, src))
+}
+
+// Table adds a table.
+func (b *DocBuilder) Table(rows ...*TableRow) *DocBuilder {
+	return b.Append(Table(rows...))
+}
+
+// Panel adds a panel.
+func (b *DocBuilder) Panel(panelType PanelType, content ...Block) *DocBuilder {
+	return b.Append(Panel(panelType, content...))
+}
+
+// Blockquote adds a quote.
+func (b *DocBuilder) Blockquote(content ...Block) *DocBuilder {
+	return b.Append(Blockquote(content...))
+}
+
+// Rule adds a horizontal rule.
+func (b *DocBuilder) Rule() *DocBuilder {
+	return b.Append(Rule())
+}
+
+// Block is a block node of a document, eg: a paragraph or a table.
+type Block struct {
+	node *Node
+}
+
+// Heading returns a heading of level 1 to 6.
+func Heading(level int, text string) Block {
+	return Block{&Node{
+		NodeType:   NodeHeading,
+		Attributes: map[string]any{"level": min(max(level, 1), 6)},
+		Content:    inlineNodes([]Inline{Text(text)}),
+	}}
+}
+
+// Paragraph returns a paragraph.
+func Paragraph(content ...Inline) Block {
+	return Block{&Node{NodeType: NodeParagraph, Content: inlineNodes(content)}}
+}
+
+// BulletList returns a bullet list, or an empty block without items.
+func BulletList(items ...*ListItem) Block {
+	return Block{list(NodeBulletList, items)}
+}
+
+// OrderedList returns a numbered list, or an empty block without items.
+func OrderedList(items ...*ListItem) Block {
+	return Block{list(NodeOrderedList, items)}
+}
+
+// CodeBlock returns a code block. The language is optional.
+func CodeBlock(language, src string) Block {
+	n := &Node{NodeType: NodeCodeBlock}
+	if language != "" {
+		n.Attributes = map[string]any{"language": language}
+	}
+	if src = strings.TrimRight(src, "\n"); src != "" {
+		n.Content = []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: src}}}
+	}
+	return Block{n}
+}
+
+// Panel returns a panel. Panels hold paragraphs, headings, lists, code
+// blocks and rules, other blocks are converted to paragraphs. Unknown
+// panel types fall back to PanelInfo.
+func Panel(panelType PanelType, content ...Block) Block {
+	if !slices.Contains(panelTypeValues, string(panelType)) {
+		panelType = PanelInfo
+	}
+	return Block{&Node{
+		NodeType:   NodePanel,
+		Attributes: map[string]any{"panelType": string(panelType)},
+		Content:    contain(panelContent, blockNodes(content)),
+	}}
+}
+
+// Blockquote returns a quote. Quotes hold paragraphs, lists and code
+// blocks, other blocks are converted to paragraphs.
+func Blockquote(content ...Block) Block {
+	return Block{&Node{NodeType: NodeBlockquote, Content: contain(blockquoteContent, blockNodes(content))}}
+}
+
+// Rule returns a horizontal rule.
+func Rule() Block {
+	return Block{&Node{NodeType: NodeRule}}
+}
+
+// Table returns a table. Cells of the first row are usually headers.
+// Rows without cells are left out, as well as tables without rows.
+func Table(rows ...*TableRow) Block {
+	n := &Node{NodeType: NodeTable}
+	for _, r := range rows {
+		if len(r.cells) == 0 {
+			continue
+		}
+		row := &Node{NodeType: ChildNodeTableRow}
+		for _, c := range r.cells {
+			row.Content = append(row.Content, c.node())
+		}
+		n.Content = append(n.Content, row)
+	}
+	if len(n.Content) == 0 {
+		return Block{}
+	}
+	return Block{n}
+}
+
+// ListItem is an item of a list.
+type ListItem struct {
+	content []*Node
+}
+
+// Item returns a list item with a paragraph of the content.
+func Item(content ...Inline) *ListItem {
+	return &ListItem{content: []*Node{{NodeType: NodeParagraph, Content: inlineNodes(content)}}}
+}
+
+// BulletList adds a nested bullet list to the item.
+func (i *ListItem) BulletList(items ...*ListItem) *ListItem {
+	if n := list(NodeBulletList, items); n != nil {
+		i.content = append(i.content, n)
+	}
+	return i
+}
+
+// OrderedList adds a nested numbered list to the item.
+func (i *ListItem) OrderedList(items ...*ListItem) *ListItem {
+	if n := list(NodeOrderedList, items); n != nil {
+		i.content = append(i.content, n)
+	}
+	return i
+}
+
+// CodeBlock adds a code block to the item.
+func (i *ListItem) CodeBlock(language, src string) *ListItem {
+	i.content = append(i.content, CodeBlock(language, src).node)
+	return i
+}
+
+// list returns a list node of the items, or nil without items.
+func list(nt NodeType, items []*ListItem) *Node {
+	if len(items) == 0 {
+		return nil
+	}
+	n := &Node{NodeType: nt}
+	for _, item := range items {
+		n.Content = append(n.Content, &Node{NodeType: ChildNodeListItem, Content: slices.Clone(item.content)})
+	}
+	return n
+}
+
+// TableRow is a row of a table.
+type TableRow struct {
+	cells []*TableCell
+}
+
+// Row returns a table row.
+func Row(cells ...*TableCell) *TableRow {
+	return &TableRow{cells: cells}
+}
+
+// TableCell is a cell of a table.
+type TableCell struct {
+	header  bool
+	content []Inline
+	attrs   map[string]any
+}
+
+// Cell returns a table cell with a paragraph of the content.
+func Cell(content ...Inline) *TableCell {
+	return &TableCell{content: content}
+}
+
+// HeaderCell returns a table header cell with a paragraph of the content.
+func HeaderCell(content ...Inline) *TableCell {
+	return &TableCell{header: true, content: content}
+}
+
+// Background sets the background color of the cell, eg: #deebff.
+// Colors other than hex values are ignored.
+func (c *TableCell) Background(color string) *TableCell {
+	if !colorPattern.MatchString(color) {
+		return c
+	}
+	return c.attr("background", color)
+}
+
+// Span makes the cell span several columns and rows.
+func (c *TableCell) Span(cols, rows int) *TableCell {
+	c.attr("colspan", max(cols, 1))
+	return c.attr("rowspan", max(rows, 1))
+}
+
+func (c *TableCell) attr(k string, v any) *TableCell {
+	if c.attrs == nil {
+		c.attrs = make(map[string]any)
+	}
+	c.attrs[k] = v
+	return c
+}
+
+func (c *TableCell) node() *Node {
+	n := &Node{
+		NodeType: ChildNodeTableCell,
+		Content:  []*Node{{NodeType: NodeParagraph, Content: inlineNodes(c.content)}},
+	}
+	if c.header {
+		n.NodeType = ChildNodeTableHeader
+	}
+	if c.attrs != nil {
+		n.Attributes = c.attrs
+	}
+	return n
+}
+
+// Inline is an inline node of a block, eg: text or a mention.
+type Inline interface {
+	inline() *Node
+}
+
+type inlineNode struct {
+	node *Node
+}
+
+func (n inlineNode) inline() *Node { return n.node }
+
+// Mention returns a mention of a user by account ID.
+func Mention(accountID string) Inline {
+	return inlineNode{&Node{NodeType: InlineNodeMention, Attributes: map[string]any{"id": accountID}}}
+}
+
+// Emoji returns an emoji by its short name, eg: :smile:.
+func Emoji(shortName string) Inline {
+	shortName = ":" + strings.Trim(shortName, ":") + ":"
+	return inlineNode{&Node{NodeType: InlineNodeEmoji, Attributes: map[string]any{"shortName": shortName}}}
+}
+
+// HardBreak returns a line break.
+func HardBreak() Inline {
+	return inlineNode{&Node{NodeType: InlineNodeHardBreak}}
+}
+
+// InlineCard returns a link displayed as a card.
+func InlineCard(url string) Inline {
+	return inlineNode{&Node{NodeType: InlineNodeCard, Attributes: map[string]any{"url": url}}}
+}
+
+// TextRun is text with formatting marks.
+type TextRun struct {
+	text  string
+	marks []MarkNode
+}
+
+// Text returns text without formatting.
+func Text(text string) *TextRun {
+	return &TextRun{text: text}
+}
+
+// Bold makes the text bold.
+func (t *TextRun) Bold() *TextRun { return t.mark(MarkNode{MarkType: MarkStrong}) }
+
+// Italic makes the text italic.
+func (t *TextRun) Italic() *TextRun { return t.mark(MarkNode{MarkType: MarkEm}) }
+
+// Underline underlines the text.
+func (t *TextRun) Underline() *TextRun { return t.mark(MarkNode{MarkType: MarkUnderline}) }
+
+// Strike strikes through the text.
+func (t *TextRun) Strike() *TextRun { return t.mark(MarkNode{MarkType: MarkStrike}) }
+
+// Code formats the text as inline code. Code can only be combined with
+// links, other marks are dropped.
+func (t *TextRun) Code() *TextRun { return t.mark(MarkNode{MarkType: MarkCode}) }
+
+// Sub makes the text subscript.
+func (t *TextRun) Sub() *TextRun {
+	return t.mark(MarkNode{MarkType: MarkSubSup, Attributes: map[string]any{"type": "sub"}})
+}
+
+// Sup makes the text superscript.
+func (t *TextRun) Sup() *TextRun {
+	return t.mark(MarkNode{MarkType: MarkSubSup, Attributes: map[string]any{"type": "sup"}})
+}
+
+// Color sets the color of the text, eg: #ff5630. It can't be combined
+// with code or links. Colors other than hex values are ignored.
+func (t *TextRun) Color(color string) *TextRun {
+	if !colorPattern.MatchString(color) {
+		return t
+	}
+	return t.mark(MarkNode{MarkType: MarkTextColor, Attributes: map[string]any{"color": color}})
+}
+
+// Link links the text to a URL.
+func (t *TextRun) Link(href string) *TextRun {
+	return t.mark(MarkNode{MarkType: MarkLink, Attributes: map[string]any{"href": href}})
+}
+
+// mark adds a mark, replacing a mark of the same type.
+func (t *TextRun) mark(m MarkNode) *TextRun {
+	t.marks = slices.DeleteFunc(t.marks, func(o MarkNode) bool { return o.MarkType == m.MarkType })
+	t.marks = append(t.marks, m)
+	return t
+}
+
+func (t *TextRun) inline() *Node {
+	if t.text == "" {
+		return nil
+	}
+
+	marks := slices.Clone(t.marks)
+	has := func(mt NodeType) bool {
+		return slices.ContainsFunc(marks, func(m MarkNode) bool { return m.MarkType == mt })
+	}
+	switch {
+	case has(MarkCode):
+		marks = slices.DeleteFunc(marks, func(m MarkNode) bool { return m.MarkType != MarkCode && m.MarkType != MarkLink })
+	case has(MarkLink):
+		marks = slices.DeleteFunc(marks, func(m MarkNode) bool { return m.MarkType == MarkTextColor })
+	}
+
+	return &Node{NodeType: ChildNodeText, NodeValue: NodeValue{Text: t.text, Marks: marks}}
+}
+
+func inlineNodes(content []Inline) []*Node {
+	var out []*Node
+	for _, c := range content {
+		if c == nil {
+			continue
+		}
+		if n := c.inline(); n != nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func blockNodes(blocks []Block) []*Node {
+	var out []*Node
+	for _, b := range blocks {
+		if b.node != nil {
+			out = append(out, b.node)
+		}
+	}
+	return out
+}
+
+// contain adjusts the content of a container to the allowed nodes.
+// Headings are converted to bold paragraphs, other nodes to paragraphs
+// of their text. Containers can't be empty, so an empty paragraph is
+// added if nothing is left.
+func contain(allowed []NodeType, content []*Node) []*Node {
+	out := make([]*Node, 0, len(content))
+	for _, n := range content {
+		switch {
+		case slices.Contains(allowed, n.NodeType):
+			out = append(out, n)
+		case n.NodeType == NodeHeading:
+			out = append(out, &Node{NodeType: NodeParagraph, Content: withMark(n.Content, MarkNode{MarkType: MarkStrong})})
+		default:
+			if text := textOf(n); text != "" {
+				out = append(out, &Node{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: text}}}})
+			}
+		}
+	}
+	if len(out) == 0 {
+		out = append(out, &Node{NodeType: NodeParagraph})
+	}
+	return out
+}
+
+// textOf returns the text of a node and its children.
+func textOf(n *Node) string {
+	var parts []string
+	if n.Text != "" {
+		parts = append(parts, n.Text)
+	}
+	for _, c := range n.Content {
+		if text := textOf(c); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,185 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocBuilder(t *testing.T) {
+	doc := Doc().
+		Heading(2, "Release v1.2").
+		Paragraph(Text("Deployed").Bold(), Text(" by "), Mention("5b10a2844c20165700ede21g"), Text(" "), Emoji("rocket")).
+		BulletList(
+			Item(Text("API")).BulletList(Item(Text("v2").Code())),
+			Item(Text("Docs").Link("https://example.com/docs")),
+		).
+		OrderedList(Item(Text("Migrate")).CodeBlock("sh", "make migrate\n")).
+		Table(
+			Row(HeaderCell(Text("Service")), HeaderCell(Text("Status"))),
+			Row(Cell(Text("api")), Cell(Text("up").Color("#36b37e")).Background("#e3fcef")),
+		).
+		Panel(PanelWarning, Paragraph(Text("Restart workers"), HardBreak(), InlineCard("https://example.com/runbook"))).
+		Blockquote(Paragraph(Text("Ship it").Italic())).
+		Rule().
+		CodeBlock("go", "fmt.Println(\"done\")").
+		Build()
+
+	expected := `{"version": 1, "type": "doc", "content": [
+		{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Release v1.2"}]},
+		{"type": "paragraph", "content": [
+			{"type": "text", "text": "Deployed", "marks": [{"type": "strong"}]},
+			{"type": "text", "text": " by "},
+			{"type": "mention", "attrs": {"id": "5b10a2844c20165700ede21g"}},
+			{"type": "text", "text": " "},
+			{"type": "emoji", "attrs": {"shortName": ":rocket:"}}
+		]},
+		{"type": "bulletList", "content": [
+			{"type": "listItem", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "API"}]},
+				{"type": "bulletList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "v2", "marks": [{"type": "code"}]}]}]}
+				]}
+			]},
+			{"type": "listItem", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Docs", "marks": [{"type": "link", "attrs": {"href": "https://example.com/docs"}}]}]}
+			]}
+		]},
+		{"type": "orderedList", "content": [
+			{"type": "listItem", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Migrate"}]},
+				{"type": "codeBlock", "attrs": {"language": "sh"}, "content": [{"type": "text", "text": "make migrate"}]}
+			]}
+		]},
+		{"type": "table", "content": [
+			{"type": "tableRow", "content": [
+				{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Service"}]}]},
+				{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Status"}]}]}
+			]},
+			{"type": "tableRow", "content": [
+				{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "api"}]}]},
+				{"type": "tableCell", "attrs": {"background": "#e3fcef"}, "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "up", "marks": [{"type": "textColor", "attrs": {"color": "#36b37e"}}]}]}
+				]}
+			]}
+		]},
+		{"type": "panel", "attrs": {"panelType": "warning"}, "content": [
+			{"type": "paragraph", "content": [
+				{"type": "text", "text": "Restart workers"},
+				{"type": "hardBreak"},
+				{"type": "inlineCard", "attrs": {"url": "https://example.com/runbook"}}
+			]}
+		]},
+		{"type": "blockquote", "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Ship it", "marks": [{"type": "em"}]}]}
+		]},
+		{"type": "rule"},
+		{"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "fmt.Println(\"done\")"}]}
+	]}`
+
+	actual, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+func TestDocBuilderAdjustsInvalidValues(t *testing.T) {
+	heading := Heading(9, "Notes")
+
+	doc := Doc().
+		Append(heading).
+		Heading(0, "Top").
+		Paragraph(Text(""), Text("x").Bold().Code().Link("https://example.com"), Text("y").Link("https://example.com").Color("#ff5630")).
+		Panel(PanelInfo, heading, Table(Row(Cell(Text("a")), Cell(Text("b")))), CodeBlock("go", "x := 1"), Rule()).
+		Blockquote(heading, CodeBlock("", "")).
+		Build()
+
+	expected := `{"version": 1, "type": "doc", "content": [
+		{"type": "heading", "attrs": {"level": 6}, "content": [{"type": "text", "text": "Notes"}]},
+		{"type": "heading", "attrs": {"level": 1}, "content": [{"type": "text", "text": "Top"}]},
+		{"type": "paragraph", "content": [
+			{"type": "text", "text": "x", "marks": [{"type": "code"}, {"type": "link", "attrs": {"href": "https://example.com"}}]},
+			{"type": "text", "text": "y", "marks": [{"type": "link", "attrs": {"href": "https://example.com"}}]}
+		]},
+		{"type": "panel", "attrs": {"panelType": "info"}, "content": [
+			{"type": "heading", "attrs": {"level": 6}, "content": [{"type": "text", "text": "Notes"}]},
+			{"type": "paragraph", "content": [{"type": "text", "text": "a b"}]},
+			{"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "x := 1"}]},
+			{"type": "rule"}
+		]},
+		{"type": "blockquote", "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Notes", "marks": [{"type": "strong"}]}]},
+			{"type": "codeBlock"}
+		]}
+	]}`
+
+	actual, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+
+	// The heading added to the quote is left as is in the document.
+	assert.Empty(t, doc.Content[0].Content[0].Marks)
+}
+
+func TestDocBuilderLeavesOutEmptyNodes(t *testing.T) {
+	doc := Doc().
+		BulletList().
+		Table().
+		Table(Row(), Row(Cell(Text("a")))).
+		OrderedList(Item(Text("b")).BulletList()).
+		Panel(PanelNote, BulletList()).
+		Build()
+	assert.NoError(t, doc.Validate())
+
+	expected := `{"version": 1, "type": "doc", "content": [
+		{"type": "table", "content": [
+			{"type": "tableRow", "content": [{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "a"}]}]}]}
+		]},
+		{"type": "orderedList", "content": [
+			{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "b"}]}]}
+		]},
+		{"type": "panel", "attrs": {"panelType": "note"}, "content": [{"type": "paragraph"}]}
+	]}`
+
+	actual, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+
+	assert.NoError(t, Doc().BulletList().Table(Row()).Build().Validate())
+}
+
+func TestDocBuilderDropsInvalidValues(t *testing.T) {
+	doc := Doc().
+		Paragraph(Text("x").Color("red"), Text("y").Bold().Color("#FF5630")).
+		Panel(PanelType("bogus"), Paragraph(Text("z"))).
+		Panel(PanelType("tip"), Paragraph(Text("w"))).
+		Table(Row(Cell(Text("a")).Background("blue"))).
+		Build()
+	assert.NoError(t, doc.Validate())
+
+	expected := `{"version": 1, "type": "doc", "content": [
+		{"type": "paragraph", "content": [
+			{"type": "text", "text": "x"},
+			{"type": "text", "text": "y", "marks": [{"type": "strong"}, {"type": "textColor", "attrs": {"color": "#FF5630"}}]}
+		]},
+		{"type": "panel", "attrs": {"panelType": "info"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "z"}]}]},
+		{"type": "panel", "attrs": {"panelType": "tip"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "w"}]}]},
+		{"type": "table", "content": [
+			{"type": "tableRow", "content": [{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "a"}]}]}]}
+		]}
+	]}`
+
+	actual, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(actual))
+}
+
+func TestDocBuilderTranslate(t *testing.T) {
+	doc := Doc().
+		Heading(1, "Title").
+		Paragraph(Text("Some "), Text("bold").Bold(), Text(" text")).
+		Build()
+
+	tr := NewTranslator(doc, NewMarkdownTranslator())
+	assert.Equal(t, "# Title\nSome **bold** text\n\n", tr.Translate())
+}
@@ -1,4 +1,6 @@
-// Package adf translates Atlassian Document Format (ADF) to other formats like markdown.
+// Package adf translates Atlassian Document Format (ADF) to markdown, HTML and plain text,
+// builds ADF documents from CommonMark or with a DocBuilder, and validates them
+// against the ADF schema.
 //
 // See: https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/
 package adf
@@ -0,0 +1,298 @@
+package adf
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	bf "github.com/russross/blackfriday/v2"
+)
+
+var (
+	// inlinePattern matches Jira style mentions, eg: [~accountid:5b10a2844c20165700ede21g]
+	// or [~jane], and emoji shortcodes, eg: :smile:.
+	inlinePattern = regexp.MustCompile(`\[~(?:accountid:)?([^\]\s]+)\]|:([a-z][a-z0-9_+-]*):`)
+
+	// panelPattern matches the marker of a GitHub style alert, eg: > [!NOTE].
+	panelPattern = regexp.MustCompile(`^\[!([A-Za-z]+)\]\s*`)
+
+	panelTypes = map[string]PanelType{
+		"note":      PanelInfo,
+		"info":      PanelInfo,
+		"tip":       PanelSuccess,
+		"success":   PanelSuccess,
+		"important": PanelNote,
+		"warning":   PanelWarning,
+		"caution":   PanelError,
+		"error":     PanelError,
+	}
+)
+
+// FromMarkdown converts CommonMark to an ADF document.
+//
+// Besides the CommonMark syntax, tables, strikethrough and autolinks are
+// supported, as well as the following extensions:
+//   - Mentions in Jira syntax, eg: [~accountid:5b10a2844c20165700ede21g].
+//   - Emoji shortcodes, eg: :smile:.
+//   - Panels as GitHub style alerts, eg: > [!WARNING].
+//
+// Soft line breaks are converted to spaces, use two trailing spaces or
+// a backslash for a hard break. Images are converted to links as ADF
+// media must be uploaded first.
+func FromMarkdown(md string) *ADF {
+	doc := &ADF{Version: 1, DocType: "doc", Content: []*Node{}}
+
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	root := bf.New(bf.WithExtensions(bf.CommonExtensions)).Parse([]byte(md))
+	for n := root.FirstChild; n != nil; n = n.Next {
+		doc.Content = append(doc.Content, fromBlock(n)...)
+	}
+
+	return doc
+}
+
+func fromBlocks(n *bf.Node) []*Node {
+	out := make([]*Node, 0)
+	for c := n.FirstChild; c != nil; c = c.Next {
+		out = append(out, fromBlock(c)...)
+	}
+	return out
+}
+
+func fromBlock(n *bf.Node) []*Node {
+	switch n.Type {
+	case bf.Paragraph:
+		content := fromInlines(n, nil)
+		if len(content) == 0 {
+			return nil
+		}
+		return []*Node{{NodeType: NodeParagraph, Content: content}}
+	case bf.Heading:
+		return []*Node{{
+			NodeType:   NodeHeading,
+			Attributes: map[string]any{"level": n.Level},
+			Content:    fromInlines(n, nil),
+		}}
+	case bf.BlockQuote:
+		return []*Node{fromBlockquote(n)}
+	case bf.List:
+		list := &Node{NodeType: NodeBulletList}
+		if n.ListFlags&bf.ListTypeOrdered != 0 {
+			list.NodeType = NodeOrderedList
+		}
+		for item := n.FirstChild; item != nil; item = item.Next {
+			content := contain(listContent, fromBlocks(item))
+			if content[0].NodeType != NodeParagraph {
+				content = append([]*Node{{NodeType: NodeParagraph}}, content...)
+			}
+			list.Content = append(list.Content, &Node{NodeType: ChildNodeListItem, Content: content})
+		}
+		return []*Node{list}
+	case bf.CodeBlock:
+		code := &Node{NodeType: NodeCodeBlock}
+		if lang, _, _ := strings.Cut(strings.TrimSpace(string(n.Info)), " "); lang != "" {
+			code.Attributes = map[string]any{"language": lang}
+		}
+		if text := strings.TrimRight(string(n.Literal), "\n"); text != "" {
+			code.Content = []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: text}}}
+		}
+		return []*Node{code}
+	case bf.HorizontalRule:
+		return []*Node{{NodeType: NodeRule}}
+	case bf.Table:
+		return []*Node{fromTable(n)}
+	case bf.HTMLBlock:
+		text := strings.TrimSpace(string(n.Literal))
+		if text == "" {
+			return nil
+		}
+		return []*Node{{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: text}}}}}
+	}
+	return fromBlocks(n)
+}
+
+// fromBlockquote converts a blockquote to a panel if it starts with
+// an alert marker, eg: > [!NOTE].
+func fromBlockquote(n *bf.Node) *Node {
+	content := fromBlocks(n)
+	quote := &Node{NodeType: NodeBlockquote, Content: contain(blockquoteContent, content)}
+	if len(content) == 0 || content[0].NodeType != NodeParagraph || len(content[0].Content) == 0 {
+		return quote
+	}
+
+	first := content[0].Content[0]
+	m := panelPattern.FindStringSubmatch(first.Text)
+	if first.NodeType != ChildNodeText || m == nil {
+		return quote
+	}
+	panelType, ok := panelTypes[strings.ToLower(m[1])]
+	if !ok {
+		return quote
+	}
+
+	first.Text = strings.TrimPrefix(first.Text, m[0])
+	inline := content[0].Content
+	if first.Text == "" {
+		inline = inline[1:]
+	}
+	if len(inline) > 0 && inline[0].NodeType == InlineNodeHardBreak {
+		inline = inline[1:]
+	}
+	if content[0].Content = inline; len(inline) == 0 {
+		content = content[1:]
+	}
+
+	return &Node{
+		NodeType:   NodePanel,
+		Attributes: map[string]any{"panelType": string(panelType)},
+		Content:    contain(panelContent, content),
+	}
+}
+
+func fromTable(n *bf.Node) *Node {
+	table := &Node{NodeType: NodeTable}
+
+	var rows []*bf.Node
+	for section := n.FirstChild; section != nil; section = section.Next {
+		for row := section.FirstChild; row != nil; row = row.Next {
+			rows = append(rows, row)
+		}
+	}
+	for _, row := range rows {
+		r := &Node{NodeType: ChildNodeTableRow}
+		for cell := row.FirstChild; cell != nil; cell = cell.Next {
+			c := &Node{NodeType: ChildNodeTableCell, Content: []*Node{{NodeType: NodeParagraph, Content: fromInlines(cell, nil)}}}
+			if cell.IsHeader {
+				c.NodeType = ChildNodeTableHeader
+			}
+			r.Content = append(r.Content, c)
+		}
+		table.Content = append(table.Content, r)
+	}
+
+	return table
+}
+
+// fromInlines converts the inline children of a node. The marks are
+// applied to all text of the children.
+func fromInlines(n *bf.Node, marks []MarkNode) []*Node {
+	out := make([]*Node, 0)
+	for c := n.FirstChild; c != nil; c = c.Next {
+		switch c.Type {
+		case bf.Text:
+			// Text may be split around special characters, eg: [, so
+			// adjacent nodes are joined to match mentions and emoji.
+			var text strings.Builder
+			text.Write(c.Literal)
+			for c.Next != nil && c.Next.Type == bf.Text {
+				c = c.Next
+				text.Write(c.Literal)
+			}
+			out = append(out, fromText(strings.ReplaceAll(text.String(), "\n", " "), marks)...)
+		case bf.Softbreak:
+			out = append(out, fromText(" ", marks)...)
+		case bf.Hardbreak:
+			out = append(out, &Node{NodeType: InlineNodeHardBreak})
+		case bf.Emph:
+			out = append(out, fromInlines(c, addMark(marks, MarkNode{MarkType: MarkEm}))...)
+		case bf.Strong:
+			out = append(out, fromInlines(c, addMark(marks, MarkNode{MarkType: MarkStrong}))...)
+		case bf.Del:
+			out = append(out, fromInlines(c, addMark(marks, MarkNode{MarkType: MarkStrike}))...)
+		case bf.Link, bf.Image:
+			attrs := map[string]any{"href": string(c.Destination)}
+			if len(c.Title) > 0 {
+				attrs["title"] = string(c.Title)
+			}
+			link := addMark(marks, MarkNode{MarkType: MarkLink, Attributes: attrs})
+			if content := fromInlines(c, link); len(content) > 0 {
+				out = append(out, content...)
+			} else if len(c.Destination) > 0 {
+				out = append(out, &Node{NodeType: ChildNodeText, NodeValue: NodeValue{Text: string(c.Destination), Marks: link}})
+			}
+		case bf.Code:
+			// Code can only be combined with links.
+			code := slices.DeleteFunc(slices.Clone(marks), func(m MarkNode) bool { return m.MarkType != MarkLink })
+			code = append(code, MarkNode{MarkType: MarkCode})
+			if len(c.Literal) > 0 {
+				out = append(out, &Node{NodeType: ChildNodeText, NodeValue: NodeValue{Text: string(c.Literal), Marks: code}})
+			}
+		case bf.HTMLSpan:
+			switch html := strings.ToLower(strings.ReplaceAll(string(c.Literal), " ", "")); html {
+			case "<br>", "<br/>":
+				out = append(out, &Node{NodeType: InlineNodeHardBreak})
+			default:
+				out = append(out, fromText(string(c.Literal), marks)...)
+			}
+		default:
+			out = append(out, fromInlines(c, marks)...)
+		}
+	}
+	return out
+}
+
+// fromText converts text to text nodes with the marks, and to mention
+// and emoji nodes.
+func fromText(text string, marks []MarkNode) []*Node {
+	out := make([]*Node, 0)
+	add := func(s string) {
+		if s != "" {
+			out = append(out, &Node{NodeType: ChildNodeText, NodeValue: NodeValue{Text: s, Marks: marks}})
+		}
+	}
+
+	last := 0
+	for _, m := range inlinePattern.FindAllStringSubmatchIndex(text, -1) {
+		// Shortcodes must be separate words, eg: not in std::string::npos.
+		if m[2] < 0 && (m[0] > 0 && isWordByte(text[m[0]-1]) || m[1] < len(text) && isWordByte(text[m[1]])) {
+			continue
+		}
+		add(text[last:m[0]])
+		last = m[1]
+
+		if m[2] >= 0 {
+			id := text[m[2]:m[3]]
+			out = append(out, &Node{
+				NodeType:   InlineNodeMention,
+				Attributes: map[string]any{"id": id, "text": "@" + id},
+			})
+			continue
+		}
+		out = append(out, &Node{
+			NodeType:   InlineNodeEmoji,
+			Attributes: map[string]any{"shortName": text[m[0]:m[1]]},
+		})
+	}
+	add(text[last:])
+
+	return out
+}
+
+func isWordByte(b byte) bool {
+	return b == ':' || b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// addMark returns the marks with m added, unless they have a mark of the
+// same type already, eg: in nested emphasis like *_x_*.
+func addMark(marks []MarkNode, m MarkNode) []MarkNode {
+	if slices.ContainsFunc(marks, func(o MarkNode) bool { return o.MarkType == m.MarkType }) {
+		return marks
+	}
+	return append(slices.Clip(marks), m)
+}
+
+// withMark returns the nodes with a mark added to the text nodes.
+// Code is left as is, it can't be combined with other marks.
+func withMark(nodes []*Node, m MarkNode) []*Node {
+	out := make([]*Node, 0, len(nodes))
+	for _, n := range nodes {
+		skip := func(o MarkNode) bool { return o.MarkType == m.MarkType || o.MarkType == MarkCode }
+		if n.NodeType == ChildNodeText && !slices.ContainsFunc(n.Marks, skip) {
+			c := *n
+			c.Marks = addMark(n.Marks, m)
+			n = &c
+		}
+		out = append(out, n)
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromMarkdown(t *testing.T) {
+	cases := []struct {
+		name     string
+		md       string
+		expected string
+	}{
+		{
+			name:     "empty",
+			md:       "",
+			expected: `[]`,
+		},
+		{
+			name: "heading and marks",
+			md:   "## Title\n\nSome **bold _and italic_**, `code`, ~~gone~~ and [a link](https://example.com \"Example\").",
+			expected: `[
+				{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Title"}]},
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "Some "},
+					{"type": "text", "text": "bold ", "marks": [{"type": "strong"}]},
+					{"type": "text", "text": "and italic", "marks": [{"type": "strong"}, {"type": "em"}]},
+					{"type": "text", "text": ", "},
+					{"type": "text", "text": "code", "marks": [{"type": "code"}]},
+					{"type": "text", "text": ", "},
+					{"type": "text", "text": "gone", "marks": [{"type": "strike"}]},
+					{"type": "text", "text": " and "},
+					{"type": "text", "text": "a link", "marks": [{"type": "link", "attrs": {"href": "https://example.com", "title": "Example"}}]},
+					{"type": "text", "text": "."}
+				]}
+			]`,
+		},
+		{
+			name: "code in link",
+			md:   "[**`go test`**](https://go.dev)",
+			expected: `[
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "go test", "marks": [{"type": "link", "attrs": {"href": "https://go.dev"}}, {"type": "code"}]}
+				]}
+			]`,
+		},
+		{
+			name: "nested emphasis",
+			md:   "*_x_* **__y__**",
+			expected: `[
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "x", "marks": [{"type": "em"}]},
+					{"type": "text", "text": " "},
+					{"type": "text", "text": "y", "marks": [{"type": "strong"}]}
+				]}
+			]`,
+		},
+		{
+			name: "line breaks",
+			md:   "First line\nsame paragraph  \nsecond line\\\nthird line",
+			expected: `[
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "First line same paragraph"},
+					{"type": "hardBreak"},
+					{"type": "text", "text": "second line"},
+					{"type": "hardBreak"},
+					{"type": "text", "text": "third line"}
+				]}
+			]`,
+		},
+		{
+			name: "mentions and emoji",
+			md:   "Thanks [~accountid:5b10a2844c20165700ede21g] and [~jane] :tada: std::string 10:30:45",
+			expected: `[
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "Thanks "},
+					{"type": "mention", "attrs": {"id": "5b10a2844c20165700ede21g", "text": "@5b10a2844c20165700ede21g"}},
+					{"type": "text", "text": " and "},
+					{"type": "mention", "attrs": {"id": "jane", "text": "@jane"}},
+					{"type": "text", "text": " "},
+					{"type": "emoji", "attrs": {"shortName": ":tada:"}},
+					{"type": "text", "text": " std::string 10:30:45"}
+				]}
+			]`,
+		},
+		{
+			name: "lists",
+			md:   "- one\n- two\n  1. nested\n\n1. first\n2. second",
+			expected: `[
+				{"type": "bulletList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "one"}]}]},
+					{"type": "listItem", "content": [
+						{"type": "paragraph", "content": [{"type": "text", "text": "two"}]},
+						{"type": "orderedList", "content": [
+							{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "nested"}]}]}
+						]}
+					]}
+				]},
+				{"type": "orderedList", "content": [
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "first"}]}]},
+					{"type": "listItem", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "second"}]}]}
+				]}
+			]`,
+		},
+		{
+			name: "code block",
+			md:   "```go\nfunc main() {\n\tfmt.Println(\"**hi**\")\n}\n```\n\n    indented",
+			expected: `[
+				{"type": "codeBlock", "attrs": {"language": "go"}, "content": [{"type": "text", "text": "func main() {\n\tfmt.Println(\"**hi**\")\n}"}]},
+				{"type": "codeBlock", "content": [{"type": "text", "text": "indented"}]}
+			]`,
+		},
+		{
+			name: "table",
+			md:   "| Name | Value |\n|------|-------|\n| a | **1** |",
+			expected: `[
+				{"type": "table", "content": [
+					{"type": "tableRow", "content": [
+						{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Name"}]}]},
+						{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Value"}]}]}
+					]},
+					{"type": "tableRow", "content": [
+						{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "a"}]}]},
+						{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "1", "marks": [{"type": "strong"}]}]}]}
+					]}
+				]}
+			]`,
+		},
+		{
+			name: "blockquote and panels",
+			md:   "> quoted\n\ntext\n\n> [!WARNING]\n> Mind the gap\n\nmore\n\n> [!TIP] Use `-v`",
+			expected: `[
+				{"type": "blockquote", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "quoted"}]}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "text"}]},
+				{"type": "panel", "attrs": {"panelType": "warning"}, "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "Mind the gap"}]}
+				]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "more"}]},
+				{"type": "panel", "attrs": {"panelType": "success"}, "content": [
+					{"type": "paragraph", "content": [{"type": "text", "text": "Use "}, {"type": "text", "text": "-v", "marks": [{"type": "code"}]}]}
+				]}
+			]`,
+		},
+		{
+			name: "rule and image",
+			md:   "above\n\n---\n\n![diagram](https://example.com/d.png)",
+			expected: `[
+				{"type": "paragraph", "content": [{"type": "text", "text": "above"}]},
+				{"type": "rule"},
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "diagram", "marks": [{"type": "link", "attrs": {"href": "https://example.com/d.png"}}]}
+				]}
+			]`,
+		},
+		{
+			name: "autolink",
+			md:   "See https://example.com",
+			expected: `[
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "See "},
+					{"type": "text", "text": "https://example.com", "marks": [{"type": "link", "attrs": {"href": "https://example.com"}}]}
+				]}
+			]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := FromMarkdown(tc.md)
+			assert.Equal(t, 1, doc.Version)
+			assert.Equal(t, "doc", doc.DocType)
+
+			content, err := json.Marshal(doc.Content)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tc.expected, string(content))
+		})
+	}
+}
+
+func TestFromMarkdownTranslate(t *testing.T) {
+	doc := FromMarkdown("# Title\n\nSome **bold** text.")
+
+	tr := NewTranslator(doc, NewMarkdownTranslator())
+	assert.Equal(t, "# Title\nSome **bold** text.\n\n", tr.Translate())
+}
+
+func TestFromMarkdownIsValid(t *testing.T) {
+	corpus := []string{
+		"",
+		"plain text",
+		"# 0\n* 0\n# 0",
+		"- a\n\n    > quote",
+		"- a\n\n    | a |\n    |---|\n    | 1 |",
+		"- a\n\n    ---",
+		"1. a\n\n    # heading",
+		"- a\n  - b\n    - c\n      - d\n        - e\n          - f",
+		"- ```\n  code\n  ```",
+		"- \n- b",
+		"> - a\n>\n>     > nested",
+		"> # heading\n>\n> | a |\n> |---|\n> | 1 |",
+		"> [!WARNING]\n> ---\n> ```go\n> x\n> ```\n> > quote",
+		"*_x_*",
+		"**__x__**",
+		"***x***",
+		"~~~~x~~~~",
+		"*[link](https://example.com)*",
+		"[*a* **b** `c`](https://example.com)",
+		"**`code`**",
+		"| a | b |\n|---|---|\n| **x** | [~jane] :smile: |",
+		"![image](https://example.com/a.png)",
+		"<https://example.com>",
+		"line  \nbreak<br>",
+		"<div>html</div>",
+		"#",
+		"[]()",
+	}
+
+	for _, md := range corpus {
+		assert.NoError(t, FromMarkdown(md).Validate(), md)
+	}
+}
@@ -0,0 +1,365 @@
+package adf
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// HTMLTranslator is an HTML translator.
+//
+// Text and attributes are escaped, links are limited to http, https and
+// mailto URLs and colors to hex values, so the output is safe to embed
+// in a page. Panels, statuses and mentions get classes to style them,
+// eg: adf-panel adf-panel-info, adf-status adf-status-green and adf-mention.
+type HTMLTranslator struct {
+	classHooks nodeTypeHook
+	mention    MentionResolver
+	media      MediaResolver
+	fallback   UnknownNodeFunc
+	figure     bool // inside a media single.
+	tasks      int  // depth of task lists.
+}
+
+// HTMLTranslatorOption is a functional option for HTMLTranslator.
+type HTMLTranslatorOption func(*HTMLTranslator)
+
+// NewHTMLTranslator constructs HTML translator.
+func NewHTMLTranslator(opts ...HTMLTranslatorOption) *HTMLTranslator {
+	tr := HTMLTranslator{
+		classHooks: nodeTypeHook{
+			NodePanel:         htmlPanelClass,
+			InlineNodeStatus:  htmlStatusClass,
+			InlineNodeMention: func(Connector) string { return "adf-mention" },
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&tr)
+	}
+
+	return &tr
+}
+
+// WithHTMLClassHooks sets the class of the elements of node types. The
+// hooks replace the default classes of the same types, an empty class
+// omits the attribute.
+func WithHTMLClassHooks(hooks nodeTypeHook) HTMLTranslatorOption {
+	return func(tr *HTMLTranslator) {
+		for nt, hook := range hooks {
+			tr.classHooks[nt] = hook
+		}
+	}
+}
+
+// WithHTMLMentionResolver sets the resolver of mentioned account names.
+func WithHTMLMentionResolver(fn MentionResolver) HTMLTranslatorOption {
+	return func(tr *HTMLTranslator) {
+		tr.mention = fn
+	}
+}
+
+// WithHTMLMediaResolver sets the resolver of media links.
+func WithHTMLMediaResolver(fn MediaResolver) HTMLTranslatorOption {
+	return func(tr *HTMLTranslator) {
+		tr.media = fn
+	}
+}
+
+// WithHTMLFallback sets the translation of nodes of unknown types.
+// By default their content is kept, see KeepUnknownContent and SkipUnknown.
+func WithHTMLFallback(fn UnknownNodeFunc) HTMLTranslatorOption {
+	return func(tr *HTMLTranslator) {
+		tr.fallback = fn
+	}
+}
+
+// Unknown implements UnknownNodeTranslator interface.
+func (tr *HTMLTranslator) Unknown(n *Node, content string) string {
+	if tr.fallback == nil {
+		return KeepUnknownContent(n, content)
+	}
+	return tr.fallback(n, content)
+}
+
+// Text implements TextTranslator interface.
+func (*HTMLTranslator) Text(s string) string {
+	return html.EscapeString(s)
+}
+
+// Open implements TagOpener interface.
+//
+//nolint:gocyclo
+func (tr *HTMLTranslator) Open(n Connector, _ int) string {
+	attrs := n.GetAttributes()
+
+	switch n.GetType() {
+	case NodeParagraph:
+		return tr.tag(n, "p")
+	case NodeHeading:
+		return tr.tag(n, fmt.Sprintf("h%d", htmlHeadingLevel(attrs)))
+	case NodeBulletList:
+		return tr.tag(n, "ul")
+	case NodeOrderedList:
+		if order := attrInt(getAttr(attrs, "order")); order > 1 {
+			return tr.tag(n, "ol", "start", fmt.Sprint(order))
+		}
+		return tr.tag(n, "ol")
+	case ChildNodeListItem, ChildNodeDecisionItem:
+		return tr.tag(n, "li")
+	case NodeCodeBlock:
+		if lang := attrString(attrs, "language"); lang != "" {
+			return tr.tag(n, "pre") + fmt.Sprintf(`<code class="language-%s">`, html.EscapeString(lang))
+		}
+		return tr.tag(n, "pre") + "<code>"
+	case NodeBlockquote:
+		return tr.tag(n, "blockquote")
+	case NodePanel, NodeBodiedExtension:
+		return tr.tag(n, "div")
+	case NodeRule:
+		return tr.tag(n, "hr")
+	case NodeTable:
+		return tr.tag(n, "table") + "<tbody>"
+	case ChildNodeTableRow:
+		return tr.tag(n, "tr")
+	case ChildNodeTableHeader, ChildNodeTableCell:
+		name := "td"
+		if n.GetType() == ChildNodeTableHeader {
+			name = "th"
+		}
+		var kv []string
+		for _, k := range []string{"colspan", "rowspan"} {
+			if span := attrInt(getAttr(attrs, k)); span > 1 {
+				kv = append(kv, k, fmt.Sprint(span))
+			}
+		}
+		if bg := attrString(attrs, "background"); colorPattern.MatchString(bg) {
+			kv = append(kv, "style", "background-color: "+bg)
+		}
+		return tr.tag(n, name, kv...)
+	case NodeExpand, NodeNestedExpand:
+		return tr.tag(n, "details") + "<summary>" + html.EscapeString(attrString(attrs, "title")) + "</summary>"
+	case NodeMediaSingle:
+		tr.figure = true
+		return tr.tag(n, "figure")
+	case NodeMediaGroup, NodeLayoutSection, ChildNodeLayoutColumn:
+		return tr.tag(n, "div")
+	case ChildNodeCaption:
+		return tr.tag(n, "figcaption")
+	case NodeMedia, InlineNodeMediaInline:
+		m := resolveMedia(n, tr.media)
+		name := m.Name
+		if name == "" {
+			name = "attachment"
+		}
+		href := safeURL(m.URL)
+		switch {
+		case href == "":
+			return tr.tag(n, "span") + html.EscapeString(name) + "</span>"
+		case tr.figure && n.GetType() == NodeMedia:
+			return tr.tag(n, "img", "src", href, "alt", m.Name)
+		default:
+			return tr.tag(n, "a", "href", href) + html.EscapeString(name) + "</a>"
+		}
+	case NodeTaskList:
+		// Task lists are nested in each other instead of in items.
+		if tr.tasks++; tr.tasks > 1 {
+			return "<li>" + tr.tag(n, "ul")
+		}
+		return tr.tag(n, "ul")
+	case NodeDecisionList:
+		return tr.tag(n, "ul")
+	case ChildNodeTaskItem:
+		if attrString(attrs, "state") == "DONE" {
+			return tr.tag(n, "li") + `<input type="checkbox" disabled checked> `
+		}
+		return tr.tag(n, "li") + `<input type="checkbox" disabled> `
+	case NodeBlockCard, NodeEmbedCard, InlineNodeCard:
+		href := safeURL(attrString(attrs, "url"))
+		if href == "" {
+			return ""
+		}
+		link := tr.tag(n, "a", "href", href) + html.EscapeString(href) + "</a>"
+		if n.GetType() != InlineNodeCard {
+			return "<p>" + link + "</p>\n"
+		}
+		return link
+	case NodeExtension:
+		if text := attrString(attrs, "text"); text != "" {
+			return tr.tag(n, "p") + html.EscapeString(text) + "</p>\n"
+		}
+		return ""
+	case InlineNodeExtension:
+		return html.EscapeString(attrString(attrs, "text"))
+	case InlineNodeHardBreak:
+		return "<br>"
+	case InlineNodeMention:
+		return tr.tag(n, "span", "data-account-id", attrString(attrs, "id")) +
+			"@" + html.EscapeString(resolveMention(n, tr.mention)) + "</span>"
+	case InlineNodeEmoji:
+		return html.EscapeString(emojiText(n))
+	case InlineNodeStatus:
+		return tr.tag(n, "span") + html.EscapeString(attrString(attrs, "text")) + "</span>"
+	case InlineNodeDate:
+		date := attrDate(attrs)
+		return tr.tag(n, "time", "datetime", date) + date + "</time>"
+	case MarkStrong:
+		return "<strong>"
+	case MarkEm:
+		return "<em>"
+	case MarkCode:
+		return "<code>"
+	case MarkStrike:
+		return "<s>"
+	case MarkUnderline:
+		return "<u>"
+	case MarkLink:
+		if href := safeURL(attrString(attrs, "href")); href != "" {
+			return fmt.Sprintf(`<a href="%s">`, html.EscapeString(href))
+		}
+		return "<a>"
+	case MarkSubSup:
+		if attrString(attrs, "type") == "sup" {
+			return "<sup>"
+		}
+		return "<sub>"
+	case MarkTextColor, MarkBackgroundColor:
+		prop := "color"
+		if n.GetType() == MarkBackgroundColor {
+			prop = "background-color"
+		}
+		if color := attrString(attrs, "color"); colorPattern.MatchString(color) {
+			return fmt.Sprintf(`<span style="%s: %s">`, prop, color)
+		}
+		return "<span>"
+	}
+
+	return ""
+}
+
+// Close implements TagCloser interface.
+//
+//nolint:gocyclo
+func (tr *HTMLTranslator) Close(n Connector) string {
+	switch n.GetType() {
+	case NodeParagraph:
+		return "</p>\n"
+	case NodeHeading:
+		return fmt.Sprintf("</h%d>\n", htmlHeadingLevel(n.GetAttributes()))
+	case NodeTaskList:
+		if tr.tasks--; tr.tasks > 0 {
+			return "</ul></li>\n"
+		}
+		return "</ul>\n"
+	case NodeBulletList, NodeDecisionList:
+		return "</ul>\n"
+	case NodeOrderedList:
+		return "</ol>\n"
+	case ChildNodeListItem, ChildNodeTaskItem, ChildNodeDecisionItem:
+		return "</li>\n"
+	case NodeCodeBlock:
+		return "</code></pre>\n"
+	case NodeBlockquote:
+		return "</blockquote>\n"
+	case NodePanel, NodeBodiedExtension, NodeMediaGroup, NodeLayoutSection, ChildNodeLayoutColumn:
+		return "</div>\n"
+	case NodeRule:
+		return "\n"
+	case NodeTable:
+		return "</tbody></table>\n"
+	case ChildNodeTableRow:
+		return "</tr>\n"
+	case ChildNodeTableHeader:
+		return "</th>"
+	case ChildNodeTableCell:
+		return "</td>"
+	case NodeExpand, NodeNestedExpand:
+		return "</details>\n"
+	case NodeMediaSingle:
+		tr.figure = false
+		return "</figure>\n"
+	case ChildNodeCaption:
+		return "</figcaption>"
+	case MarkStrong:
+		return "</strong>"
+	case MarkEm:
+		return "</em>"
+	case MarkCode:
+		return "</code>"
+	case MarkStrike:
+		return "</s>"
+	case MarkUnderline:
+		return "</u>"
+	case MarkLink:
+		return "</a>"
+	case MarkSubSup:
+		if attrString(n.GetAttributes(), "type") == "sup" {
+			return "</sup>"
+		}
+		return "</sub>"
+	case MarkTextColor, MarkBackgroundColor:
+		return "</span>"
+	}
+
+	return ""
+}
+
+// tag returns an opening tag with the class of the node and the escaped
+// attributes given as key value pairs.
+func (tr *HTMLTranslator) tag(n Connector, name string, kv ...string) string {
+	var tag strings.Builder
+
+	tag.WriteString("<" + name)
+	if hook, ok := tr.classHooks[n.GetType()]; ok {
+		if class := hook(n); class != "" {
+			tag.WriteString(fmt.Sprintf(` class="%s"`, html.EscapeString(class)))
+		}
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		tag.WriteString(fmt.Sprintf(` %s="%s"`, kv[i], html.EscapeString(kv[i+1])))
+	}
+	tag.WriteString(">")
+
+	return tag.String()
+}
+
+func htmlPanelClass(n Connector) string {
+	class := "adf-panel"
+	if pt := attrString(n.GetAttributes(), "panelType"); slices.Contains(panelTypeValues, pt) {
+		class += " adf-panel-" + pt
+	}
+	return class
+}
+
+func htmlStatusClass(n Connector) string {
+	class := "adf-status"
+	if color := attrString(n.GetAttributes(), "color"); slices.Contains(statusColors, color) {
+		class += " adf-status-" + color
+	}
+	return class
+}
+
+func htmlHeadingLevel(attrs any) int {
+	return min(max(attrInt(getAttr(attrs, "level")), 1), 6)
+}
+
+func getAttr(a any, key string) any {
+	attrs, _ := a.(map[string]any)
+	return attrs[key]
+}
+
+// safeURL returns the URL if it's relative or uses a safe scheme, it
+// returns an empty string otherwise, eg: for javascript: URLs.
+func safeURL(s string) string {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil || s == "" {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return u.String()
+	}
+	return ""
+}
@@ -0,0 +1,133 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const renderDoc = `{"version": 1, "type": "doc", "content": [
+	{"type": "heading", "attrs": {"level": 2}, "content": [{"type": "text", "text": "Release <v1.2>"}]},
+	{"type": "paragraph", "content": [
+		{"type": "text", "text": "Deployed", "marks": [{"type": "strong"}]},
+		{"type": "text", "text": " by "},
+		{"type": "mention", "attrs": {"id": "5b10a2844c20165700ede21g", "text": "@Jane"}},
+		{"type": "text", "text": " "},
+		{"type": "emoji", "attrs": {"shortName": ":rocket:", "text": "🚀"}},
+		{"type": "text", "text": " see "},
+		{"type": "text", "text": "docs", "marks": [{"type": "link", "attrs": {"href": "https://example.com/docs?a=1&b=2"}}]},
+		{"type": "text", "text": " or "},
+		{"type": "text", "text": "this", "marks": [{"type": "link", "attrs": {"href": "javascript:alert(1)"}}]},
+		{"type": "hardBreak"},
+		{"type": "status", "attrs": {"text": "DONE", "color": "green"}},
+		{"type": "text", "text": " on "},
+		{"type": "date", "attrs": {"timestamp": "1735689600000"}}
+	]},
+	{"type": "panel", "attrs": {"panelType": "warning"}, "content": [
+		{"type": "paragraph", "content": [{"type": "text", "text": "Restart", "marks": [{"type": "textColor", "attrs": {"color": "red;background:url(x)"}}]}]}
+	]},
+	{"type": "orderedList", "content": [
+		{"type": "listItem", "content": [
+			{"type": "paragraph", "content": [{"type": "text", "text": "Migrate"}]},
+			{"type": "codeBlock", "attrs": {"language": "sh"}, "content": [{"type": "text", "text": "make <migrate>"}]}
+		]}
+	]},
+	{"type": "taskList", "attrs": {"localId": "1"}, "content": [
+		{"type": "taskItem", "attrs": {"localId": "2", "state": "DONE"}, "content": [{"type": "text", "text": "Tests"}]},
+		{"type": "taskList", "attrs": {"localId": "3"}, "content": [
+			{"type": "taskItem", "attrs": {"localId": "4", "state": "TODO"}, "content": [{"type": "text", "text": "Review"}]}
+		]}
+	]},
+	{"type": "table", "content": [
+		{"type": "tableRow", "content": [
+			{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Service"}]}]},
+			{"type": "tableHeader", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Status"}]}]}
+		]},
+		{"type": "tableRow", "content": [
+			{"type": "tableCell", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "api"}]}]},
+			{"type": "tableCell", "attrs": {"colspan": 2, "background": "#e3fcef"}, "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "up"}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "since May"}]}
+			]}
+		]}
+	]},
+	{"type": "mediaSingle", "content": [
+		{"type": "media", "attrs": {"type": "file", "id": "abc", "collection": "jira", "alt": "screen.png"}},
+		{"type": "caption", "content": [{"type": "text", "text": "Screenshot"}]}
+	]},
+	{"type": "mediaGroup", "content": [{"type": "media", "attrs": {"type": "file", "id": "def", "collection": "jira", "alt": "log.txt"}}]},
+	{"type": "expand", "attrs": {"title": "Details"}, "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Hidden"}]}]},
+	{"type": "futureNode", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "From the future"}]}]}
+]}`
+
+func TestHTMLTranslator(t *testing.T) {
+	var doc ADF
+	assert.NoError(t, json.Unmarshal([]byte(renderDoc), &doc))
+
+	tr := NewTranslator(&doc, NewHTMLTranslator())
+	expected := `<h2>Release &lt;v1.2&gt;</h2>
+<p><strong>Deployed</strong> by <span class="adf-mention" data-account-id="5b10a2844c20165700ede21g">@Jane</span> 🚀 see ` +
+		`<a href="https://example.com/docs?a=1&amp;b=2">docs</a> or <a>this</a><br>` +
+		`<span class="adf-status adf-status-green">DONE</span> on <time datetime="2025-01-01">2025-01-01</time></p>
+<div class="adf-panel adf-panel-warning"><p><span>Restart</span></p>
+</div>
+<ol><li><p>Migrate</p>
+<pre><code class="language-sh">make &lt;migrate&gt;</code></pre>
+</li>
+</ol>
+<ul><li><input type="checkbox" disabled checked> Tests</li>
+<li><ul><li><input type="checkbox" disabled> Review</li>
+</ul></li>
+</ul>
+<table><tbody><tr><th><p>Service</p>
+</th><th><p>Status</p>
+</th></tr>
+<tr><td><p>api</p>
+</td><td colspan="2" style="background-color: #e3fcef"><p>up</p>
+<p>since May</p>
+</td></tr>
+</tbody></table>
+<figure><span>screen.png</span><figcaption>Screenshot</figcaption></figure>
+<div><span>log.txt</span></div>
+<details><summary>Details</summary><p>Hidden</p>
+</details>
+<p>From the future</p>
+`
+	assert.Equal(t, expected, tr.Translate())
+}
+
+func TestHTMLTranslatorHooks(t *testing.T) {
+	doc := Doc().
+		Panel(PanelInfo, Paragraph(Text("Note"))).
+		Paragraph(Mention("5b10a2844c20165700ede21g"), Text(" and "), Mention("unknown")).
+		Build()
+	doc.Content = append(doc.Content,
+		&Node{NodeType: NodeMediaSingle, Content: []*Node{{NodeType: NodeMedia, Attributes: map[string]any{"type": "file", "id": "abc", "collection": "jira"}}}},
+		&Node{NodeType: NodeMediaGroup, Content: []*Node{{NodeType: NodeMedia, Attributes: map[string]any{"type": "file", "id": "def", "collection": "jira"}}}},
+		&Node{NodeType: "futureNode", Content: []*Node{{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: "Skipped"}}}}}},
+	)
+
+	tr := NewTranslator(doc, NewHTMLTranslator(
+		WithHTMLClassHooks(nodeTypeHook{
+			NodePanel:         func(Connector) string { return "callout" },
+			InlineNodeMention: func(Connector) string { return "" },
+			NodeParagraph:     func(Connector) string { return "text" },
+		}),
+		WithHTMLMentionResolver(func(id string) (string, bool) {
+			return "Jane Doe", id == "5b10a2844c20165700ede21g"
+		}),
+		WithHTMLMediaResolver(func(id, collection string) (Media, bool) {
+			return Media{URL: "https://example.atlassian.net/attachment/" + id, Name: id + ".png"}, true
+		}),
+		WithHTMLFallback(SkipUnknown),
+	))
+
+	expected := `<div class="callout"><p class="text">Note</p>
+</div>
+<p class="text"><span data-account-id="5b10a2844c20165700ede21g">@Jane Doe</span> and <span data-account-id="unknown">@unknown</span></p>
+<figure><img src="https://example.atlassian.net/attachment/abc" alt="abc.png"></figure>
+<div><a href="https://example.atlassian.net/attachment/def">def.png</a></div>
+`
+	assert.Equal(t, expected, tr.Translate())
+}
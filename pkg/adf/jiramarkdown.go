@@ -25,20 +25,25 @@ type JiraMarkdownTranslator struct {
 }
 
 // NewJiraMarkdownTranslator constructs jira markdown translator.
-func NewJiraMarkdownTranslator() *JiraMarkdownTranslator {
+// The options are applied after the jira specific hooks.
+func NewJiraMarkdownTranslator(opts ...MarkdownTranslatorOption) *JiraMarkdownTranslator {
 	openHooks := nodeTypeHook{
-		NodePanel: nodePanelOpenHook,
+		NodePanel:        nodePanelOpenHook,
+		NodeExpand:       nodeExpandOpenHook,
+		NodeNestedExpand: nodeExpandOpenHook,
 	}
 
 	closeHooks := nodeTypeHook{
-		NodePanel: nodePanelCloseHook,
+		NodePanel:        nodePanelCloseHook,
+		NodeExpand:       nodePanelCloseHook,
+		NodeNestedExpand: nodePanelCloseHook,
 	}
 
 	return &JiraMarkdownTranslator{
-		MarkdownTranslator: NewMarkdownTranslator(
+		MarkdownTranslator: NewMarkdownTranslator(append([]MarkdownTranslatorOption{
 			WithMarkdownOpenHooks(openHooks),
 			WithMarkdownCloseHooks(closeHooks),
-		),
+		}, opts...)...),
 	}
 }
 
@@ -87,6 +92,15 @@ func nodePanelOpenHook(n Connector) string {
 	return tag.String()
 }
 
+// nodeExpandOpenHook renders an expand as a panel with its title
+// as jira has no collapsible sections.
+func nodeExpandOpenHook(n Connector) string {
+	if title := attrString(n.GetAttributes(), "title"); title != "" {
+		return fmt.Sprintf("\n{panel:title=%s}\n", title)
+	}
+	return "\n{panel}\n"
+}
+
 func nodePanelCloseHook(Connector) string {
 	return "{panel}\n"
 }
@@ -3,7 +3,9 @@ package adf
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type nodeTypeHook map[NodeType]func(Connector) string
@@ -20,10 +22,12 @@ type MarkdownTranslator struct {
 		ol, ul  map[int]bool
 		depthO  int
 		depthU  int
+		depthT  int         // task lists are nested in each other.
 		counter map[int]int // each level starts with same numeric counter at the moment.
 	}
 	openHooks  nodeTypeHook
 	closeHooks nodeTypeHook
+	fallback   UnknownNodeFunc
 }
 
 // MarkdownTranslatorOption is a functional option for MarkdownTranslator.
@@ -36,6 +40,7 @@ func NewMarkdownTranslator(opts ...MarkdownTranslatorOption) *MarkdownTranslator
 			ol, ul  map[int]bool
 			depthO  int
 			depthU  int
+			depthT  int
 			counter map[int]int
 		}{
 			ol:      make(map[int]bool),
@@ -65,6 +70,22 @@ func WithMarkdownCloseHooks(hooks nodeTypeHook) MarkdownTranslatorOption {
 	}
 }
 
+// WithMarkdownFallback sets the translation of nodes of unknown types.
+// By default their content is kept, see KeepUnknownContent and SkipUnknown.
+func WithMarkdownFallback(fn UnknownNodeFunc) MarkdownTranslatorOption {
+	return func(tr *MarkdownTranslator) {
+		tr.fallback = fn
+	}
+}
+
+// Unknown implements UnknownNodeTranslator interface.
+func (tr *MarkdownTranslator) Unknown(n *Node, content string) string {
+	if tr.fallback == nil {
+		return KeepUnknownContent(n, content)
+	}
+	return tr.fallback(n, content)
+}
+
 // Open implements TagOpener interface.
 //
 //nolint:gocyclo
@@ -101,6 +122,29 @@ func (tr *MarkdownTranslator) Open(n Connector, _ int) string {
 			tag.WriteString("\n")
 		case NodeMedia:
 			tag.WriteString("\n[attachment]")
+		case NodeRule:
+			tag.WriteString("---\n")
+		case NodeExpand, NodeNestedExpand:
+			if title := attrString(attrs, "title"); title != "" {
+				tag.WriteString(fmt.Sprintf("**%s**\n\n", title))
+			}
+		case NodeBlockCard, NodeEmbedCard:
+			tag.WriteString("📍 ")
+		case NodeTaskList:
+			tr.list.depthT++
+		case ChildNodeTaskItem:
+			for range tr.list.depthT - 1 {
+				tag.WriteString("\t")
+			}
+			if attrString(attrs, "state") == "DONE" {
+				tag.WriteString("- [x] ")
+			} else {
+				tag.WriteString("- [ ] ")
+			}
+		case ChildNodeDecisionItem:
+			tag.WriteString("- ✓ ")
+		case ChildNodeCaption:
+			tag.WriteString("\n_")
 		case NodeBulletList:
 			tr.list.depthU++
 			tr.list.ul[tr.list.depthU] = true
@@ -139,7 +183,24 @@ func (tr *MarkdownTranslator) Open(n Connector, _ int) string {
 		case InlineNodeHardBreak:
 			tag.WriteString("\n\n")
 		case InlineNodeMention:
-			tag.WriteString(" @")
+			name := attrString(attrs, "text")
+			if name == "" {
+				name = attrString(attrs, "id")
+			}
+			return " @" + strings.TrimPrefix(name, "@")
+		case InlineNodeEmoji:
+			if attrString(attrs, "text") == "" {
+				tag.WriteString(attrString(attrs, "shortName"))
+			}
+		case InlineNodeStatus:
+			tag.WriteString(" [")
+		case InlineNodeDate:
+			tag.WriteString(" " + attrDate(attrs))
+		case InlineNodeMediaInline:
+			tag.WriteString(" [attachment]")
+		case InlineNodePlaceholder:
+			// Placeholders are hints shown in the editor only.
+			return ""
 		case InlineNodeCard:
 			tag.WriteString(" 📍 ")
 		case MarkStrong:
@@ -178,6 +239,24 @@ func (tr *MarkdownTranslator) Close(n Connector) string {
 			tag.WriteString("\n```\n")
 		case NodePanel:
 			tag.WriteString("---\n")
+		case NodeMediaSingle, NodeMediaGroup:
+			tag.WriteString("\n")
+		case NodeBlockCard, NodeEmbedCard:
+			return attrString(n.GetAttributes(), "url") + "\n\n"
+		case NodeExtension:
+			if attrString(n.GetAttributes(), "text") != "" {
+				tag.WriteString("\n\n")
+			}
+		case NodeTaskList:
+			if tr.list.depthT--; tr.list.depthT == 0 {
+				tag.WriteString("\n")
+			}
+		case NodeDecisionList:
+			tag.WriteString("\n")
+		case ChildNodeTaskItem, ChildNodeDecisionItem:
+			tag.WriteString("\n")
+		case ChildNodeCaption:
+			tag.WriteString("_\n")
 		case NodeHeading:
 			tag.WriteString("\n")
 		case NodeBulletList:
@@ -210,8 +289,10 @@ func (tr *MarkdownTranslator) Close(n Connector) string {
 			}
 		case InlineNodeMention:
 			tag.WriteString(" ")
-		case InlineNodeEmoji:
+		case InlineNodeEmoji, InlineNodeDate, InlineNodeMediaInline:
 			tag.WriteString(" ")
+		case InlineNodeStatus:
+			tag.WriteString("] ")
 		case MarkStrong:
 			tag.WriteString("** ")
 		case MarkEm:
@@ -248,7 +329,7 @@ func (tr *MarkdownTranslator) setOpenTagAttributes(a any) string {
 				tag.WriteString(fmt.Sprintf("%s", v))
 				nl = true
 			case "level":
-				for range int(v.(float64)) {
+				for range attrInt(v) {
 					tag.WriteString("#")
 				}
 				tag.WriteString(" ")
@@ -282,6 +363,42 @@ func (*MarkdownTranslator) setCloseTagAttributes(a any) string {
 	return tag.String()
 }
 
+// attrInt returns the value of a numeric attribute. Attributes are float64
+// if they are decoded from JSON and int if they are built, see FromMarkdown.
+func attrInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+// attrString returns the value of a string attribute.
+func attrString(a any, key string) string {
+	attrs, _ := a.(map[string]any)
+	s, _ := attrs[key].(string)
+	return s
+}
+
+// attrDate formats the timestamp of a date node, in milliseconds
+// since the epoch, as a UTC date.
+func attrDate(a any) string {
+	attrs, _ := a.(map[string]any)
+
+	var ms int64
+	switch v := attrs["timestamp"].(type) {
+	case string:
+		ms, _ = strconv.ParseInt(v, 10, 64)
+	case float64:
+		ms = int64(v)
+	case int:
+		ms = int64(v)
+	}
+	return time.UnixMilli(ms).UTC().Format(time.DateOnly)
+}
+
 func (*MarkdownTranslator) isValidAttr(attr string) bool {
 	known := []string{"language", "level", "text"}
 	return slices.Contains(known, attr)
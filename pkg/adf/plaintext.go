@@ -0,0 +1,140 @@
+package adf
+
+// PlainTextTranslator is a plain text translator, eg: to index documents
+// for search. Blocks and list items are written on separate lines, table
+// cells are separated by tabs and all formatting is dropped.
+type PlainTextTranslator struct {
+	mention  MentionResolver
+	media    MediaResolver
+	fallback UnknownNodeFunc
+	table    bool // inside a table.
+	cell     int  // current column of a table row.
+	blocks   int  // blocks of the current cell.
+}
+
+// PlainTextTranslatorOption is a functional option for PlainTextTranslator.
+type PlainTextTranslatorOption func(*PlainTextTranslator)
+
+// NewPlainTextTranslator constructs plain text translator.
+func NewPlainTextTranslator(opts ...PlainTextTranslatorOption) *PlainTextTranslator {
+	var tr PlainTextTranslator
+
+	for _, opt := range opts {
+		opt(&tr)
+	}
+
+	return &tr
+}
+
+// WithPlainTextMentionResolver sets the resolver of mentioned account names.
+func WithPlainTextMentionResolver(fn MentionResolver) PlainTextTranslatorOption {
+	return func(tr *PlainTextTranslator) {
+		tr.mention = fn
+	}
+}
+
+// WithPlainTextMediaResolver sets the resolver of media names.
+func WithPlainTextMediaResolver(fn MediaResolver) PlainTextTranslatorOption {
+	return func(tr *PlainTextTranslator) {
+		tr.media = fn
+	}
+}
+
+// WithPlainTextFallback sets the translation of nodes of unknown types.
+// By default their content is kept, see KeepUnknownContent and SkipUnknown.
+func WithPlainTextFallback(fn UnknownNodeFunc) PlainTextTranslatorOption {
+	return func(tr *PlainTextTranslator) {
+		tr.fallback = fn
+	}
+}
+
+// Unknown implements UnknownNodeTranslator interface.
+func (tr *PlainTextTranslator) Unknown(n *Node, content string) string {
+	if tr.fallback == nil {
+		return KeepUnknownContent(n, content)
+	}
+	return tr.fallback(n, content)
+}
+
+// Text implements TextTranslator interface.
+func (*PlainTextTranslator) Text(s string) string {
+	return s
+}
+
+// Open implements TagOpener interface.
+func (tr *PlainTextTranslator) Open(n Connector, _ int) string {
+	switch n.GetType() {
+	case NodeTable:
+		tr.table = true
+	case ChildNodeTableRow:
+		tr.cell = 0
+	case ChildNodeTableHeader, ChildNodeTableCell:
+		tr.cell++
+		tr.blocks = 0
+		if tr.cell > 1 {
+			return "\t"
+		}
+	case NodeParagraph, NodeHeading, NodeCodeBlock:
+		// Blocks of a cell are joined on a line.
+		if tr.table {
+			if tr.blocks++; tr.blocks > 1 {
+				return " "
+			}
+		}
+	case NodeExpand, NodeNestedExpand:
+		if title := attrString(n.GetAttributes(), "title"); title != "" {
+			return title + "\n"
+		}
+	case InlineNodeHardBreak:
+		return "\n"
+	case NodeMedia, NodeBlockCard, NodeEmbedCard, NodeExtension:
+		if v := tr.value(n); v != "" {
+			return v + "\n"
+		}
+		return ""
+	}
+
+	return tr.value(n)
+}
+
+// Close implements TagCloser interface.
+func (tr *PlainTextTranslator) Close(n Connector) string {
+	switch n.GetType() {
+	case NodeParagraph, NodeHeading, NodeCodeBlock:
+		if !tr.table {
+			return "\n"
+		}
+	case ChildNodeTaskItem, ChildNodeDecisionItem, ChildNodeCaption, ChildNodeTableRow:
+		return "\n"
+	case NodeTable:
+		tr.table = false
+	}
+
+	return ""
+}
+
+// value returns the text of a node without content, eg: a mention.
+func (tr *PlainTextTranslator) value(n Connector) string {
+	attrs := n.GetAttributes()
+
+	switch n.GetType() {
+	case NodeMedia, InlineNodeMediaInline:
+		m := resolveMedia(n, tr.media)
+		if m.Name != "" {
+			return m.Name
+		}
+		return m.URL
+	case NodeBlockCard, NodeEmbedCard, InlineNodeCard:
+		return attrString(attrs, "url")
+	case NodeExtension, InlineNodeExtension, InlineNodeStatus:
+		return attrString(attrs, "text")
+	case InlineNodeMention:
+		return "@" + resolveMention(n, tr.mention)
+	case InlineNodeEmoji:
+		return emojiText(n)
+	case InlineNodeDate:
+		return attrDate(attrs)
+	}
+
+	return ""
+}
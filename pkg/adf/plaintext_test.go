@@ -0,0 +1,46 @@
+package adf
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlainTextTranslator(t *testing.T) {
+	var doc ADF
+	assert.NoError(t, json.Unmarshal([]byte(renderDoc), &doc))
+
+	tr := NewTranslator(&doc, NewPlainTextTranslator())
+	expected := "Release <v1.2>\n" +
+		"Deployed by @Jane 🚀 see docs or this\nDONE on 2025-01-01\n" +
+		"Restart\n" +
+		"Migrate\nmake <migrate>\n" +
+		"Tests\nReview\n" +
+		"Service\tStatus\napi\tup since May\n" +
+		"screen.png\nScreenshot\n" +
+		"log.txt\n" +
+		"Details\nHidden\n" +
+		"From the future\n"
+	assert.Equal(t, expected, tr.Translate())
+}
+
+func TestPlainTextTranslatorHooks(t *testing.T) {
+	doc := Doc().
+		Paragraph(Text("Ping "), Mention("5b10a2844c20165700ede21g"), Text(" "), Emoji("tada")).
+		Build()
+	doc.Content = append(doc.Content,
+		&Node{NodeType: NodeMediaGroup, Content: []*Node{
+			{NodeType: NodeMedia, Attributes: map[string]any{"type": "file", "id": "abc", "collection": "jira"}},
+			{NodeType: NodeMedia, Attributes: map[string]any{"type": "external", "url": "https://example.com/logo.png"}},
+		}},
+		&Node{NodeType: "futureNode", Content: []*Node{{NodeType: NodeParagraph, Content: []*Node{{NodeType: ChildNodeText, NodeValue: NodeValue{Text: "Future"}}}}}},
+	)
+
+	tr := NewTranslator(doc, NewPlainTextTranslator(
+		WithPlainTextMentionResolver(func(string) (string, bool) { return "@Jane Doe", true }),
+		WithPlainTextMediaResolver(func(id, _ string) (Media, bool) { return Media{Name: id + ".png"}, true }),
+		WithPlainTextFallback(func(n *Node, content string) string { return "[" + string(n.NodeType) + "] " + content }),
+	))
+	assert.Equal(t, "Ping @Jane Doe :tada:\nabc.png\nhttps://example.com/logo.png\n[futureNode] Future\n", tr.Translate())
+}
@@ -0,0 +1,64 @@
+package adf
+
+import "strings"
+
+// MentionResolver returns the display name of a mentioned account, eg: from
+// a user lookup. The text of the mention is used if ok is false.
+type MentionResolver func(accountID string) (name string, ok bool)
+
+// Media is a media node resolved by a MediaResolver.
+type Media struct {
+	// URL links to the content of the media, eg: an attachment.
+	URL string
+	// Name is the file name or description of the media.
+	Name string
+}
+
+// MediaResolver resolves file and link media by their id and collection,
+// eg: to the attachments of an issue. Media are rendered with their alt
+// text only if ok is false.
+type MediaResolver func(id, collection string) (media Media, ok bool)
+
+// resolveMention returns the name of a mentioned account without the @.
+func resolveMention(n Connector, resolve MentionResolver) string {
+	attrs := n.GetAttributes()
+	id := attrString(attrs, "id")
+	if resolve != nil {
+		if name, ok := resolve(id); ok {
+			return strings.TrimPrefix(name, "@")
+		}
+	}
+	if text := attrString(attrs, "text"); text != "" {
+		return strings.TrimPrefix(text, "@")
+	}
+	return id
+}
+
+// resolveMedia returns the link and name of a media node. External media
+// link to their url.
+func resolveMedia(n Connector, resolve MediaResolver) Media {
+	attrs := n.GetAttributes()
+	m := Media{Name: attrString(attrs, "alt")}
+	if attrString(attrs, "type") == "external" {
+		m.URL = attrString(attrs, "url")
+		return m
+	}
+	if resolve != nil {
+		if r, ok := resolve(attrString(attrs, "id"), attrString(attrs, "collection")); ok {
+			if r.Name == "" {
+				r.Name = m.Name
+			}
+			return r
+		}
+	}
+	return m
+}
+
+// emojiText returns the emoji character of an emoji node or its shortcode.
+func emojiText(n Connector) string {
+	attrs := n.GetAttributes()
+	if text := attrString(attrs, "text"); text != "" {
+		return text
+	}
+	return attrString(attrs, "shortName")
+}
@@ -0,0 +1,459 @@
+package adf
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// maxListDepth is the maximum depth of nested lists supported by the editor.
+const maxListDepth = 6
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+var (
+	inlineContent = []NodeType{
+		ChildNodeText, InlineNodeMention, InlineNodeEmoji, InlineNodeHardBreak, InlineNodeCard,
+		InlineNodeDate, InlineNodeStatus, InlineNodePlaceholder, InlineNodeMediaInline, InlineNodeExtension,
+	}
+	docContent = []NodeType{
+		NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeBlockquote,
+		NodePanel, NodeRule, NodeTable, NodeExpand, NodeMediaSingle, NodeMediaGroup, NodeTaskList,
+		NodeDecisionList, NodeLayoutSection, NodeBlockCard, NodeEmbedCard, NodeExtension, NodeBodiedExtension,
+	}
+	cellContent = []NodeType{
+		NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeBlockquote,
+		NodePanel, NodeRule, NodeMediaSingle, NodeMediaGroup, NodeTaskList, NodeDecisionList,
+		NodeBlockCard, NodeEmbedCard, NodeExtension, NodeNestedExpand,
+	}
+	expandContent = []NodeType{
+		NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeBlockquote,
+		NodePanel, NodeRule, NodeTable, NodeMediaSingle, NodeMediaGroup, NodeTaskList, NodeDecisionList,
+		NodeBlockCard, NodeEmbedCard, NodeExtension, NodeNestedExpand,
+	}
+	nestedExpandContent = []NodeType{
+		NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeBlockquote,
+		NodePanel, NodeRule, NodeMediaSingle, NodeMediaGroup, NodeTaskList, NodeDecisionList, NodeExtension,
+	}
+	columnContent = slices.DeleteFunc(slices.Clone(docContent), func(t NodeType) bool { return t == NodeLayoutSection })
+	listContent   = []NodeType{NodeParagraph, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeMediaSingle}
+
+	textMarks  = []NodeType{MarkEm, MarkStrong, MarkStrike, MarkCode, MarkUnderline, MarkLink, MarkSubSup, MarkTextColor, MarkBackgroundColor, MarkAnnotation}
+	blockMarks = []NodeType{MarkAlignment, MarkIndentation}
+
+	// excludedMarks lists the marks that can't be combined with a mark.
+	excludedMarks = map[NodeType][]NodeType{
+		MarkCode:            {MarkEm, MarkStrong, MarkStrike, MarkUnderline, MarkSubSup, MarkTextColor, MarkBackgroundColor},
+		MarkTextColor:       {MarkCode, MarkLink},
+		MarkBackgroundColor: {MarkCode},
+		MarkAlignment:       {MarkIndentation},
+	}
+
+	panelTypeValues  = []string{"info", "note", "tip", "warning", "error", "success", "custom"}
+	statusColors     = []string{"neutral", "purple", "blue", "red", "yellow", "green"}
+	itemStates       = []string{"TODO", "DONE"}
+	decisionStates   = []string{"DECIDED"}
+	mediaTypes       = []string{"file", "link", "external"}
+	alignmentValues  = []string{"center", "end"}
+	breakoutModes    = []string{"wide", "full-width"}
+	subSupTypes      = []string{"sub", "sup"}
+	embedCardLayouts = []string{"wide", "full-width", "center", "wrap-right", "wrap-left", "align-end", "align-start"}
+)
+
+// nodeSpec describes the content, attributes and marks allowed in a node.
+type nodeSpec struct {
+	content []NodeType
+	// first restricts the type of the first child.
+	first []NodeType
+	// min is the minimum number of children.
+	min   int
+	marks []NodeType
+	attrs func(v *validator, path string, attrs map[string]any)
+}
+
+var nodeSpecs = map[NodeType]nodeSpec{
+	NodeParagraph: {content: inlineContent, marks: blockMarks},
+	NodeHeading: {content: inlineContent, marks: blockMarks, attrs: func(v *validator, path string, a map[string]any) {
+		v.intAttr(path, a, "level", true, 1, 6)
+	}},
+	NodeBulletList: {content: []NodeType{ChildNodeListItem}, min: 1},
+	NodeOrderedList: {content: []NodeType{ChildNodeListItem}, min: 1, attrs: func(v *validator, path string, a map[string]any) {
+		v.intAttr(path, a, "order", false, 0, -1)
+	}},
+	ChildNodeListItem: {content: listContent, first: []NodeType{NodeParagraph, NodeMediaSingle, NodeCodeBlock}, min: 1},
+	NodeCodeBlock: {content: []NodeType{ChildNodeText}, marks: []NodeType{MarkBreakout}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "language", false)
+	}},
+	NodeBlockquote: {content: []NodeType{NodeParagraph, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeMediaGroup, NodeMediaSingle}, min: 1},
+	NodePanel: {
+		content: []NodeType{
+			NodeParagraph, NodeHeading, NodeBulletList, NodeOrderedList, NodeCodeBlock, NodeRule, NodeBlockCard,
+			NodeMediaGroup, NodeMediaSingle, NodeTaskList, NodeDecisionList, NodeExtension,
+		},
+		min: 1,
+		attrs: func(v *validator, path string, a map[string]any) {
+			v.enumAttr(path, a, "panelType", true, panelTypeValues)
+		},
+	},
+	NodeRule:  {},
+	NodeTable: {content: []NodeType{ChildNodeTableRow}, min: 1},
+	ChildNodeTableRow: {
+		content: []NodeType{ChildNodeTableHeader, ChildNodeTableCell},
+		min:     1,
+	},
+	ChildNodeTableHeader: {content: cellContent, min: 1, attrs: cellAttrs},
+	ChildNodeTableCell:   {content: cellContent, min: 1, attrs: cellAttrs},
+	NodeExpand: {content: expandContent, min: 1, marks: []NodeType{MarkBreakout}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "title", false)
+	}},
+	NodeNestedExpand: {content: nestedExpandContent, min: 1, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "title", false)
+	}},
+	NodeMediaSingle: {content: []NodeType{NodeMedia, ChildNodeCaption}, first: []NodeType{NodeMedia}, min: 1, marks: []NodeType{MarkLink}},
+	NodeMediaGroup:  {content: []NodeType{NodeMedia}, min: 1},
+	NodeMedia:       {marks: []NodeType{MarkLink, MarkBorder, MarkAnnotation}, attrs: mediaAttrs},
+	ChildNodeCaption: {content: []NodeType{
+		ChildNodeText, InlineNodeMention, InlineNodeEmoji, InlineNodeHardBreak, InlineNodeCard, InlineNodeDate, InlineNodeStatus, InlineNodePlaceholder,
+	}},
+	NodeTaskList: {content: []NodeType{ChildNodeTaskItem, NodeTaskList}, first: []NodeType{ChildNodeTaskItem}, min: 1, attrs: localIDAttrs},
+	ChildNodeTaskItem: {content: inlineContent, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "localId", true)
+		v.enumAttr(path, a, "state", true, itemStates)
+	}},
+	NodeDecisionList: {content: []NodeType{ChildNodeDecisionItem}, min: 1, attrs: localIDAttrs},
+	ChildNodeDecisionItem: {content: inlineContent, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "localId", true)
+		v.enumAttr(path, a, "state", true, decisionStates)
+	}},
+	NodeLayoutSection: {content: []NodeType{ChildNodeLayoutColumn}, min: 1, marks: []NodeType{MarkBreakout}},
+	ChildNodeLayoutColumn: {content: columnContent, min: 1, attrs: func(v *validator, path string, a map[string]any) {
+		v.numberAttr(path, a, "width", true, 0, 100)
+	}},
+	NodeBlockCard: {attrs: cardAttrs},
+	NodeEmbedCard: {attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "url", true)
+		v.enumAttr(path, a, "layout", true, embedCardLayouts)
+	}},
+	NodeExtension:       {marks: blockMarks, attrs: extensionAttrs},
+	NodeBodiedExtension: {content: columnContent, min: 1, attrs: extensionAttrs},
+	ChildNodeText:       {marks: textMarks},
+	InlineNodeMention: {marks: []NodeType{MarkAnnotation}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "id", true)
+	}},
+	InlineNodeEmoji: {marks: []NodeType{MarkAnnotation}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "shortName", true)
+	}},
+	InlineNodeHardBreak: {},
+	InlineNodeCard:      {marks: []NodeType{MarkAnnotation}, attrs: cardAttrs},
+	InlineNodeDate: {marks: []NodeType{MarkAnnotation}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "timestamp", true)
+	}},
+	InlineNodeStatus: {marks: []NodeType{MarkAnnotation}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "text", true)
+		v.enumAttr(path, a, "color", true, statusColors)
+	}},
+	InlineNodePlaceholder: {attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "text", true)
+	}},
+	InlineNodeMediaInline: {marks: []NodeType{MarkLink, MarkBorder, MarkAnnotation}, attrs: func(v *validator, path string, a map[string]any) {
+		v.stringAttr(path, a, "id", true)
+		v.stringAttr(path, a, "collection", true)
+	}},
+	InlineNodeExtension: {marks: []NodeType{MarkAnnotation}, attrs: extensionAttrs},
+}
+
+func cellAttrs(v *validator, path string, a map[string]any) {
+	v.intAttr(path, a, "colspan", false, 1, -1)
+	v.intAttr(path, a, "rowspan", false, 1, -1)
+	v.stringAttr(path, a, "background", false)
+}
+
+func mediaAttrs(v *validator, path string, a map[string]any) {
+	if !v.enumAttr(path, a, "type", true, mediaTypes) {
+		return
+	}
+	if a["type"] == "external" {
+		v.stringAttr(path, a, "url", true)
+		return
+	}
+	v.stringAttr(path, a, "id", true)
+	v.stringAttr(path, a, "collection", true)
+}
+
+func cardAttrs(v *validator, path string, a map[string]any) {
+	if _, ok := a["data"]; ok {
+		return
+	}
+	v.stringAttr(path, a, "url", true)
+}
+
+func localIDAttrs(v *validator, path string, a map[string]any) {
+	v.stringAttr(path, a, "localId", true)
+}
+
+func extensionAttrs(v *validator, path string, a map[string]any) {
+	v.stringAttr(path, a, "extensionKey", true)
+	v.stringAttr(path, a, "extensionType", true)
+}
+
+// ValidationError is a schema violation at a path of the document,
+// eg: content[0].content[2].marks[1].
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors lists all schema violations of a document.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the violations to use with errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Validate checks the document against the ADF schema: the allowed
+// children of each node, the required attributes, the marks allowed
+// on each node and their combinations, and the nesting of lists.
+//
+// It returns ValidationErrors with all violations found or nil if
+// the document is valid.
+func (a *ADF) Validate() error {
+	if a == nil {
+		return ValidationErrors{{Path: "doc", Message: "document is nil"}}
+	}
+
+	v := validator{}
+	if a.Version != 1 {
+		v.errorf("version", "must be 1, got %d", a.Version)
+	}
+	if a.DocType != "doc" {
+		v.errorf("type", "must be \"doc\", got %q", a.DocType)
+	}
+	v.content("", a.Content, nodeSpec{content: docContent}, 0)
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+type validator struct {
+	errs ValidationErrors
+}
+
+func (v *validator) errorf(path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// content validates the children of a node with the given spec.
+// The depth is the number of lists the children are nested in.
+func (v *validator) content(path string, nodes []*Node, spec nodeSpec, depth int) {
+	if path != "" {
+		path += "."
+	}
+	if len(nodes) < spec.min {
+		v.errorf(strings.TrimSuffix(path, "."), "must have at least %d child node(s)", spec.min)
+	}
+
+	for i, n := range nodes {
+		p := fmt.Sprintf("%scontent[%d]", path, i)
+		if n == nil {
+			v.errorf(p, "node is nil")
+			continue
+		}
+		if _, ok := nodeSpecs[n.NodeType]; !ok {
+			v.errorf(p, "unknown node type %q", n.NodeType)
+			continue
+		}
+		if i == 0 && spec.first != nil && !slices.Contains(spec.first, n.NodeType) {
+			v.errorf(p, "%q is not allowed as the first child", n.NodeType)
+		} else if !slices.Contains(spec.content, n.NodeType) {
+			v.errorf(p, "%q is not allowed here", n.NodeType)
+		}
+		v.node(p, n, depth)
+	}
+}
+
+func (v *validator) node(path string, n *Node, depth int) {
+	spec := nodeSpecs[n.NodeType]
+
+	attrs, ok := n.Attributes.(map[string]any)
+	if n.Attributes != nil && !ok {
+		v.errorf(path+".attrs", "must be an object")
+	}
+	if spec.attrs != nil {
+		spec.attrs(v, path, attrs)
+	}
+
+	if n.NodeType == ChildNodeText {
+		if n.Text == "" {
+			v.errorf(path, "text must not be empty")
+		}
+	} else if n.Text != "" {
+		v.errorf(path, "text is not allowed on %q", n.NodeType)
+	}
+	v.marks(path, n.Marks, spec.marks)
+
+	if spec.content == nil {
+		if len(n.Content) > 0 {
+			v.errorf(path, "%q can't have child nodes", n.NodeType)
+		}
+		return
+	}
+
+	switch n.NodeType {
+	case NodeBulletList, NodeOrderedList, NodeTaskList:
+		if depth++; depth > maxListDepth {
+			v.errorf(path, "lists can't be nested more than %d levels deep", maxListDepth)
+			return
+		}
+	case NodeCodeBlock:
+		// Code is plain text.
+		for i, c := range n.Content {
+			if c != nil && len(c.Marks) > 0 {
+				v.errorf(fmt.Sprintf("%s.content[%d].marks", path, i), "marks are not allowed in %q", NodeCodeBlock)
+			}
+		}
+	}
+	v.content(path, n.Content, spec, depth)
+}
+
+func (v *validator) marks(path string, marks []MarkNode, allowed []NodeType) {
+	for i, m := range marks {
+		p := fmt.Sprintf("%s.marks[%d]", path, i)
+		if !slices.Contains(allowed, m.MarkType) {
+			v.errorf(p, "mark %q is not allowed here", m.MarkType)
+			continue
+		}
+		for _, o := range marks[:i] {
+			if o.MarkType == m.MarkType {
+				v.errorf(p, "duplicate mark %q", m.MarkType)
+			} else if slices.Contains(excludedMarks[m.MarkType], o.MarkType) || slices.Contains(excludedMarks[o.MarkType], m.MarkType) {
+				v.errorf(p, "mark %q can't be combined with %q", m.MarkType, o.MarkType)
+			}
+		}
+
+		attrs, ok := m.Attributes.(map[string]any)
+		if m.Attributes != nil && !ok {
+			v.errorf(p+".attrs", "must be an object")
+		}
+		switch m.MarkType {
+		case MarkLink:
+			v.stringAttr(p, attrs, "href", true)
+		case MarkSubSup:
+			v.enumAttr(p, attrs, "type", true, subSupTypes)
+		case MarkTextColor, MarkBackgroundColor:
+			v.colorAttr(p, attrs, "color", true)
+		case MarkBorder:
+			v.intAttr(p, attrs, "size", true, 1, 3)
+			v.stringAttr(p, attrs, "color", true)
+		case MarkAlignment:
+			v.enumAttr(p, attrs, "align", true, alignmentValues)
+		case MarkIndentation:
+			v.intAttr(p, attrs, "level", true, 1, 6)
+		case MarkBreakout:
+			v.enumAttr(p, attrs, "mode", true, breakoutModes)
+		case MarkAnnotation:
+			v.stringAttr(p, attrs, "id", true)
+		}
+	}
+}
+
+// attr looks up an attribute and reports it if it's required and missing.
+func (v *validator) attr(path string, attrs map[string]any, name string, required bool) (any, bool) {
+	val, ok := attrs[name]
+	if !ok || val == nil {
+		if required {
+			v.errorf(path+".attrs."+name, "is required")
+		}
+		return nil, false
+	}
+	return val, true
+}
+
+func (v *validator) stringAttr(path string, attrs map[string]any, name string, required bool) bool {
+	val, ok := v.attr(path, attrs, name, required)
+	if !ok {
+		return false
+	}
+	if _, ok := val.(string); !ok {
+		v.errorf(path+".attrs."+name, "must be a string")
+		return false
+	}
+	return true
+}
+
+func (v *validator) enumAttr(path string, attrs map[string]any, name string, required bool, values []string) bool {
+	if !v.stringAttr(path, attrs, name, required) {
+		return false
+	}
+	if s := attrs[name].(string); !slices.Contains(values, s) {
+		v.errorf(path+".attrs."+name, "must be one of %s, got %q", strings.Join(values, ", "), s)
+		return false
+	}
+	return true
+}
+
+func (v *validator) colorAttr(path string, attrs map[string]any, name string, required bool) {
+	if !v.stringAttr(path, attrs, name, required) {
+		return
+	}
+	if s := attrs[name].(string); !colorPattern.MatchString(s) {
+		v.errorf(path+".attrs."+name, "must be a hex color, eg: #ff5630, got %q", s)
+	}
+}
+
+// numberAttr checks a numeric attribute is in the range, a negative
+// max means the range is unbounded.
+func (v *validator) numberAttr(path string, attrs map[string]any, name string, required bool, lo, hi float64) (float64, bool) {
+	val, ok := v.attr(path, attrs, name, required)
+	if !ok {
+		return 0, false
+	}
+
+	var f float64
+	switch n := val.(type) {
+	case int:
+		f = float64(n)
+	case int64:
+		f = float64(n)
+	case float64:
+		f = n
+	default:
+		v.errorf(path+".attrs."+name, "must be a number")
+		return 0, false
+	}
+	if f < lo || hi >= 0 && f > hi {
+		if hi < 0 {
+			v.errorf(path+".attrs."+name, "must be at least %v, got %v", lo, f)
+		} else {
+			v.errorf(path+".attrs."+name, "must be between %v and %v, got %v", lo, hi, f)
+		}
+		return 0, false
+	}
+	return f, true
+}
+
+func (v *validator) intAttr(path string, attrs map[string]any, name string, required bool, lo, hi int) {
+	f, ok := v.numberAttr(path, attrs, name, required, float64(lo), float64(hi))
+	if ok && f != float64(int(f)) {
+		v.errorf(path+".attrs."+name, "must be an integer, got %v", f)
+	}
+}
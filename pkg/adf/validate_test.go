@@ -0,0 +1,161 @@
+package adf
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	data, err := os.ReadFile("./testdata/md.json")
+	assert.NoError(t, err)
+
+	var doc ADF
+	assert.NoError(t, json.Unmarshal(data, &doc))
+	assert.NoError(t, doc.Validate())
+
+	md := "# Title\n\nSome **bold** and `code` by [~jane] :smile:\n\n> [!NOTE]\n\n> \n\n" +
+		"- [x]()\n  1. nested\n\n| a | b |\n|---|---|\n| | 2 |\n\n```go\nfmt.Println()\n```\n\n---\n"
+	assert.NoError(t, FromMarkdown(md).Validate())
+
+	built := Doc().
+		Heading(1, "Title").
+		Paragraph(Text("x").Bold().Code().Link("https://example.com"), Text("y").Sub().Color("#ff5630"), Emoji("tada")).
+		BulletList(Item(Text("one")).OrderedList(Item(Text("two")))).
+		Table(Row(HeaderCell(Text("a")).Span(2, 1)), Row(Cell(Text("b")).Background("#e3fcef"), Cell())).
+		Panel(PanelSuccess).
+		Blockquote().
+		Build()
+	assert.NoError(t, built.Validate())
+}
+
+func TestValidateErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		doc      string
+		expected []string
+	}{
+		{
+			name:     "document",
+			doc:      `{"version": 2, "type": "paragraph", "content": []}`,
+			expected: []string{`version: must be 1, got 2`, `type: must be "doc", got "paragraph"`},
+		},
+		{
+			name: "children",
+			doc: `{"version": 1, "type": "doc", "content": [
+				{"type": "text", "text": "loose"},
+				{"type": "unknown"},
+				{"type": "paragraph", "content": [{"type": "paragraph"}]},
+				{"type": "bulletList", "content": []},
+				{"type": "bulletList", "content": [{"type": "listItem", "content": [{"type": "bulletList", "content": [{"type": "listItem", "content": [{"type": "paragraph"}]}]}]}]},
+				{"type": "rule", "content": [{"type": "text", "text": "x"}]},
+				{"type": "panel", "attrs": {"panelType": "info"}, "content": [{"type": "panel", "attrs": {"panelType": "info"}, "content": [{"type": "paragraph"}]}]},
+				{"type": "table", "content": [{"type": "tableRow", "content": [{"type": "tableCell", "content": [{"type": "table", "content": [{"type": "tableRow", "content": [{"type": "tableCell", "content": [{"type": "paragraph"}]}]}]}]}]}]}
+			]}`,
+			expected: []string{
+				`content[0]: "text" is not allowed here`,
+				`content[1]: unknown node type "unknown"`,
+				`content[2].content[0]: "paragraph" is not allowed here`,
+				`content[3]: must have at least 1 child node(s)`,
+				`content[4].content[0].content[0]: "bulletList" is not allowed as the first child`,
+				`content[5]: "rule" can't have child nodes`,
+				`content[6].content[0]: "panel" is not allowed here`,
+				`content[7].content[0].content[0].content[0]: "table" is not allowed here`,
+			},
+		},
+		{
+			name: "attributes",
+			doc: `{"version": 1, "type": "doc", "content": [
+				{"type": "heading", "attrs": {"level": 7}},
+				{"type": "heading", "attrs": {"level": 1.5}},
+				{"type": "heading"},
+				{"type": "panel", "attrs": {"panelType": "danger"}, "content": [{"type": "paragraph"}]},
+				{"type": "paragraph", "content": [
+					{"type": "mention", "attrs": {"id": 1}},
+					{"type": "status", "attrs": {"text": "DONE", "color": "orange"}},
+					{"type": "text", "text": ""}
+				]},
+				{"type": "mediaSingle", "content": [{"type": "media", "attrs": {"type": "file", "id": "abc"}}]},
+				{"type": "paragraph", "attrs": "level"}
+			]}`,
+			expected: []string{
+				`content[0].attrs.level: must be between 1 and 6, got 7`,
+				`content[1].attrs.level: must be an integer, got 1.5`,
+				`content[2].attrs.level: is required`,
+				`content[3].attrs.panelType: must be one of info, note, tip, warning, error, success, custom, got "danger"`,
+				`content[4].content[0].attrs.id: must be a string`,
+				`content[4].content[1].attrs.color: must be one of neutral, purple, blue, red, yellow, green, got "orange"`,
+				`content[4].content[2]: text must not be empty`,
+				`content[5].content[0].attrs.collection: is required`,
+				`content[6].attrs: must be an object`,
+			},
+		},
+		{
+			name: "marks",
+			doc: `{"version": 1, "type": "doc", "content": [
+				{"type": "paragraph", "content": [
+					{"type": "text", "text": "a", "marks": [{"type": "code"}, {"type": "strong"}]},
+					{"type": "text", "text": "b", "marks": [{"type": "link", "attrs": {"href": "https://example.com"}}, {"type": "textColor", "attrs": {"color": "#ff5630"}}]},
+					{"type": "text", "text": "c", "marks": [{"type": "em"}, {"type": "em"}]},
+					{"type": "text", "text": "d", "marks": [{"type": "link"}, {"type": "subsup", "attrs": {"type": "super"}}, {"type": "backgroundColor", "attrs": {"color": "red"}}]},
+					{"type": "text", "text": "e", "marks": [{"type": "alignment", "attrs": {"align": "center"}}]}
+				]},
+				{"type": "codeBlock", "content": [{"type": "text", "text": "x", "marks": [{"type": "strong"}]}]},
+				{"type": "heading", "attrs": {"level": 1}, "marks": [{"type": "alignment", "attrs": {"align": "end"}}, {"type": "indentation", "attrs": {"level": 1}}]}
+			]}`,
+			expected: []string{
+				`content[0].content[0].marks[1]: mark "strong" can't be combined with "code"`,
+				`content[0].content[1].marks[1]: mark "textColor" can't be combined with "link"`,
+				`content[0].content[2].marks[1]: duplicate mark "em"`,
+				`content[0].content[3].marks[0].attrs.href: is required`,
+				`content[0].content[3].marks[1].attrs.type: must be one of sub, sup, got "super"`,
+				`content[0].content[3].marks[2].attrs.color: must be a hex color, eg: #ff5630, got "red"`,
+				`content[0].content[4].marks[0]: mark "alignment" is not allowed here`,
+				`content[1].content[0].marks: marks are not allowed in "codeBlock"`,
+				`content[2].marks[1]: mark "indentation" can't be combined with "alignment"`,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var doc ADF
+			assert.NoError(t, json.Unmarshal([]byte(tc.doc), &doc))
+
+			err := doc.Validate()
+
+			var errs ValidationErrors
+			assert.True(t, errors.As(err, &errs))
+
+			actual := make([]string, 0, len(errs))
+			for _, e := range errs {
+				actual = append(actual, e.Error())
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestValidateNestingLimit(t *testing.T) {
+	nested := func(depth int) *ListItem {
+		item := Item(Text("deep"))
+		for range depth - 1 {
+			item = Item(Text("level")).BulletList(item)
+		}
+		return item
+	}
+
+	assert.NoError(t, Doc().BulletList(nested(maxListDepth)).Build().Validate())
+
+	err := Doc().BulletList(nested(maxListDepth + 1)).Build().Validate()
+	path := "content[0]" + strings.Repeat(".content[0].content[1]", maxListDepth)
+	assert.EqualError(t, err, path+": lists can't be nested more than 6 levels deep")
+
+	var verr *ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.Equal(t, path, verr.Path)
+}
@@ -0,0 +1,351 @@
+// Package config loads named client profiles from a configuration file.
+//
+// Configuration files can be written in YAML, TOML or JSON:
+//
+//	default: work
+//	profiles:
+//	  work:
+//	    server: https://example.atlassian.net
+//	    login: user@example.com
+//	    project: PROJ
+//	    board: 42
+//	    custom_fields:
+//	      story-points:
+//	        id: customfield_10016
+//	        type: number
+//	  onprem:
+//	    server: https://jira.example.com
+//	    auth_type: bearer
+//	    installation: Local
+//
+// Values of the selected profile can be overridden by environment
+// variables, eg: JIRA_SERVER or JIRA_API_TOKEN.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+const (
+	// EnvConfigFile is the environment variable holding the path of the config file.
+	EnvConfigFile = "JIRA_CONFIG_FILE"
+	// EnvProfile is the environment variable selecting the profile.
+	EnvProfile = "JIRA_PROFILE"
+)
+
+var (
+	// ErrConfigNotFound denotes no config file was found.
+	ErrConfigNotFound = fmt.Errorf("config: file not found")
+	// ErrProfileNotFound denotes the profile isn't defined in the config file.
+	ErrProfileNotFound = fmt.Errorf("config: profile not found")
+	// ErrNoProfile denotes no profile was selected and there is no default one.
+	ErrNoProfile = fmt.Errorf("config: no profile selected")
+)
+
+// File is a config file holding named profiles.
+type File struct {
+	// Default is the profile used if none is selected.
+	Default string `mapstructure:"default"`
+	// Profiles indexed by name. Names are case-insensitive.
+	Profiles map[string]*Profile `mapstructure:"profiles"`
+
+	path string
+}
+
+// Profile holds the configuration of a client.
+type Profile struct {
+	Name string `mapstructure:"-"`
+
+	Server       string `mapstructure:"server"`
+	Login        string `mapstructure:"login"`
+	APIToken     string `mapstructure:"api_token"`
+	AuthType     string `mapstructure:"auth_type"`
+	Installation string `mapstructure:"installation"`
+	CloudID      string `mapstructure:"cloud_id"`
+	Insecure     bool   `mapstructure:"insecure"`
+
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	MTLS *MTLS `mapstructure:"mtls"`
+
+	// Project is the default project key.
+	Project string `mapstructure:"project"`
+	// Board is the default board id.
+	Board int `mapstructure:"board"`
+
+	// CustomFields are custom fields indexed by alias, eg: story-points.
+	CustomFields map[string]CustomField `mapstructure:"custom_fields"`
+}
+
+// MTLS holds the paths of the mTLS certificates.
+type MTLS struct {
+	CaCert     string `mapstructure:"ca_cert"`
+	ClientCert string `mapstructure:"client_cert"`
+	ClientKey  string `mapstructure:"client_key"`
+}
+
+// CustomField describes a custom field.
+type CustomField struct {
+	// ID of the field, eg: customfield_10016.
+	ID string `mapstructure:"id"`
+	// Type of the field, eg: string, number, option or array.
+	Type string `mapstructure:"type"`
+	// Items is the type of the items of array fields.
+	Items string `mapstructure:"items"`
+}
+
+// DefaultPaths returns the paths searched for a config file: $JIRA_CONFIG_FILE
+// if set, otherwise config.yml, config.yaml, config.toml or config.json in
+// the jira directory of the user config dir, eg: ~/.config/jira.
+func DefaultPaths() ([]string, error) {
+	if env := os.Getenv(EnvConfigFile); env != "" {
+		return []string{env}, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, ext := range []string{"yml", "yaml", "toml", "json"} {
+		paths = append(paths, filepath.Join(dir, "jira", "config."+ext))
+	}
+	return paths, nil
+}
+
+// Load reads the config file at path. The format is inferred from the extension.
+// If path is empty, the first existing file among DefaultPaths is read.
+func Load(path string) (*File, error) {
+	if path == "" {
+		paths, err := DefaultPaths()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range paths {
+			if _, err := os.Stat(p); err == nil {
+				path = p
+				break
+			}
+		}
+		if path == "" {
+			return nil, ErrConfigNotFound
+		}
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrConfigNotFound, path)
+		}
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	f := File{path: path}
+	if err := v.Unmarshal(&f); err != nil {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	for name, p := range f.Profiles {
+		if p == nil {
+			p = &Profile{}
+			f.Profiles[name] = p
+		}
+		p.Name = name
+	}
+
+	return &f, nil
+}
+
+// LoadProfile reads the profile from the default config file, see File.Profile.
+func LoadProfile(name string) (*Profile, error) {
+	f, err := Load("")
+	if err != nil {
+		return nil, err
+	}
+	return f.Profile(name)
+}
+
+// Path returns the path the file was loaded from.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Names returns the sorted profile names.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns a copy of the profile with environment overrides applied.
+//
+// If name is empty, the profile is selected by $JIRA_PROFILE, the default
+// profile of the file or the only profile defined, in that order.
+// The profile is validated before it's returned.
+func (f *File) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvProfile)
+	}
+	if name == "" {
+		name = f.Default
+	}
+	if name == "" && len(f.Profiles) == 1 {
+		name = f.Names()[0]
+	}
+	if name == "" {
+		return nil, ErrNoProfile
+	}
+
+	p, ok := f.Profiles[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	out := p.clone()
+	if err := out.applyEnv(); err != nil {
+		return nil, err
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Validate checks the profile and normalizes the auth and installation types.
+func (p *Profile) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("config: profile %q: %s", p.Name, fmt.Sprintf(format, args...)))
+	}
+
+	if p.Server == "" && p.CloudID == "" {
+		invalid("server is required")
+	}
+
+	p.AuthType = strings.ToLower(p.AuthType)
+	switch jira.AuthType(p.AuthType) {
+	case "", jira.AuthTypeBasic, jira.AuthTypeBearer, jira.AuthTypeOAuth2:
+	case jira.AuthTypeMTLS:
+		if p.MTLS == nil {
+			invalid("mtls config is required for mtls auth")
+		}
+	default:
+		invalid("unknown auth type %q", p.AuthType)
+	}
+
+	switch strings.ToLower(p.Installation) {
+	case "":
+	case strings.ToLower(jira.InstallationTypeCloud):
+		p.Installation = jira.InstallationTypeCloud
+	case strings.ToLower(jira.InstallationTypeLocal):
+		p.Installation = jira.InstallationTypeLocal
+	default:
+		invalid("unknown installation type %q", p.Installation)
+	}
+
+	if p.MTLS != nil && (p.MTLS.ClientCert == "") != (p.MTLS.ClientKey == "") {
+		invalid("mtls client cert and key must be set together")
+	}
+	if p.Timeout < 0 {
+		invalid("timeout must not be negative")
+	}
+	if p.Board < 0 {
+		invalid("board must be a valid board id")
+	}
+	for alias, cf := range p.CustomFields {
+		if cf.ID == "" {
+			invalid("custom field %q has no id", alias)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// IssueTypeFields returns the custom fields in the form expected by
+// jira.CreateRequest.WithCustomFields and jira.EditRequest.WithCustomFields.
+func (p *Profile) IssueTypeFields() []jira.IssueTypeField {
+	aliases := make([]string, 0, len(p.CustomFields))
+	for alias := range p.CustomFields {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	fields := make([]jira.IssueTypeField, 0, len(aliases))
+	for _, alias := range aliases {
+		cf := p.CustomFields[alias]
+
+		field := jira.IssueTypeField{Name: alias, Key: cf.ID, FieldID: cf.ID}
+		field.Schema.DataType = cf.Type
+		field.Schema.Items = cf.Items
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// applyEnv overrides the profile with the values set in the environment.
+func (p *Profile) applyEnv() error {
+	strs := map[string]*string{
+		"JIRA_SERVER":       &p.Server,
+		"JIRA_LOGIN":        &p.Login,
+		"JIRA_API_TOKEN":    &p.APIToken,
+		"JIRA_AUTH_TYPE":    &p.AuthType,
+		"JIRA_INSTALLATION": &p.Installation,
+		"JIRA_CLOUD_ID":     &p.CloudID,
+		"JIRA_PROJECT":      &p.Project,
+	}
+	for key, val := range strs {
+		if env := os.Getenv(key); env != "" {
+			*val = env
+		}
+	}
+
+	if env := os.Getenv("JIRA_INSECURE"); env != "" {
+		v, err := strconv.ParseBool(env)
+		if err != nil {
+			return fmt.Errorf("config: JIRA_INSECURE: %w", err)
+		}
+		p.Insecure = v
+	}
+	if env := os.Getenv("JIRA_TIMEOUT"); env != "" {
+		v, err := time.ParseDuration(env)
+		if err != nil {
+			return fmt.Errorf("config: JIRA_TIMEOUT: %w", err)
+		}
+		p.Timeout = v
+	}
+	if env := os.Getenv("JIRA_BOARD"); env != "" {
+		v, err := strconv.Atoi(env)
+		if err != nil {
+			return fmt.Errorf("config: JIRA_BOARD: %w", err)
+		}
+		p.Board = v
+	}
+
+	return nil
+}
+
+func (p *Profile) clone() *Profile {
+	out := *p
+	if p.MTLS != nil {
+		m := *p.MTLS
+		out.MTLS = &m
+	}
+	out.CustomFields = maps.Clone(p.CustomFields)
+	return &out
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+const yamlConfig = `default: work
+profiles:
+  work:
+    server: https://test.atlassian.net
+    login: user@example.com
+    timeout: 30s
+    project: TEST
+    board: 42
+    custom_fields:
+      story-points:
+        id: customfield_10016
+        type: number
+  OnPrem:
+    server: https://jira.example.com
+    auth_type: Bearer
+    installation: local
+    mtls:
+      ca_cert: /etc/jira/ca.pem
+`
+
+func writeConfig(t *testing.T, name, data string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+
+	f, err := Load(writeConfig(t, "config.yml", yamlConfig))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"onprem", "work"}, f.Names())
+
+	p, err := f.Profile("")
+	assert.NoError(t, err)
+	assert.Equal(t, &Profile{
+		Name:    "work",
+		Server:  "https://test.atlassian.net",
+		Login:   "user@example.com",
+		Timeout: 30 * time.Second,
+		Project: "TEST",
+		Board:   42,
+		CustomFields: map[string]CustomField{
+			"story-points": {ID: "customfield_10016", Type: "number"},
+		},
+	}, p)
+
+	p, err = f.Profile("OnPrem")
+	assert.NoError(t, err)
+	assert.Equal(t, "bearer", p.AuthType)
+	assert.Equal(t, jira.InstallationTypeLocal, p.Installation)
+	assert.Equal(t, &MTLS{CaCert: "/etc/jira/ca.pem"}, p.MTLS)
+
+	_, err = f.Profile("missing")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+
+	_, err = Load(filepath.Join(t.TempDir(), "config.yml"))
+	assert.ErrorIs(t, err, ErrConfigNotFound)
+}
+
+func TestLoadFormats(t *testing.T) {
+	cases := map[string]string{
+		"config.toml": "[profiles.work]\nserver = \"https://test.atlassian.net\"\nlogin = \"user@example.com\"\n",
+		"config.json": `{"profiles": {"work": {"server": "https://test.atlassian.net", "login": "user@example.com"}}}`,
+	}
+
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(EnvProfile, "")
+
+			f, err := Load(writeConfig(t, name, data))
+			assert.NoError(t, err)
+
+			// The only profile is used if there is no default one.
+			p, err := f.Profile("")
+			assert.NoError(t, err)
+			assert.Equal(t, "https://test.atlassian.net", p.Server)
+			assert.Equal(t, "user@example.com", p.Login)
+		})
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	t.Setenv(EnvConfigFile, writeConfig(t, "config.yml", yamlConfig))
+	t.Setenv(EnvProfile, "onprem")
+	t.Setenv("JIRA_SERVER", "https://override.example.com")
+	t.Setenv("JIRA_API_TOKEN", "env-token")
+	t.Setenv("JIRA_BOARD", "7")
+
+	p, err := LoadProfile("")
+	assert.NoError(t, err)
+	assert.Equal(t, "onprem", p.Name)
+	assert.Equal(t, "https://override.example.com", p.Server)
+	assert.Equal(t, "env-token", p.APIToken)
+	assert.Equal(t, 7, p.Board)
+
+	t.Setenv("JIRA_BOARD", "first")
+	_, err = LoadProfile("")
+	assert.EqualError(t, err, `config: JIRA_BOARD: strconv.Atoi: parsing "first": invalid syntax`)
+}
+
+func TestProfileValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		profile Profile
+		err     string
+	}{
+		{
+			name:    "valid",
+			profile: Profile{Name: "work", Server: "https://test.atlassian.net"},
+		},
+		{
+			name:    "cloud id instead of server",
+			profile: Profile{Name: "work", CloudID: "cloud-id", AuthType: "oauth2"},
+		},
+		{
+			name:    "missing server",
+			profile: Profile{Name: "work"},
+			err:     `config: profile "work": server is required`,
+		},
+		{
+			name:    "unknown auth type",
+			profile: Profile{Name: "work", Server: "https://test.atlassian.net", AuthType: "digest"},
+			err:     `config: profile "work": unknown auth type "digest"`,
+		},
+		{
+			name:    "mtls without certificates",
+			profile: Profile{Name: "work", Server: "https://test.atlassian.net", AuthType: "mtls"},
+			err:     `config: profile "work": mtls config is required for mtls auth`,
+		},
+		{
+			name: "multiple errors",
+			profile: Profile{
+				Name:         "work",
+				Server:       "https://test.atlassian.net",
+				Installation: "server",
+				MTLS:         &MTLS{ClientCert: "/etc/jira/client.pem"},
+				CustomFields: map[string]CustomField{"story-points": {}},
+			},
+			err: `config: profile "work": unknown installation type "server"` + "\n" +
+				`config: profile "work": mtls client cert and key must be set together` + "\n" +
+				`config: profile "work": custom field "story-points" has no id`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.profile.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestProfileIssueTypeFields(t *testing.T) {
+	p := Profile{CustomFields: map[string]CustomField{
+		"story-points": {ID: "customfield_10016", Type: "number"},
+		"labels":       {ID: "customfield_10020", Type: "array", Items: "option"},
+	}}
+
+	var labels, points jira.IssueTypeField
+	labels.Name, labels.Key, labels.FieldID = "labels", "customfield_10020", "customfield_10020"
+	labels.Schema.DataType, labels.Schema.Items = "array", "option"
+	points.Name, points.Key, points.FieldID = "story-points", "customfield_10016", "customfield_10016"
+	points.Schema.DataType = "number"
+
+	assert.Equal(t, []jira.IssueTypeField{labels, points}, p.IssueTypeFields())
+}
@@ -0,0 +1,84 @@
+// Package credentials resolves the login and API token used to authenticate
+// with a Jira server from various sources that can be chained together.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound denotes the provider has no credentials for the server.
+var ErrNotFound = fmt.Errorf("credentials: not found")
+
+// Credentials hold the login and secret used to authenticate with a server.
+type Credentials struct {
+	Login string
+	Token string
+}
+
+// Provider resolves credentials for the given server and login.
+//
+// The login might be empty if it isn't known upfront, in which case providers
+// that store it return it alongside the token. Providers must return ErrNotFound
+// when they have no credentials for the server.
+type Provider interface {
+	Credentials(ctx context.Context, server, login string) (*Credentials, error)
+}
+
+// ProviderFunc is an adapter to allow the use of ordinary functions as providers.
+type ProviderFunc func(ctx context.Context, server, login string) (*Credentials, error)
+
+// Credentials calls f(ctx, server, login).
+func (f ProviderFunc) Credentials(ctx context.Context, server, login string) (*Credentials, error) {
+	return f(ctx, server, login)
+}
+
+// Static provides fixed credentials.
+type Static Credentials
+
+// Credentials implements Provider interface.
+func (s Static) Credentials(context.Context, string, string) (*Credentials, error) {
+	if s.Token == "" {
+		return nil, ErrNotFound
+	}
+	return &Credentials{Login: s.Login, Token: s.Token}, nil
+}
+
+type chain []Provider
+
+// Chain returns a provider that queries the providers in order and returns the
+// first credentials found. Failing providers are skipped, their errors are only
+// reported if none of the providers has credentials for the server.
+func Chain(providers ...Provider) Provider {
+	return chain(providers)
+}
+
+func (c chain) Credentials(ctx context.Context, server, login string) (*Credentials, error) {
+	errs := []error{ErrNotFound}
+
+	for _, p := range c {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		creds, err := p.Credentials(ctx, server, login)
+		if err == nil && creds != nil && creds.Token != "" {
+			if creds.Login == "" {
+				creds.Login = login
+			}
+			return creds, nil
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			errs = append(errs, err)
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// Default returns the chain of providers used when no provider is configured:
+// environment variables, the netrc file and the system keyring.
+func Default() Provider {
+	return Chain(Env{}, Netrc{}, Keyring{})
+}
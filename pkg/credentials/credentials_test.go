@@ -0,0 +1,106 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	failing := ProviderFunc(func(context.Context, string, string) (*Credentials, error) {
+		return nil, errors.New("keyring unavailable")
+	})
+
+	var calls []string
+	record := func(name string, creds *Credentials) Provider {
+		return ProviderFunc(func(_ context.Context, server, login string) (*Credentials, error) {
+			assert.Equal(t, "https://test.atlassian.net", server)
+			assert.Equal(t, "user", login)
+
+			calls = append(calls, name)
+			if creds == nil {
+				return nil, ErrNotFound
+			}
+			return creds, nil
+		})
+	}
+
+	p := Chain(record("first", nil), failing, record("second", &Credentials{Token: "secret"}), record("third", &Credentials{Token: "other"}))
+
+	creds, err := p.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "user", Token: "secret"}, creds)
+	assert.Equal(t, []string{"first", "second"}, calls)
+
+	// Errors are only reported when no credentials are found.
+	_, err = Chain(record("first", nil), failing).Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "keyring unavailable")
+
+	_, err = Chain().Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TEST_JIRA_LOGIN", "env-user")
+	t.Setenv("TEST_JIRA_TOKEN", "env-token")
+
+	p := Env{LoginVar: "TEST_JIRA_LOGIN", TokenVar: "TEST_JIRA_TOKEN"}
+
+	creds, err := p.Credentials(context.Background(), "https://test.atlassian.net", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "env-user", Token: "env-token"}, creds)
+
+	// The login given by the caller takes precedence.
+	creds, err = p.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "user", Token: "env-token"}, creds)
+
+	_, err = Env{TokenVar: "TEST_JIRA_MISSING"}.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestFileAndNetrc(t *testing.T) {
+	dir := t.TempDir()
+
+	token := filepath.Join(dir, "token")
+	assert.NoError(t, os.WriteFile(token, []byte("file-token\n"), 0o600))
+
+	creds, err := File{Path: token}.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "user", Token: "file-token"}, creds)
+
+	_, err = File{Path: filepath.Join(dir, "missing")}.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	rc := filepath.Join(dir, "netrc")
+	assert.NoError(t, os.WriteFile(rc, []byte("machine test.atlassian.net login user password netrc-token\n"), 0o600))
+
+	creds, err = Netrc{Path: rc}.Credentials(context.Background(), "https://test.atlassian.net", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "user", Token: "netrc-token"}, creds)
+
+	_, err = Netrc{Path: rc}.Credentials(context.Background(), "https://other.atlassian.net", "user")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	p := Command{Name: "sh", Args: []string{"-c", `echo "token-for-$JIRA_LOGIN@$JIRA_SERVER"`}}
+
+	creds, err := p.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, &Credentials{Login: "user", Token: "token-for-user@https://test.atlassian.net"}, creds)
+
+	_, err = Command{Name: "sh", Args: []string{"-c", "echo locked >&2; exit 1"}}.Credentials(context.Background(), "https://test.atlassian.net", "user")
+	assert.ErrorContains(t, err, "locked")
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
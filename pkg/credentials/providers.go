@@ -0,0 +1,151 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+
+	"github.com/eliziario/jira-lib/pkg/netrc"
+)
+
+const (
+	// DefaultLoginVar is the environment variable read by Env for the login.
+	DefaultLoginVar = "JIRA_LOGIN"
+	// DefaultTokenVar is the environment variable read by Env for the token.
+	DefaultTokenVar = "JIRA_API_TOKEN"
+	// DefaultKeyringService is the keyring service used by jira-cli.
+	DefaultKeyringService = "jira-cli"
+)
+
+// Env provides credentials from environment variables.
+type Env struct {
+	// LoginVar defaults to DefaultLoginVar.
+	LoginVar string
+	// TokenVar defaults to DefaultTokenVar.
+	TokenVar string
+}
+
+// Credentials implements Provider interface.
+func (e Env) Credentials(_ context.Context, _, login string) (*Credentials, error) {
+	loginVar, tokenVar := e.LoginVar, e.TokenVar
+	if loginVar == "" {
+		loginVar = DefaultLoginVar
+	}
+	if tokenVar == "" {
+		tokenVar = DefaultTokenVar
+	}
+
+	token := os.Getenv(tokenVar)
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	if v := os.Getenv(loginVar); v != "" && login == "" {
+		login = v
+	}
+	return &Credentials{Login: login, Token: token}, nil
+}
+
+// Netrc provides credentials from a netrc file.
+type Netrc struct {
+	// Path of the netrc file, defaults to $NETRC or ~/.netrc.
+	Path string
+}
+
+// Credentials implements Provider interface.
+func (n Netrc) Credentials(_ context.Context, server, login string) (*Credentials, error) {
+	entry, err := netrc.ReadFile(n.Path, server, login)
+	if errors.Is(err, netrc.ErrNetrcEntryNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Credentials{Login: entry.Login, Token: entry.Password}, nil
+}
+
+// Keyring provides credentials from the system keyring.
+type Keyring struct {
+	// Service defaults to DefaultKeyringService.
+	Service string
+}
+
+// Credentials implements Provider interface.
+func (k Keyring) Credentials(_ context.Context, _, login string) (*Credentials, error) {
+	if login == "" {
+		return nil, ErrNotFound
+	}
+
+	service := k.Service
+	if service == "" {
+		service = DefaultKeyringService
+	}
+
+	secret, err := keyring.Get(service, login)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Credentials{Login: login, Token: secret}, nil
+}
+
+// File provides the token stored in a file, eg: a mounted secret.
+// Surrounding whitespace is ignored.
+type File struct {
+	Path string
+}
+
+// Credentials implements Provider interface.
+func (f File) Credentials(_ context.Context, _, login string) (*Credentials, error) {
+	data, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	return &Credentials{Login: login, Token: token}, nil
+}
+
+// Command provides the token printed by an external command, eg: a password
+// manager CLI. The server and login are passed to the command through the
+// JIRA_SERVER and JIRA_LOGIN environment variables.
+type Command struct {
+	Name string
+	Args []string
+}
+
+// Credentials implements Provider interface.
+func (c Command) Credentials(ctx context.Context, server, login string) (*Credentials, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, c.Name, c.Args...)
+	cmd.Env = append(os.Environ(), "JIRA_SERVER="+server, "JIRA_LOGIN="+login)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("credentials: %s: %w: %s", c.Name, err, msg)
+		}
+		return nil, fmt.Errorf("credentials: %s: %w", c.Name, err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return nil, ErrNotFound
+	}
+	return &Credentials{Login: login, Token: token}, nil
+}
@@ -23,23 +23,33 @@ type BoardResult struct {
 
 // Boards gets all boards of a given type in a project.
 func (c *Client) Boards(project, boardType string) (*BoardResult, error) {
+	return c.BoardsContext(context.Background(), project, boardType)
+}
+
+// BoardsContext is the same as Boards but accepts a context.
+func (c *Client) BoardsContext(ctx context.Context, project, boardType string) (*BoardResult, error) {
 	path := fmt.Sprintf("/board?projectKeyOrId=%s", project)
 	if boardType != "" {
 		path += fmt.Sprintf("&type=%s", boardType)
 	}
 
-	return c.board(path)
+	return c.board(ctx, path)
 }
 
 // BoardSearch fetches boards with the given name in a project.
 func (c *Client) BoardSearch(project, name string) (*BoardResult, error) {
+	return c.BoardSearchContext(context.Background(), project, name)
+}
+
+// BoardSearchContext is the same as BoardSearch but accepts a context.
+func (c *Client) BoardSearchContext(ctx context.Context, project, name string) (*BoardResult, error) {
 	path := fmt.Sprintf("/board?projectKeyOrId=%s&name=%s", project, name)
 
-	return c.board(path)
+	return c.board(ctx, path)
 }
 
-func (c *Client) board(path string) (*BoardResult, error) {
-	res, err := c.GetV1(context.Background(), path, nil)
+func (c *Client) board(ctx context.Context, path string) (*BoardResult, error) {
+	res, err := c.GetV1(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,84 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eliziario/jira-lib/pkg/jql"
+)
+
+const (
+	defaultBoundingWindow = 90 * 24 * time.Hour
+	defaultBoundingField  = "created"
+)
+
+// ErrUnboundedJQL denotes a query without any search restriction.
+var ErrUnboundedJQL = fmt.Errorf("jira: unbounded JQL queries are not allowed")
+
+// JQLBoundingMode decides how unbounded queries are handled by the cloud search endpoint.
+type JQLBoundingMode int
+
+// JQL bounding modes.
+const (
+	// JQLBoundingPassThrough sends queries as is and lets Jira decide. This is the default.
+	JQLBoundingPassThrough JQLBoundingMode = iota
+	// JQLBoundingError rejects unbounded queries with ErrUnboundedJQL before sending them.
+	JQLBoundingError
+	// JQLBoundingAuto restricts unbounded queries to the issues within the configured window.
+	JQLBoundingAuto
+)
+
+// JQLBoundingPolicy configures how unbounded queries are handled by the cloud
+// GET /search/jql endpoint, which rejects queries without a search restriction
+// like `ORDER BY created DESC`.
+type JQLBoundingPolicy struct {
+	Mode JQLBoundingMode
+	// Window is how far back auto bounded queries look. Defaults to 90 days.
+	Window time.Duration
+	// Field is the date field used to bound queries. Defaults to created.
+	Field string
+}
+
+// WithJQLBoundingPolicy is a functional opt to set how unbounded search queries are handled.
+func WithJQLBoundingPolicy(p JQLBoundingPolicy) ClientFunc {
+	return func(c *Client) {
+		c.bounding = p
+	}
+}
+
+// apply returns the query to send to the server.
+func (p JQLBoundingPolicy) apply(q string) (string, error) {
+	if p.Mode == JQLBoundingPassThrough || jql.IsBounded(q) {
+		return q, nil
+	}
+	if p.Mode == JQLBoundingError {
+		return "", ErrUnboundedJQL
+	}
+
+	window, field := p.Window, p.Field
+	if window <= 0 {
+		window = defaultBoundingWindow
+	}
+	if field == "" {
+		field = defaultBoundingField
+	}
+
+	// The query is unbounded so there is nothing but an optional ORDER BY clause.
+	_, orderBy := jql.SplitOrderBy(q)
+
+	bound := fmt.Sprintf("%s >= %s", field, relativeDate(window))
+	return strings.TrimSpace(bound + " " + orderBy), nil
+}
+
+// relativeDate formats the duration as a JQL relative date in the past, eg: -90d.
+func relativeDate(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("-%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("-%dh", d/time.Hour)
+	default:
+		return fmt.Sprintf("-%dm", (d+time.Minute-1)/time.Minute)
+	}
+}
@@ -0,0 +1,228 @@
+package jira
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CachePolicy configures the response cache.
+type CachePolicy struct {
+	// Store holds the cached responses. Defaults to an in-memory store.
+	Store CacheStore
+	// TTLs is how long responses are fresh, indexed by endpoint relative to the
+	// API root, eg: /field. An endpoint also matches its sub-paths, eg: /project
+	// matches /project/TEST. Only GET requests to these endpoints are cached.
+	// A zero TTL revalidates the response on every use. Defaults to DefaultCacheTTLs.
+	TTLs map[string]time.Duration
+}
+
+// DefaultCacheTTLs returns the TTLs of endpoints that rarely change.
+func DefaultCacheTTLs() map[string]time.Duration {
+	return map[string]time.Duration{
+		"/field":            time.Hour,
+		"/project":          time.Hour,
+		"/board":            15 * time.Minute,
+		"/issueLinkType":    time.Hour,
+		"/issue/createmeta": time.Hour,
+	}
+}
+
+// listedBy maps resources to the other resources listing them, eg: the sprints
+// of a board are listed by /board/{id}/sprint and the versions of a project by
+// /project/{key}/versions.
+var listedBy = map[string][]string{
+	"/sprint":    {"/board"},
+	"/epic":      {"/board"},
+	"/version":   {"/project"},
+	"/component": {"/project"},
+	"/field":     {"/issue/createmeta"},
+}
+
+// WithCache is a functional opt to cache responses of endpoints that rarely change.
+//
+// Cached responses are revalidated with If-None-Match and If-Modified-Since once
+// they expire if the server sent an ETag or Last-Modified header. Successful
+// POST, PUT and DELETE requests invalidate the cached responses of the resource
+// they modify, eg: creating a project invalidates /project, and of the resources
+// listing it, eg: ending a sprint invalidates /board.
+func WithCache(p CachePolicy) ClientFunc {
+	return func(c *Client) {
+		if p.Store == nil {
+			p.Store = NewMemoryCache()
+		}
+		if p.TTLs == nil {
+			p.TTLs = DefaultCacheTTLs()
+		}
+		c.cache = &p
+	}
+}
+
+// InvalidateCache removes the cached responses of the given endpoints and their
+// sub-paths, eg: /project. All cached responses are removed if none is given.
+func (c *Client) InvalidateCache(endpoints ...string) {
+	if c.cache == nil {
+		return
+	}
+	c.cache.Store.DeleteFunc(func(key string) bool {
+		if len(endpoints) == 0 {
+			return true
+		}
+		u, err := url.Parse(key)
+		if err != nil {
+			return false
+		}
+		ep := apiEndpoint(u)
+		for _, e := range endpoints {
+			if matchEndpoint(ep, e) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// cacheMiddleware serves fresh responses from the cache and revalidates stale ones.
+func (c *Client) cacheMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			ep := apiEndpoint(req.URL)
+
+			if req.Method != http.MethodGet {
+				res, err := next.RoundTrip(req)
+				if err == nil && req.Method != http.MethodHead && res.StatusCode < http.StatusBadRequest {
+					resource := resourceOf(ep)
+					c.InvalidateCache(append([]string{resource}, listedBy[resource]...)...)
+				}
+				return res, err
+			}
+
+			ttl, ok := c.cache.ttl(ep)
+			if !ok {
+				return next.RoundTrip(req)
+			}
+
+			key := req.URL.String()
+			cached, found := c.cache.Store.Get(key)
+			if found && time.Now().Before(cached.Expires) {
+				return cached.response(req), nil
+			}
+
+			if found && (cached.ETag != "" || cached.LastModified != "") {
+				req = req.Clone(req.Context())
+				if cached.ETag != "" {
+					req.Header.Set("If-None-Match", cached.ETag)
+				}
+				if cached.LastModified != "" {
+					req.Header.Set("If-Modified-Since", cached.LastModified)
+				}
+			}
+
+			res, err := next.RoundTrip(req)
+			if err != nil {
+				return nil, err
+			}
+
+			switch {
+			case res.StatusCode == http.StatusNotModified && found:
+				discard(res)
+
+				cached.Expires = time.Now().Add(ttl)
+				c.cache.Store.Set(key, cached)
+
+				return cached.response(req), nil
+			case res.StatusCode == http.StatusOK && !strings.Contains(res.Header.Get("Cache-Control"), "no-store"):
+				body, err := io.ReadAll(res.Body)
+				_ = res.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				res.Body = io.NopCloser(bytes.NewReader(body))
+
+				c.cache.Store.Set(key, &CachedResponse{
+					StatusCode:   res.StatusCode,
+					Header:       res.Header.Clone(),
+					Body:         body,
+					ETag:         res.Header.Get("ETag"),
+					LastModified: res.Header.Get("Last-Modified"),
+					Expires:      time.Now().Add(ttl),
+				})
+			}
+
+			return res, nil
+		})
+	}
+}
+
+func (p *CachePolicy) ttl(endpoint string) (time.Duration, bool) {
+	var (
+		match string
+		ttl   time.Duration
+	)
+	for e, t := range p.TTLs {
+		if matchEndpoint(endpoint, e) && len(e) > len(match) {
+			match, ttl = e, t
+		}
+	}
+	return ttl, match != ""
+}
+
+// apiEndpoint returns the path relative to the API root,
+// eg: /field for /rest/api/3/field or /board for /rest/agile/1.0/board.
+func apiEndpoint(u *url.URL) string {
+	p := u.Path
+	i := strings.Index(p, "/rest/")
+	if i < 0 {
+		return p
+	}
+
+	parts := strings.SplitN(p[i+len("/rest/"):], "/", 3)
+	if len(parts) < 3 {
+		return "/"
+	}
+	return "/" + parts[2]
+}
+
+func matchEndpoint(endpoint, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return endpoint == prefix || strings.HasPrefix(endpoint, prefix+"/")
+}
+
+// resourceOf returns the top level resource of the endpoint, eg: /issue for /issue/TEST-1.
+func resourceOf(endpoint string) string {
+	if endpoint == "" {
+		return "/"
+	}
+	if i := strings.IndexByte(endpoint[1:], '/'); i >= 0 {
+		return endpoint[:i+1]
+	}
+	return endpoint
+}
+
+// CachedResponse is a response stored in the cache.
+type CachedResponse struct {
+	StatusCode   int         `json:"status"`
+	Header       http.Header `json:"header"`
+	Body         []byte      `json:"body"`
+	ETag         string      `json:"etag,omitempty"`
+	LastModified string      `json:"lastModified,omitempty"`
+	Expires      time.Time   `json:"expires"`
+}
+
+func (r *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
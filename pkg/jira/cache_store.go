@@ -0,0 +1,165 @@
+package jira
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CacheStore stores cached responses. Stores are best-effort: failing to read
+// or write an entry only results in a cache miss.
+//
+// Implementations must be safe for concurrent use. Responses are stored per
+// URL, so a store must not be shared by clients using different credentials.
+type CacheStore interface {
+	// Get returns the response stored for the key.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores the response for the key.
+	Set(key string, res *CachedResponse)
+	// DeleteFunc removes the responses whose key matches.
+	DeleteFunc(match func(key string) bool)
+}
+
+// MemoryCache is an in-memory cache store.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]*CachedResponse
+}
+
+// NewMemoryCache creates an empty in-memory cache store.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]*CachedResponse)}
+}
+
+// Get implements CacheStore interface.
+func (m *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	cp := *res
+	return &cp, true
+}
+
+// Set implements CacheStore interface.
+func (m *MemoryCache) Set(key string, res *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cp := *res
+	m.entries[key] = &cp
+}
+
+// DeleteFunc implements CacheStore interface.
+func (m *MemoryCache) DeleteFunc(match func(key string) bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key := range m.entries {
+		if match(key) {
+			delete(m.entries, key)
+		}
+	}
+}
+
+// DiskCache is a cache store keeping responses in files, so that they
+// survive restarts. Responses may contain sensitive data, so files are
+// only readable by the user.
+type DiskCache struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// diskEntry is the content of a cache file.
+type diskEntry struct {
+	Key      string          `json:"key"`
+	Response *CachedResponse `json:"response"`
+}
+
+// NewDiskCache creates a cache store in the directory, which is created if needed.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get implements CacheStore interface.
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	e, err := d.read(d.path(key))
+	if err != nil || e.Key != key || e.Response == nil {
+		return nil, false
+	}
+	return e.Response, true
+}
+
+// Set implements CacheStore interface.
+func (d *DiskCache) Set(key string, res *CachedResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	data, err := json.Marshal(diskEntry{Key: key, Response: res})
+	if err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err != nil || cerr != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), d.path(key))
+}
+
+// DeleteFunc implements CacheStore interface.
+func (d *DiskCache) DeleteFunc(match func(key string) bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(d.dir, "*.json"))
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		e, err := d.read(f)
+		if err != nil || match(e.Key) {
+			_ = os.Remove(f)
+		}
+	}
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (d *DiskCache) read(path string) (*diskEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var e diskEntry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(e.Key) == "" {
+		return nil, os.ErrNotExist
+	}
+	return &e, nil
+}
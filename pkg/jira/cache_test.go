@@ -0,0 +1,234 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheServesFreshResponses(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/field":
+			_, _ = w.Write([]byte(`[{"id": "summary", "name": "Summary"}]`))
+		case "/rest/api/2/serverInfo":
+			_, _ = w.Write([]byte(`{"version": "9.4.0"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCache(CachePolicy{}))
+
+	for range 3 {
+		fields, err := client.GetField()
+		assert.NoError(t, err)
+		assert.Len(t, fields, 1)
+		assert.Equal(t, "summary", fields[0].ID)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Endpoints without TTL are not cached.
+	for range 2 {
+		_, err := client.ServerInfo()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, requests)
+
+	client.InvalidateCache("/field")
+
+	_, err := client.GetField()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, requests)
+}
+
+func TestCacheRevalidatesStaleResponses(t *testing.T) {
+	var requests, notModified int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/project", r.URL.Path)
+		requests++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`[{"key": "TEST", "name": "Test"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCache(CachePolicy{
+		TTLs: map[string]time.Duration{"/project": 0},
+	}))
+
+	for range 3 {
+		projects, err := client.Project()
+		assert.NoError(t, err)
+		assert.Len(t, projects, 1)
+		assert.Equal(t, "TEST", projects[0].Key)
+	}
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 2, notModified)
+}
+
+func TestCacheInvalidatesAfterMutations(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/2/issue/createmeta":
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"projects": []}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/issue/TEST-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/2/user/properties/TEST":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCache(CachePolicy{}))
+	meta := CreateMetaRequest{Projects: "TEST"}
+
+	_, err := client.GetCreateMeta(&meta)
+	assert.NoError(t, err)
+
+	// Mutating other resources keeps the cached response.
+	res, err := client.PutV2(context.Background(), "/user/properties/TEST", []byte(`{}`), nil)
+	assert.NoError(t, err)
+	discard(res)
+
+	_, err = client.GetCreateMeta(&meta)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+
+	res, err = client.PutV2(context.Background(), "/issue/TEST-1", []byte(`{}`), nil)
+	assert.NoError(t, err)
+	discard(res)
+
+	_, err = client.GetCreateMeta(&meta)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCacheInvalidatesListingResources(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/agile/1.0/board/1/sprint":
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"values": [{"id": 2, "state": "active"}], "isLast": true}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/agile/1.0/sprint/2":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 2, "state": "active"}`))
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/agile/1.0/sprint/2":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCache(CachePolicy{}))
+
+	for range 2 {
+		_, err := client.Sprints(1, "state=active", 0, 50)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Ending a sprint invalidates the sprints of the boards.
+	assert.NoError(t, client.EndSprint(2))
+
+	_, err := client.Sprints(1, "state=active", 0, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCacheInvalidatesProjectVersions(t *testing.T) {
+	versions := []string{`{"id": "1", "name": "v1.0"}`}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/3/project/TEST/versions":
+			_, _ = w.Write([]byte("[" + strings.Join(versions, ",") + "]"))
+		case r.Method == http.MethodPost && r.URL.Path == "/rest/api/3/version":
+			versions = append(versions, `{"id": "2", "name": "v2.0"}`)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(versions[1]))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCache(CachePolicy{}))
+
+	releases, err := client.Release("TEST")
+	assert.NoError(t, err)
+	assert.Len(t, releases, 1)
+
+	res, err := client.Post(context.Background(), "/version", []byte(`{"name": "v2.0", "project": "TEST"}`), nil)
+	assert.NoError(t, err)
+	discard(res)
+
+	releases, err = client.Release("TEST")
+	assert.NoError(t, err)
+	assert.Len(t, releases, 2)
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://test.atlassian.net/rest/api/3/field"
+
+	store, err := NewDiskCache(dir)
+	assert.NoError(t, err)
+
+	_, ok := store.Get(key)
+	assert.False(t, ok)
+
+	expires := time.Now().Add(time.Hour).Round(0)
+	store.Set(key, &CachedResponse{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Etag": {`"v1"`}},
+		Body:       []byte(`[]`),
+		ETag:       `"v1"`,
+		Expires:    expires,
+	})
+
+	// Entries survive restarts.
+	store, err = NewDiskCache(dir)
+	assert.NoError(t, err)
+
+	res, ok := store.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, []byte(`[]`), res.Body)
+	assert.Equal(t, `"v1"`, res.ETag)
+	assert.True(t, expires.Equal(res.Expires))
+
+	store.DeleteFunc(func(k string) bool { return k == key })
+
+	_, ok = store.Get(key)
+	assert.False(t, ok)
+}
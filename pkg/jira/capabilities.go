@@ -0,0 +1,100 @@
+package jira
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+const (
+	// DeploymentTypeCloud is the deployment type of Jira cloud.
+	DeploymentTypeCloud = "Cloud"
+	// DeploymentTypeServer is the deployment type of Jira server.
+	DeploymentTypeServer = "Server"
+	// DeploymentTypeDataCenter is the deployment type of Jira data center.
+	DeploymentTypeDataCenter = "DataCenter"
+)
+
+// Capabilities describes the installation of a server and the features it supports.
+type Capabilities struct {
+	ServerInfo ServerInfo
+
+	// InstallationType is InstallationTypeCloud or InstallationTypeLocal.
+	InstallationType string
+	// APIVersion is the latest REST API version, 3 on cloud and 2 otherwise.
+	APIVersion int
+	// ADF reports whether rich text fields use the Atlassian Document Format.
+	ADF bool
+	// TokenPagination reports whether search results are paginated with page
+	// tokens instead of offsets, see Search and SearchV2.
+	TokenPagination bool
+	// ApproximateCount reports whether issues can be counted without a search, see Count.
+	ApproximateCount bool
+	// AccountIDs reports whether users are identified by account id instead of name.
+	AccountIDs bool
+	// IssueTypeCreateMeta reports whether create metadata is fetched per project
+	// issue type, see GetCreateMetaForJiraServerV9.
+	IssueTypeCreateMeta bool
+}
+
+// IsCloud reports whether the server is Jira cloud.
+func (c *Capabilities) IsCloud() bool {
+	return c.InstallationType == InstallationTypeCloud
+}
+
+// AtLeast reports whether the server version is at least major.minor.
+// Cloud versions don't follow server versions, eg: 1001.0.0.
+func (c *Capabilities) AtLeast(major, minor int) bool {
+	v := slices.Concat(c.ServerInfo.VersionNumbers, []int{0, 0})
+	if v[0] != major {
+		return v[0] > major
+	}
+	return v[1] >= minor
+}
+
+// DetectCapabilities derives the capabilities of a server from its info.
+// Servers that don't report the cloud deployment type are treated as
+// on-premise installations.
+func DetectCapabilities(info *ServerInfo) *Capabilities {
+	caps := Capabilities{ServerInfo: *info}
+
+	if strings.EqualFold(info.DeploymentType, DeploymentTypeCloud) {
+		caps.InstallationType = InstallationTypeCloud
+		caps.APIVersion = 3
+		caps.ADF = true
+		caps.TokenPagination = true
+		caps.ApproximateCount = true
+		caps.AccountIDs = true
+		return &caps
+	}
+
+	caps.InstallationType = InstallationTypeLocal
+	caps.APIVersion = 2
+	caps.IssueTypeCreateMeta = caps.AtLeast(9, 0)
+
+	return &caps
+}
+
+// Capabilities detects the installation type and the features of the server.
+// The result is cached, so the server is only queried once.
+func (c *Client) Capabilities() (*Capabilities, error) {
+	return c.CapabilitiesContext(context.Background())
+}
+
+// CapabilitiesContext is the same as Capabilities but accepts a context.
+func (c *Client) CapabilitiesContext(ctx context.Context) (*Capabilities, error) {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+
+	if c.caps != nil {
+		return c.caps, nil
+	}
+
+	info, err := c.ServerInfoContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.caps = DetectCapabilities(info)
+
+	return c.caps, nil
+}
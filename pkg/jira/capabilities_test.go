@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectCapabilities(t *testing.T) {
+	cases := []struct {
+		name string
+		info ServerInfo
+		want Capabilities
+	}{
+		{
+			name: "cloud",
+			info: ServerInfo{DeploymentType: "Cloud", VersionNumbers: []int{1001, 0, 0}},
+			want: Capabilities{
+				InstallationType: InstallationTypeCloud,
+				APIVersion:       3,
+				ADF:              true,
+				TokenPagination:  true,
+				ApproximateCount: true,
+				AccountIDs:       true,
+			},
+		},
+		{
+			name: "data center 9",
+			info: ServerInfo{DeploymentType: "DataCenter", VersionNumbers: []int{9, 12, 1}},
+			want: Capabilities{
+				InstallationType:    InstallationTypeLocal,
+				APIVersion:          2,
+				IssueTypeCreateMeta: true,
+			},
+		},
+		{
+			name: "server 8",
+			info: ServerInfo{DeploymentType: "Server", VersionNumbers: []int{8, 20, 10}},
+			want: Capabilities{
+				InstallationType: InstallationTypeLocal,
+				APIVersion:       2,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.want.ServerInfo = tc.info
+			assert.Equal(t, &tc.want, DetectCapabilities(&tc.info))
+		})
+	}
+}
+
+func TestCapabilitiesAtLeast(t *testing.T) {
+	caps := Capabilities{ServerInfo: ServerInfo{VersionNumbers: []int{9, 4}}}
+
+	assert.True(t, caps.AtLeast(8, 20))
+	assert.True(t, caps.AtLeast(9, 4))
+	assert.False(t, caps.AtLeast(9, 5))
+	assert.False(t, caps.AtLeast(10, 0))
+	assert.False(t, (&Capabilities{}).AtLeast(1, 0))
+}
+
+func TestClientCapabilities(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/serverInfo", r.URL.Path)
+		requests++
+
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "9.4.0", "versionNumbers": [9, 4, 0], "deploymentType": "Server"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	// Failures are not cached.
+	_, err := client.Capabilities()
+	assert.Error(t, err)
+
+	caps, err := client.Capabilities()
+	assert.NoError(t, err)
+	assert.False(t, caps.IsCloud())
+	assert.True(t, caps.IssueTypeCreateMeta)
+
+	_, err = client.Capabilities()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
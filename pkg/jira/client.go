@@ -4,16 +4,16 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
-	"crypto/x509"
-	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
-	"net/http/httputil"
-	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/eliziario/jira-lib/pkg/credentials"
 )
 
 const (
@@ -42,17 +42,6 @@ var (
 	ErrEmptyResponse = fmt.Errorf("jira: empty response from server")
 )
 
-// ErrUnexpectedResponse denotes response code other than the expected one.
-type ErrUnexpectedResponse struct {
-	Body       Errors
-	Status     string
-	StatusCode int
-}
-
-func (e *ErrUnexpectedResponse) Error() string {
-	return e.Body.String()
-}
-
 // ErrMultipleFailed represents a grouped error, usually when
 // multiple request fails when running them in a loop.
 type ErrMultipleFailed struct {
@@ -97,10 +86,25 @@ func (e Errors) String() string {
 type Header map[string]string
 
 // MTLSConfig is MTLS authtype specific config.
+//
+// Certificates can be given as file paths, PEM bytes or a parsed certificate.
+// Certificates read from files are reloaded when the files change.
 type MTLSConfig struct {
 	CaCert     string
 	ClientCert string
 	ClientKey  string
+
+	// CaCertPEM, ClientCertPEM and ClientKeyPEM are in-memory
+	// alternatives to the files above.
+	CaCertPEM     []byte
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+
+	// Certificate is used instead of the client cert and key if set.
+	Certificate *tls.Certificate
+
+	// UseSystemRoots trusts the system root CAs in addition to the CA cert.
+	UseSystemRoots bool
 }
 
 // Config is a jira config.
@@ -112,72 +116,124 @@ type Config struct {
 	Insecure   *bool
 	Debug      bool
 	MTLSConfig MTLSConfig
+
+	// TokenSource supplies access tokens for the oauth2 auth type.
+	TokenSource TokenSource
+	// CloudID of the site, required to access it with OAuth 2.0 tokens.
+	// When set, requests are sent to the Atlassian API gateway instead of Server.
+	CloudID string
 }
 
 // Client is a jira client.
 type Client struct {
-	transport http.RoundTripper
-	insecure  bool
-	server    string
-	login     string
-	authType  *AuthType
-	token     string
-	timeout   time.Duration
-	debug     bool
+	transport    http.RoundTripper
+	httpClient   *http.Client
+	middlewares  []Middleware
+	insecure     bool
+	server       string
+	login        string
+	authType     *AuthType
+	token        string
+	tokenSource  TokenSource
+	credMu       sync.Mutex
+	credProvider credentials.Provider
+	timeout      time.Duration
+	debug        bool
+	retry        *RetryPolicy
+	retries      atomic.Uint64
+
+	limiter        *RateLimiter
+	familyLimiters map[APIFamily]*RateLimiter
+
+	bounding JQLBoundingPolicy
+
+	logger  *slog.Logger
+	logOpts *LogOptions
+
+	capsMu sync.Mutex
+	caps   *Capabilities
+
+	cache *CachePolicy
+
+	// err is a configuration error returned by all requests.
+	err error
 }
 
 // ClientFunc decorates option for client.
 type ClientFunc func(*Client)
 
 // NewClient instantiates new jira client.
+//
+// Configuration errors, eg: unreadable mTLS certificates, are returned by
+// every request sent with the client. Use New to get them upfront.
 func NewClient(c Config, opts ...ClientFunc) *Client {
 	client := Client{
-		server:   strings.TrimSuffix(c.Server, "/"),
-		login:    c.Login,
-		token:    c.APIToken,
-		authType: c.AuthType,
-		debug:    c.Debug,
+		server:      strings.TrimSuffix(c.Server, "/"),
+		login:       c.Login,
+		token:       c.APIToken,
+		tokenSource: c.TokenSource,
+		authType:    c.AuthType,
+		debug:       c.Debug,
+	}
+
+	if c.CloudID != "" {
+		client.server = OAuth2BaseURL(c.CloudID)
 	}
 
 	for _, opt := range opts {
 		opt(&client)
 	}
 
+	client.setupLogger()
+
+	if client.httpClient == nil {
+		client.httpClient = &http.Client{}
+	}
+	if client.transport == nil && client.httpClient.Transport != nil {
+		client.transport = client.httpClient.Transport
+	}
+	if client.transport == nil {
+		client.transport, client.err = client.defaultTransport(c)
+	}
+
+	// A single http client is reused for all requests.
+	client.httpClient.Transport = client.chain(client.transport)
+
+	return &client
+}
+
+// New is the same as NewClient but returns configuration errors.
+func New(c Config, opts ...ClientFunc) (*Client, error) {
+	client := NewClient(c, opts...)
+	if client.err != nil {
+		return nil, client.err
+	}
+	return client, nil
+}
+
+func (c *Client) defaultTransport(cfg Config) (*http.Transport, error) {
 	transport := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		TLSClientConfig: &tls.Config{
 			MinVersion:         tls.VersionTLS12,
-			InsecureSkipVerify: client.insecure,
+			InsecureSkipVerify: c.insecure,
 		},
 		DialContext: (&net.Dialer{
-			Timeout: client.timeout,
+			Timeout: c.timeout,
 		}).DialContext,
 	}
 
-	if c.AuthType != nil && *c.AuthType == AuthTypeMTLS {
-		// Create a CA certificate pool and add cert.pem to it.
-		caCert, err := os.ReadFile(c.MTLSConfig.CaCert)
+	if cfg.AuthType != nil && *cfg.AuthType == AuthTypeMTLS {
+		loader, err := newCertLoader(cfg.MTLSConfig)
 		if err != nil {
-			log.Fatalf("%s, %s", err, c.MTLSConfig.CaCert)
-		}
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-
-		// Read the key pair to create the certificate.
-		cert, err := tls.LoadX509KeyPair(c.MTLSConfig.ClientCert, c.MTLSConfig.ClientKey)
-		if err != nil {
-			log.Fatal(err)
+			return transport, err
 		}
 
 		// Add the MTLS specific configuration.
-		transport.TLSClientConfig.RootCAs = caCertPool
-		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
-		transport.TLSClientConfig.Renegotiation = tls.RenegotiateFreelyAsClient
+		loader.configure(transport)
 	}
 
-	client.transport = transport
-
-	return &client
+	return transport, nil
 }
 
 // WithTimeout is a functional opt to attach timeout to the client.
@@ -245,78 +301,67 @@ func (c *Client) DeleteV2(ctx context.Context, path string, headers Header) (*ht
 }
 
 func (c *Client) request(ctx context.Context, method, endpoint string, body []byte, headers Header) (*http.Response, error) {
-	var (
-		req *http.Request
-		res *http.Response
-		err error
-	)
-
-	req, err = http.NewRequest(method, endpoint, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+	if c.err != nil {
+		return nil, c.err
 	}
 
-	defer func() {
-		if c.debug {
-			dump(req, res)
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx, endpoint); err != nil {
+			return nil, err
 		}
-	}()
-
-	for k, v := range headers {
-		req.Header.Set(k, v)
-	}
 
-	// Set default auth type to `basic`.
-	if c.authType == nil {
-		basic := AuthTypeBasic
-		c.authType = &basic
-	}
+		res, err := c.do(ctx, method, endpoint, body, headers, attempt)
 
-	// When need to compare using `String()` here, it is used to handle cases where the
-	// authentication type might be empty, ensuring it defaults to the appropriate value.
-	switch c.authType.String() {
-	case string(AuthTypeMTLS):
-		if c.token != "" {
-			req.Header.Add("Authorization", "Bearer "+c.token)
+		wait, ok := c.retry.shouldRetry(ctx, method, attempt, res, err)
+		if !ok {
+			return res, err
 		}
-	case string(AuthTypeBearer):
-		req.Header.Add("Authorization", "Bearer "+c.token)
-	case string(AuthTypeBasic):
-		req.SetBasicAuth(c.login, c.token)
-	}
-
-	httpClient := &http.Client{Transport: c.transport}
 
-	return httpClient.Do(req.WithContext(ctx))
-}
-
-func dump(req *http.Request, res *http.Response) {
-	reqDump, _ := httputil.DumpRequest(req, true)
-	prettyPrintDump("Request Details", reqDump)
+		status := 0
+		if res != nil {
+			status = res.StatusCode
+		}
+		discard(res)
+
+		ra := RetryAttempt{
+			Attempt:    attempt + 1,
+			Method:     method,
+			URL:        endpoint,
+			StatusCode: status,
+			Err:        err,
+			Wait:       wait,
+		}
+		c.logRetry(ctx, ra)
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(ra)
+		}
+		c.retries.Add(1)
 
-	if res != nil {
-		respDump, _ := httputil.DumpResponse(res, false)
-		prettyPrintDump("Response Details", respDump)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
 	}
 }
 
-func prettyPrintDump(heading string, data []byte) {
-	const separatorWidth = 60
+func (c *Client) do(ctx context.Context, method, endpoint string, body []byte, headers Header, attempt int) (*http.Response, error) {
+	var (
+		req *http.Request
+		res *http.Response
+		err error
+	)
 
-	fmt.Printf("\n\n%s", strings.ToUpper(heading))
-	fmt.Printf("\n%s\n\n", strings.Repeat("-", separatorWidth))
-	fmt.Print(string(data))
-}
+	req, err = http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
 
-func formatUnexpectedResponse(res *http.Response) *ErrUnexpectedResponse {
-	var b Errors
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
-	// We don't care about decoding error here.
-	_ = json.NewDecoder(res.Body).Decode(&b)
+	start := time.Now()
+	res, err = c.httpClient.Do(req)
+	c.logRequest(ctx, req, body, res, err, attempt, time.Since(start))
 
-	return &ErrUnexpectedResponse{
-		Body:       b,
-		Status:     res.Status,
-		StatusCode: res.StatusCode,
-	}
+	return res, err
 }
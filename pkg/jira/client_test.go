@@ -207,3 +207,21 @@ func TestDeleteV2(t *testing.T) {
 
 	_ = resp.Body.Close()
 }
+
+func TestRequestWithCancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	_, err := client.SearchContext(ctx, "project=TEST", 0, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = client.GetIssueContext(ctx, "TEST-1")
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,75 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type countRequest struct {
+	JQL string `json:"jql"`
+}
+
+type countResponse struct {
+	Count int `json:"count"`
+}
+
+// Count returns the approximate number of issues matching the jql using
+// the Jira cloud POST /search/approximate-count endpoint.
+//
+// The count is eventually consistent, so recently created or updated
+// issues might not be reflected yet.
+func (c *Client) Count(jql string) (int, error) {
+	return c.CountContext(context.Background(), jql)
+}
+
+// CountContext is the same as Count but accepts a context.
+func (c *Client) CountContext(ctx context.Context, jql string) (int, error) {
+	// The approximate count endpoint rejects unbounded queries as well.
+	bounded, err := c.bounding.apply(jql)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := json.Marshal(&countRequest{JQL: bounded})
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := c.Post(ctx, "/search/approximate-count", body, Header{
+		"Accept":       "application/json",
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return 0, err
+	}
+	if res == nil {
+		return 0, ErrEmptyResponse
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, formatUnexpectedResponse(res)
+	}
+
+	var out countResponse
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return 0, err
+	}
+	return out.Count, nil
+}
+
+// CountV2 returns the exact number of issues matching the jql using v2 version
+// of the Jira GET /search endpoint. No issues are fetched in the process.
+func (c *Client) CountV2(jql string) (int, error) {
+	return c.CountV2Context(context.Background(), jql)
+}
+
+// CountV2Context is the same as CountV2 but accepts a context.
+func (c *Client) CountV2Context(ctx context.Context, jql string) (int, error) {
+	out, err := c.search(ctx, jql, 0, 0, apiVersion2, nil)
+	if err != nil {
+		return 0, err
+	}
+	return out.Total, nil
+}
@@ -3,6 +3,7 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -24,9 +25,11 @@ type CreateRequest struct {
 	IssueType string
 	// ParentIssueKey is required when creating a sub-task for classic project.
 	// This can also be used to attach epic for next-gen project.
-	ParentIssueKey   string
-	Summary          string
-	Body             interface{} // string in v1/v2 and adf.ADF in v3
+	ParentIssueKey string
+	Summary        string
+	// Body is CommonMark or an *adf.ADF. CommonMark is converted to
+	// Jira wiki markup in v2 and to ADF in v3.
+	Body             interface{}
 	Reporter         string
 	Assignee         string
 	Priority         string
@@ -66,18 +69,38 @@ func (cr *CreateRequest) WithCustomFields(cf []IssueTypeField) {
 	cr.configuredCustomFields = cf
 }
 
+// HasCustomFields reports whether valid custom fields were set with WithCustomFields.
+func (cr *CreateRequest) HasCustomFields() bool {
+	return len(cr.configuredCustomFields) > 0
+}
+
 // Create creates an issue using v3 version of the POST /issue endpoint.
 func (c *Client) Create(req *CreateRequest) (*CreateResponse, error) {
-	return c.create(req, apiVersion3)
+	return c.CreateContext(context.Background(), req)
+}
+
+// CreateContext is the same as Create but accepts a context.
+func (c *Client) CreateContext(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return c.create(ctx, req, apiVersion3)
 }
 
 // CreateV2 creates an issue using v2 version of the POST /issue endpoint.
 func (c *Client) CreateV2(req *CreateRequest) (*CreateResponse, error) {
-	return c.create(req, apiVersion2)
+	return c.CreateV2Context(context.Background(), req)
 }
 
-func (c *Client) create(req *CreateRequest, ver string) (*CreateResponse, error) {
-	data := c.getRequestData(req)
+// CreateV2Context is the same as CreateV2 but accepts a context.
+func (c *Client) CreateV2Context(ctx context.Context, req *CreateRequest) (*CreateResponse, error) {
+	return c.create(ctx, req, apiVersion2)
+}
+
+func (c *Client) create(ctx context.Context, req *CreateRequest, ver string) (*CreateResponse, error) {
+	data := c.getRequestData(req, ver)
+	if doc, ok := data.Fields.M.Description.(*adf.ADF); ok {
+		if err := doc.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: description: %w", ErrValidation, err)
+		}
+	}
 
 	body, err := json.Marshal(&data)
 	if err != nil {
@@ -93,9 +116,9 @@ func (c *Client) create(req *CreateRequest, ver string) (*CreateResponse, error)
 
 	switch ver {
 	case apiVersion2:
-		res, err = c.PostV2(context.Background(), "/issue", body, header)
+		res, err = c.PostV2(ctx, "/issue", body, header)
 	default:
-		res, err = c.Post(context.Background(), "/issue", body, header)
+		res, err = c.Post(ctx, "/issue", body, header)
 	}
 
 	if err != nil {
@@ -117,7 +140,7 @@ func (c *Client) create(req *CreateRequest, ver string) (*CreateResponse, error)
 	return &out, err
 }
 
-func (*Client) getRequestData(req *CreateRequest) *createRequest {
+func (*Client) getRequestData(req *CreateRequest, ver string) *createRequest {
 	if req.Labels == nil {
 		req.Labels = []string{}
 	}
@@ -137,7 +160,11 @@ func (*Client) getRequestData(req *CreateRequest) *createRequest {
 
 	switch v := req.Body.(type) {
 	case string:
-		cf.Description = md.ToJiraMD(v)
+		if ver == apiVersion2 {
+			cf.Description = md.ToJiraMD(v)
+		} else if v != "" {
+			cf.Description = adf.FromMarkdown(v)
+		}
 	case *adf.ADF:
 		cf.Description = v
 	}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/adf"
 )
 
 type createTestServer struct{ code int }
@@ -178,3 +180,68 @@ func TestCreateEpicNextGen(t *testing.T) {
 	_, err = client.CreateV2(&requestData)
 	assert.Error(t, &ErrUnexpectedResponse{}, err)
 }
+
+func TestCreateConvertsMarkdownToADF(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/issue", r.URL.Path)
+
+		actualBody := new(strings.Builder)
+		_, _ = io.Copy(actualBody, r.Body)
+
+		expectedBody := `{"update":{},"fields":{"project":{"key":"TEST"},"issuetype":{"name":"Bug"},"summary":"Test bug",` +
+			`"description":{"version":1,"type":"doc","content":[` +
+			`{"type":"heading","attrs":{"level":2},"content":[{"type":"text","text":"Steps"}]},` +
+			`{"type":"orderedList","content":[{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"Run "},` +
+			`{"type":"text","text":"make","marks":[{"type":"code"}]}]}]}]}]}}}`
+		assert.JSONEq(t, expectedBody, actualBody.String())
+
+		resp, err := os.ReadFile("./testdata/create.json")
+		assert.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		_, _ = w.Write(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	actual, err := client.Create(&CreateRequest{
+		Project:   "TEST",
+		IssueType: "Bug",
+		Summary:   "Test bug",
+		Body:      "## Steps\n\n1. Run `make`",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-3", actual.Key)
+}
+
+func TestCreateRejectsInvalidADF(t *testing.T) {
+	var called bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(201)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	doc := &adf.ADF{Version: 1, DocType: "doc", Content: []*adf.Node{
+		{NodeType: adf.NodeHeading, Attributes: map[string]any{"level": 7}},
+	}}
+
+	_, err := client.Create(&CreateRequest{
+		Project:   "TEST",
+		IssueType: "Bug",
+		Summary:   "Test bug",
+		Body:      doc,
+	})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.EqualError(t, err, "jira: validation failed: description: content[0].attrs.level: must be between 1 and 6, got 7")
+
+	var verr *adf.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.Equal(t, "content[0].attrs.level", verr.Path)
+	assert.False(t, called)
+}
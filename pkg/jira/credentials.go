@@ -0,0 +1,37 @@
+package jira
+
+import (
+	"context"
+
+	"github.com/eliziario/jira-lib/pkg/credentials"
+)
+
+// WithCredentialProvider is a functional opt to resolve the login and API token
+// using the given provider when the token is not set in the config. Credentials
+// are resolved on the first request and reused afterwards.
+func WithCredentialProvider(p credentials.Provider) ClientFunc {
+	return func(c *Client) {
+		c.credProvider = p
+	}
+}
+
+// credentials returns the login and token, resolving them if needed.
+func (c *Client) credentials(ctx context.Context) (string, string, error) {
+	c.credMu.Lock()
+	defer c.credMu.Unlock()
+
+	if c.token != "" || c.credProvider == nil {
+		return c.login, c.token, nil
+	}
+
+	creds, err := c.credProvider.Credentials(ctx, c.server, c.login)
+	if err != nil {
+		return "", "", err
+	}
+	if creds.Login != "" {
+		c.login = creds.Login
+	}
+	c.token = creds.Token
+
+	return c.login, c.token, nil
+}
@@ -0,0 +1,59 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/credentials"
+)
+
+func TestWithCredentialProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "resolved-user", login)
+		assert.Equal(t, "resolved-token", token)
+
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var calls int
+	provider := credentials.ProviderFunc(func(_ context.Context, s, login string) (*credentials.Credentials, error) {
+		calls++
+		assert.Equal(t, server.URL, s)
+		assert.Empty(t, login)
+
+		return &credentials.Credentials{Login: "resolved-user", Token: "resolved-token"}, nil
+	})
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithCredentialProvider(provider))
+
+	for range 2 {
+		res, err := client.GetV2(context.Background(), "/myself", nil)
+		assert.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	// Credentials are resolved once.
+	assert.Equal(t, 1, calls)
+
+	// The provider is not used if the token is set.
+	client = NewClient(Config{Server: server.URL, Login: "resolved-user", APIToken: "resolved-token"}, WithCredentialProvider(provider))
+
+	res, err := client.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+	assert.Equal(t, 1, calls)
+
+	// Resolution errors are returned to the caller.
+	client = NewClient(Config{Server: server.URL}, WithCredentialProvider(credentials.Chain()))
+
+	_, err = client.GetV2(context.Background(), "/myself", nil)
+	assert.ErrorIs(t, err, credentials.ErrNotFound)
+}
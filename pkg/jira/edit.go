@@ -3,11 +3,15 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"maps"
 	"net/http"
 	"slices"
 	"strconv"
 	"strings"
+
+	"github.com/eliziario/jira-lib/pkg/adf"
+	"github.com/eliziario/jira-lib/pkg/md"
 )
 
 const separatorMinus = "-"
@@ -21,9 +25,11 @@ type EditResponse struct {
 // EditRequest struct holds request data for edit request.
 // Setting an Assignee requires an account ID.
 type EditRequest struct {
-	IssueType       string
-	ParentIssueKey  string
-	Summary         string
+	IssueType      string
+	ParentIssueKey string
+	Summary        string
+	// Body is CommonMark. It's converted to ADF in v3
+	// and to Jira wiki markup in v2.
 	Body            string
 	Priority        string
 	Labels          []string
@@ -43,9 +49,38 @@ func (er *EditRequest) WithCustomFields(cf []IssueTypeField) {
 	er.configuredCustomFields = cf
 }
 
-// Edit updates an issue using POST /issue endpoint.
+// HasCustomFields reports whether valid custom fields were set with WithCustomFields.
+func (er *EditRequest) HasCustomFields() bool {
+	return len(er.configuredCustomFields) > 0
+}
+
+// Edit updates an issue using v2 version of the PUT /issue/{key} endpoint.
 func (c *Client) Edit(key string, req *EditRequest) error {
-	data := getRequestDataForEdit(req)
+	return c.EditContext(context.Background(), key, req)
+}
+
+// EditContext is the same as Edit but accepts a context.
+func (c *Client) EditContext(ctx context.Context, key string, req *EditRequest) error {
+	return c.edit(ctx, key, req, apiVersion2)
+}
+
+// EditV3 updates an issue using v3 version of the PUT /issue/{key} endpoint.
+func (c *Client) EditV3(key string, req *EditRequest) error {
+	return c.EditV3Context(context.Background(), key, req)
+}
+
+// EditV3Context is the same as EditV3 but accepts a context.
+func (c *Client) EditV3Context(ctx context.Context, key string, req *EditRequest) error {
+	return c.edit(ctx, key, req, apiVersion3)
+}
+
+func (c *Client) edit(ctx context.Context, key string, req *EditRequest, ver string) error {
+	data := getRequestDataForEdit(req, ver)
+	if doc, ok := data.Update.M.Description[0].Set.(*adf.ADF); ok {
+		if err := doc.Validate(); err != nil {
+			return fmt.Errorf("%w: description: %w", ErrValidation, err)
+		}
+	}
 
 	body, err := json.Marshal(&data)
 	if err != nil {
@@ -57,10 +92,20 @@ func (c *Client) Edit(key string, req *EditRequest) error {
 		endpoint += "?notifyUsers=false"
 	}
 
-	res, err := c.PutV2(context.Background(), endpoint, body, Header{
+	header := Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
-	})
+	}
+
+	var res *http.Response
+
+	switch ver {
+	case apiVersion2:
+		res, err = c.PutV2(ctx, endpoint, body, header)
+	default:
+		res, err = c.Put(ctx, endpoint, body, header)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -81,7 +126,7 @@ type editFields struct {
 		Set string `json:"set,omitempty"`
 	} `json:"summary,omitempty"`
 	Description []struct {
-		Set string `json:"set,omitempty"`
+		Set any `json:"set,omitempty"`
 	} `json:"description,omitempty"`
 	Priority []struct {
 		Set struct {
@@ -129,7 +174,7 @@ func (cfm *editFieldsMarshaler) MarshalJSON() ([]byte, error) {
 	if len(cfm.M.Summary) == 0 || cfm.M.Summary[0].Set == "" {
 		cfm.M.Summary = nil
 	}
-	if len(cfm.M.Description) == 0 || cfm.M.Description[0].Set == "" {
+	if len(cfm.M.Description) == 0 || cfm.M.Description[0].Set == nil {
 		cfm.M.Description = nil
 	}
 	if len(cfm.M.Priority) == 0 || cfm.M.Priority[0].Set.Name == "" {
@@ -167,18 +212,27 @@ type editRequest struct {
 	} `json:"fields"`
 }
 
-func getRequestDataForEdit(req *EditRequest) *editRequest {
+func getRequestDataForEdit(req *EditRequest, ver string) *editRequest {
 	if req.Labels == nil {
 		req.Labels = []string{}
 	}
 
+	var description any
+	if req.Body != "" {
+		if ver == apiVersion2 {
+			description = md.ToJiraMD(req.Body)
+		} else {
+			description = adf.FromMarkdown(req.Body)
+		}
+	}
+
 	update := editFieldsMarshaler{editFields{
 		Summary: []struct {
 			Set string `json:"set,omitempty"`
 		}{{Set: req.Summary}},
 		Description: []struct {
-			Set string `json:"set,omitempty"`
-		}{{Set: req.Body}},
+			Set any `json:"set,omitempty"`
+		}{{Set: description}},
 		Priority: []struct {
 			Set struct {
 				Name string `json:"name,omitempty"`
@@ -0,0 +1,71 @@
+package jira
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEdit(t *testing.T) {
+	cases := []struct {
+		name         string
+		edit         func(*Client, *EditRequest) error
+		expectedPath string
+		expectedBody string
+	}{
+		{
+			name:         "v3",
+			edit:         func(c *Client, req *EditRequest) error { return c.EditV3("TEST-1", req) },
+			expectedPath: "/rest/api/3/issue/TEST-1",
+			expectedBody: `{"update":{"summary":[{"set":"New summary"}],"description":[{"set":{"version":1,"type":"doc","content":[` +
+				`{"type":"paragraph","content":[{"type":"text","text":"Some "},{"type":"text","text":"bold","marks":[{"type":"strong"}]},` +
+				`{"type":"text","text":" text"}]}]}}],"labels":[{"add":"api"}]},"fields":{"parent":{}}}`,
+		},
+		{
+			name:         "v2",
+			edit:         func(c *Client, req *EditRequest) error { return c.Edit("TEST-1", req) },
+			expectedPath: "/rest/api/2/issue/TEST-1",
+			expectedBody: `{"update":{"summary":[{"set":"New summary"}],"description":[{"set":"Some *bold* text\n\n"}],` +
+				`"labels":[{"add":"api"}]},"fields":{"parent":{}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var unexpectedStatusCode bool
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "PUT", r.Method)
+				assert.Equal(t, tc.expectedPath, r.URL.Path)
+
+				actualBody := new(strings.Builder)
+				_, _ = io.Copy(actualBody, r.Body)
+
+				assert.JSONEq(t, tc.expectedBody, actualBody.String())
+
+				if unexpectedStatusCode {
+					w.WriteHeader(400)
+				} else {
+					w.WriteHeader(204)
+				}
+			}))
+			defer server.Close()
+
+			client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+			req := func() *EditRequest {
+				return &EditRequest{Summary: "New summary", Body: "Some **bold** text", Labels: []string{"api"}}
+			}
+			assert.NoError(t, tc.edit(client, req()))
+
+			unexpectedStatusCode = true
+
+			assert.Error(t, tc.edit(client, req()))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnauthorized denotes missing or invalid credentials.
+	ErrUnauthorized = fmt.Errorf("jira: unauthorized")
+	// ErrForbidden denotes insufficient permissions for the operation.
+	ErrForbidden = fmt.Errorf("jira: forbidden")
+	// ErrNotFound denotes the resource doesn't exist or isn't visible to the user.
+	ErrNotFound = fmt.Errorf("jira: resource not found")
+	// ErrConflict denotes the request conflicts with the current state of the resource.
+	ErrConflict = fmt.Errorf("jira: conflict")
+	// ErrValidation denotes the request was rejected as invalid, either by the
+	// server or before sending it, eg: a malformed ADF description.
+	ErrValidation = fmt.Errorf("jira: validation failed")
+	// ErrRateLimited denotes the request was throttled by the server.
+	ErrRateLimited = fmt.Errorf("jira: rate limited")
+)
+
+// Header names used by Jira to identify a request.
+const (
+	headerRequestID = "X-Arequestid"
+	headerTraceID   = "Atl-Traceid"
+)
+
+// ErrUnexpectedResponse denotes response code other than the expected one.
+//
+// Use errors.Is with ErrNotFound, ErrUnauthorized, ErrForbidden, ErrConflict,
+// ErrValidation and ErrRateLimited to check for the common failures, and
+// errors.As to access the details of the response.
+type ErrUnexpectedResponse struct {
+	Body       Errors
+	Status     string
+	StatusCode int
+
+	// Method and Path identify the request that failed.
+	Method string
+	Path   string
+	// RequestID is the request identifier assigned by Jira, if any.
+	// It is useful when reporting issues to Atlassian support.
+	RequestID string
+	// RetryAfter is the wait requested by the server for throttled requests.
+	RetryAfter time.Duration
+}
+
+// NewErrUnexpectedResponse constructs an error from a response with an unexpected
+// status code. It can be used to handle responses from the raw Get, Post and Put calls.
+func NewErrUnexpectedResponse(res *http.Response) *ErrUnexpectedResponse {
+	var b Errors
+
+	// We don't care about decoding error here.
+	_ = json.NewDecoder(res.Body).Decode(&b)
+
+	e := ErrUnexpectedResponse{
+		Body:       b,
+		Status:     res.Status,
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get(headerRequestID),
+	}
+	if e.RequestID == "" {
+		e.RequestID = res.Header.Get(headerTraceID)
+	}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.Path = res.Request.URL.Path
+	}
+	if wait, ok := serverWait(res.Header, time.Now()); ok {
+		e.RetryAfter = wait
+	}
+
+	return &e
+}
+
+func (e *ErrUnexpectedResponse) Error() string {
+	var out strings.Builder
+
+	out.WriteString("jira: ")
+	if e.Method != "" {
+		out.WriteString(fmt.Sprintf("%s %s: ", e.Method, e.Path))
+	}
+	if e.Status != "" {
+		out.WriteString(e.Status)
+	} else {
+		out.WriteString(fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)))
+	}
+	if e.RequestID != "" {
+		out.WriteString(fmt.Sprintf(" (request id: %s)", e.RequestID))
+	}
+	out.WriteString(e.Body.String())
+
+	return out.String()
+}
+
+// Is reports whether the error matches one of the status specific errors.
+func (e *ErrUnexpectedResponse) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// FieldErrors returns the validation messages keyed by the field they relate to.
+func (e *ErrUnexpectedResponse) FieldErrors() map[string]string {
+	return e.Body.Errors
+}
+
+func formatUnexpectedResponse(res *http.Response) *ErrUnexpectedResponse {
+	return NewErrUnexpectedResponse(res)
+}
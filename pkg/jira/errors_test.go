@@ -0,0 +1,77 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrUnexpectedResponse(t *testing.T) {
+	cases := []struct {
+		name       string
+		statusCode int
+		target     error
+	}{
+		{name: "unauthorized", statusCode: 401, target: ErrUnauthorized},
+		{name: "forbidden", statusCode: 403, target: ErrForbidden},
+		{name: "not found", statusCode: 404, target: ErrNotFound},
+		{name: "conflict", statusCode: 409, target: ErrConflict},
+		{name: "bad request", statusCode: 400, target: ErrValidation},
+		{name: "unprocessable entity", statusCode: 422, target: ErrValidation},
+		{name: "too many requests", statusCode: 429, target: ErrRateLimited},
+	}
+
+	targets := []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrConflict, ErrValidation, ErrRateLimited}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := error(&ErrUnexpectedResponse{StatusCode: tc.statusCode})
+
+			for _, target := range targets {
+				assert.Equal(t, target == tc.target, errors.Is(err, target), target.Error())
+			}
+		})
+	}
+}
+
+func TestErrUnexpectedResponseDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-AREQUESTID", "req-123")
+		w.Header().Set("Retry-After", "2")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/rest/api/3/issue" {
+			w.WriteHeader(400)
+			_, _ = w.Write([]byte(`{"errorMessages": [], "errors": {"summary": "You must specify a summary of the issue."}}`))
+			return
+		}
+
+		w.WriteHeader(404)
+		_, _ = w.Write([]byte(`{"errorMessages": ["Issue does not exist or you do not have permission to see it."]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	_, err := client.GetIssue("TEST-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var jiraErr *ErrUnexpectedResponse
+	assert.True(t, errors.As(err, &jiraErr))
+	assert.Equal(t, "GET", jiraErr.Method)
+	assert.Equal(t, "/rest/api/3/issue/TEST-1", jiraErr.Path)
+	assert.Equal(t, "req-123", jiraErr.RequestID)
+	assert.Equal(t, 2*time.Second, jiraErr.RetryAfter)
+	assert.Equal(t, "jira: GET /rest/api/3/issue/TEST-1: 404 Not Found (request id: req-123)\n"+
+		"Error:\n  - Issue does not exist or you do not have permission to see it.\n", err.Error())
+
+	_, err = client.Create(&CreateRequest{Project: "TEST", IssueType: "Bug"})
+	assert.ErrorIs(t, err, ErrValidation)
+	assert.True(t, errors.As(err, &jiraErr))
+	assert.Equal(t, "POST", jiraErr.Method)
+	assert.Equal(t, map[string]string{"summary": "You must specify a summary of the issue."}, jiraErr.FieldErrors())
+}
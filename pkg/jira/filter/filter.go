@@ -34,3 +34,29 @@ func (flt Collection) GetInt(key Key) int {
 	}
 	return 0
 }
+
+// GetString returns filter value as a string.
+func (flt Collection) GetString(key Key) string {
+	for _, f := range flt {
+		if f.Key() != key {
+			continue
+		}
+		if v, ok := f.Val().(string); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// GetStrings returns filter value as a slice of strings.
+func (flt Collection) GetStrings(key Key) []string {
+	for _, f := range flt {
+		if f.Key() != key {
+			continue
+		}
+		if v, ok := f.Val().([]string); ok {
+			return v
+		}
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/eliziario/jira-lib/pkg/jira/filter"
 	"github.com/eliziario/jira-lib/pkg/jira/filter/issue"
+	"github.com/eliziario/jira-lib/pkg/jira/filter/search"
 )
 
 func TestCollectionGet(t *testing.T) {
@@ -20,3 +21,15 @@ func TestCollectionGetInt(t *testing.T) {
 	assert.Equal(t, 5, cltn.GetInt(cltn[0].Key()))
 	assert.Equal(t, 0, cltn.GetInt("unknown"))
 }
+
+func TestCollectionGetString(t *testing.T) {
+	cltn := filter.Collection{search.NewValidateQueryFilter(search.ValidateQueryWarn)}
+	assert.Equal(t, "warn", cltn.GetString(cltn[0].Key()))
+	assert.Equal(t, "", cltn.GetString("unknown"))
+}
+
+func TestCollectionGetStrings(t *testing.T) {
+	cltn := filter.Collection{issue.NewFieldsFilter("summary", "status")}
+	assert.Equal(t, []string{"summary", "status"}, cltn.GetStrings(cltn[0].Key()))
+	assert.Nil(t, cltn.GetStrings("unknown"))
+}
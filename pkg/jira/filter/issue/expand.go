@@ -0,0 +1,32 @@
+package issue
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeyIssueExpand is a filter key for issue expand options.
+const KeyIssueExpand = filter.Key("issue-expand")
+
+// ExpandFilter is a filter for issue expand options.
+type ExpandFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewExpandFilter constructs a filter to expand additional issue data like changelog or renderedFields.
+func NewExpandFilter(expand ...string) ExpandFilter {
+	return ExpandFilter{
+		key:   KeyIssueExpand,
+		value: expand,
+	}
+}
+
+// Key returns key of this filter.
+func (f ExpandFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f ExpandFilter) Val() interface{} {
+	return f.value
+}
@@ -0,0 +1,32 @@
+package issue
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeyIssueFields is a filter key for issue fields.
+const KeyIssueFields = filter.Key("issue-fields")
+
+// FieldsFilter is a filter for issue fields.
+type FieldsFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewFieldsFilter constructs a filter to select the fields returned for an issue.
+func NewFieldsFilter(fields ...string) FieldsFilter {
+	return FieldsFilter{
+		key:   KeyIssueFields,
+		value: fields,
+	}
+}
+
+// Key returns key of this filter.
+func (f FieldsFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f FieldsFilter) Val() interface{} {
+	return f.value
+}
@@ -0,0 +1,32 @@
+package issue
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeyIssueProperties is a filter key for issue properties.
+const KeyIssueProperties = filter.Key("issue-properties")
+
+// PropertiesFilter is a filter for issue properties.
+type PropertiesFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewPropertiesFilter constructs a filter to select the issue properties returned for an issue.
+func NewPropertiesFilter(properties ...string) PropertiesFilter {
+	return PropertiesFilter{
+		key:   KeyIssueProperties,
+		value: properties,
+	}
+}
+
+// Key returns key of this filter.
+func (f PropertiesFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f PropertiesFilter) Val() interface{} {
+	return f.value
+}
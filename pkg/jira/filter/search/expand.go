@@ -0,0 +1,32 @@
+package search
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeySearchExpand is a filter key for search expand options.
+const KeySearchExpand = filter.Key("search-expand")
+
+// ExpandFilter is a filter for search expand options.
+type ExpandFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewExpandFilter constructs a filter to expand additional data like changelog or names for the search result.
+func NewExpandFilter(expand ...string) ExpandFilter {
+	return ExpandFilter{
+		key:   KeySearchExpand,
+		value: expand,
+	}
+}
+
+// Key returns key of this filter.
+func (f ExpandFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f ExpandFilter) Val() interface{} {
+	return f.value
+}
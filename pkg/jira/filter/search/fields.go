@@ -0,0 +1,32 @@
+package search
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeySearchFields is a filter key for search result fields.
+const KeySearchFields = filter.Key("search-fields")
+
+// FieldsFilter is a filter for search result fields.
+type FieldsFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewFieldsFilter constructs a filter to select the fields returned for each issue in the search result.
+func NewFieldsFilter(fields ...string) FieldsFilter {
+	return FieldsFilter{
+		key:   KeySearchFields,
+		value: fields,
+	}
+}
+
+// Key returns key of this filter.
+func (f FieldsFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f FieldsFilter) Val() interface{} {
+	return f.value
+}
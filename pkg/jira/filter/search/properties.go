@@ -0,0 +1,32 @@
+package search
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeySearchProperties is a filter key for search result properties.
+const KeySearchProperties = filter.Key("search-properties")
+
+// PropertiesFilter is a filter for search result properties.
+type PropertiesFilter struct {
+	key   filter.Key
+	value []string
+}
+
+// NewPropertiesFilter constructs a filter to select the issue properties returned for each issue in the search result.
+func NewPropertiesFilter(properties ...string) PropertiesFilter {
+	return PropertiesFilter{
+		key:   KeySearchProperties,
+		value: properties,
+	}
+}
+
+// Key returns key of this filter.
+func (f PropertiesFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f PropertiesFilter) Val() interface{} {
+	return f.value
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+)
+
+// KeySearchValidateQuery is a filter key for search query validation.
+const KeySearchValidateQuery = filter.Key("search-validate-query")
+
+// Query validation modes.
+const (
+	ValidateQueryStrict = "strict"
+	ValidateQueryWarn   = "warn"
+	ValidateQueryNone   = "none"
+)
+
+// ValidateQueryFilter is a filter for search query validation.
+type ValidateQueryFilter struct {
+	key   filter.Key
+	value string
+}
+
+// NewValidateQueryFilter constructs a filter to set how the JQL query is validated.
+// Valid values are strict, warn and none.
+func NewValidateQueryFilter(mode string) ValidateQueryFilter {
+	return ValidateQueryFilter{
+		key:   KeySearchValidateQuery,
+		value: mode,
+	}
+}
+
+// Key returns key of this filter.
+func (f ValidateQueryFilter) Key() filter.Key {
+	return f.key
+}
+
+// Val returns value of this filter.
+func (f ValidateQueryFilter) Val() interface{} {
+	return f.value
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/eliziario/jira-lib/pkg/jira/filter/issue"
@@ -27,8 +28,16 @@ const (
 )
 
 // GetIssue fetches issue details using GET /issue/{key} endpoint.
+//
+// Use filters from the issue filter package to limit comments and to select
+// fields, expand options and properties.
 func (c *Client) GetIssue(key string, opts ...filter.Filter) (*Issue, error) {
-	iss, err := c.getIssue(key, apiVersion3)
+	return c.GetIssueContext(context.Background(), key, opts...)
+}
+
+// GetIssueContext is the same as GetIssue but accepts a context.
+func (c *Client) GetIssueContext(ctx context.Context, key string, opts ...filter.Filter) (*Issue, error) {
+	iss, err := c.getIssue(ctx, key, apiVersion3, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -48,12 +57,17 @@ func (c *Client) GetIssue(key string, opts ...filter.Filter) (*Issue, error) {
 }
 
 // GetIssueV2 fetches issue details using v2 version of Jira GET /issue/{key} endpoint.
-func (c *Client) GetIssueV2(key string, _ ...filter.Filter) (*Issue, error) {
-	return c.getIssue(key, apiVersion2)
+func (c *Client) GetIssueV2(key string, opts ...filter.Filter) (*Issue, error) {
+	return c.GetIssueV2Context(context.Background(), key, opts...)
+}
+
+// GetIssueV2Context is the same as GetIssueV2 but accepts a context.
+func (c *Client) GetIssueV2Context(ctx context.Context, key string, opts ...filter.Filter) (*Issue, error) {
+	return c.getIssue(ctx, key, apiVersion2, opts)
 }
 
-func (c *Client) getIssue(key, ver string) (*Issue, error) {
-	rawOut, err := c.getIssueRaw(key, ver)
+func (c *Client) getIssue(ctx context.Context, key, ver string, opts filter.Collection) (*Issue, error) {
+	rawOut, err := c.getIssueRaw(ctx, key, ver, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -67,17 +81,27 @@ func (c *Client) getIssue(key, ver string) (*Issue, error) {
 }
 
 // GetIssueRaw fetches issue details same as GetIssue but returns the raw API response body string.
-func (c *Client) GetIssueRaw(key string) (string, error) {
-	return c.getIssueRaw(key, apiVersion3)
+func (c *Client) GetIssueRaw(key string, opts ...filter.Filter) (string, error) {
+	return c.GetIssueRawContext(context.Background(), key, opts...)
+}
+
+// GetIssueRawContext is the same as GetIssueRaw but accepts a context.
+func (c *Client) GetIssueRawContext(ctx context.Context, key string, opts ...filter.Filter) (string, error) {
+	return c.getIssueRaw(ctx, key, apiVersion3, opts)
 }
 
 // GetIssueV2Raw fetches issue details same as GetIssueV2 but returns the raw API response body string.
-func (c *Client) GetIssueV2Raw(key string) (string, error) {
-	return c.getIssueRaw(key, apiVersion2)
+func (c *Client) GetIssueV2Raw(key string, opts ...filter.Filter) (string, error) {
+	return c.GetIssueV2RawContext(context.Background(), key, opts...)
+}
+
+// GetIssueV2RawContext is the same as GetIssueV2Raw but accepts a context.
+func (c *Client) GetIssueV2RawContext(ctx context.Context, key string, opts ...filter.Filter) (string, error) {
+	return c.getIssueRaw(ctx, key, apiVersion2, opts)
 }
 
-func (c *Client) getIssueRaw(key, ver string) (string, error) {
-	path := fmt.Sprintf("/issue/%s", key)
+func (c *Client) getIssueRaw(ctx context.Context, key, ver string, opts filter.Collection) (string, error) {
+	path := fmt.Sprintf("/issue/%s", key) + issueParams(opts)
 
 	var (
 		res *http.Response
@@ -86,9 +110,9 @@ func (c *Client) getIssueRaw(key, ver string) (string, error) {
 
 	switch ver {
 	case apiVersion2:
-		res, err = c.GetV2(context.Background(), path, nil)
+		res, err = c.GetV2(ctx, path, nil)
 	default:
-		res, err = c.Get(context.Background(), path, nil)
+		res, err = c.Get(ctx, path, nil)
 	}
 
 	if err != nil {
@@ -111,17 +135,46 @@ func (c *Client) getIssueRaw(key, ver string) (string, error) {
 	return b.String(), nil
 }
 
+// issueParams builds optional query params for get issue requests.
+func issueParams(opts filter.Collection) string {
+	params := make(url.Values)
+
+	if fields := opts.GetStrings(issue.KeyIssueFields); len(fields) > 0 {
+		params.Set("fields", strings.Join(fields, ","))
+	}
+	if expand := opts.GetStrings(issue.KeyIssueExpand); len(expand) > 0 {
+		params.Set("expand", strings.Join(expand, ","))
+	}
+	if props := opts.GetStrings(issue.KeyIssueProperties); len(props) > 0 {
+		params.Set("properties", strings.Join(props, ","))
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "?" + params.Encode()
+}
+
 // AssignIssue assigns issue to the user using v3 version of the PUT /issue/{key}/assignee endpoint.
 func (c *Client) AssignIssue(key, assignee string) error {
-	return c.assignIssue(key, assignee, apiVersion3)
+	return c.AssignIssueContext(context.Background(), key, assignee)
+}
+
+// AssignIssueContext is the same as AssignIssue but accepts a context.
+func (c *Client) AssignIssueContext(ctx context.Context, key, assignee string) error {
+	return c.assignIssue(ctx, key, assignee, apiVersion3)
 }
 
 // AssignIssueV2 assigns issue to the user using v2 version of the PUT /issue/{key}/assignee endpoint.
 func (c *Client) AssignIssueV2(key, assignee string) error {
-	return c.assignIssue(key, assignee, apiVersion2)
+	return c.AssignIssueV2Context(context.Background(), key, assignee)
+}
+
+// AssignIssueV2Context is the same as AssignIssueV2 but accepts a context.
+func (c *Client) AssignIssueV2Context(ctx context.Context, key, assignee string) error {
+	return c.assignIssue(ctx, key, assignee, apiVersion2)
 }
 
-func (c *Client) assignIssue(key, assignee, ver string) error {
+func (c *Client) assignIssue(ctx context.Context, key, assignee, ver string) error {
 	path := fmt.Sprintf("/issue/%s/assignee", key)
 
 	aid := new(string)
@@ -150,7 +203,7 @@ func (c *Client) assignIssue(key, assignee, ver string) error {
 		if err != nil {
 			return err
 		}
-		res, err = c.PutV2(context.Background(), path, body, Header{
+		res, err = c.PutV2(ctx, path, body, Header{
 			"Accept":       "application/json",
 			"Content-Type": "application/json",
 		})
@@ -163,7 +216,7 @@ func (c *Client) assignIssue(key, assignee, ver string) error {
 		if err != nil {
 			return err
 		}
-		res, err = c.Put(context.Background(), path, body, Header{
+		res, err = c.Put(ctx, path, body, Header{
 			"Accept":       "application/json",
 			"Content-Type": "application/json",
 		})
@@ -185,7 +238,12 @@ func (c *Client) assignIssue(key, assignee, ver string) error {
 
 // GetIssueLinkTypes fetches issue link types using GET /issueLinkType endpoint.
 func (c *Client) GetIssueLinkTypes() ([]*IssueLinkType, error) {
-	res, err := c.GetV2(context.Background(), "/issueLinkType", nil)
+	return c.GetIssueLinkTypesContext(context.Background())
+}
+
+// GetIssueLinkTypesContext is the same as GetIssueLinkTypes but accepts a context.
+func (c *Client) GetIssueLinkTypesContext(ctx context.Context) ([]*IssueLinkType, error) {
+	res, err := c.GetV2(ctx, "/issueLinkType", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -223,6 +281,11 @@ type linkRequest struct {
 
 // LinkIssue connects issues to the given link type using POST /issueLink endpoint.
 func (c *Client) LinkIssue(inwardIssue, outwardIssue, linkType string) error {
+	return c.LinkIssueContext(context.Background(), inwardIssue, outwardIssue, linkType)
+}
+
+// LinkIssueContext is the same as LinkIssue but accepts a context.
+func (c *Client) LinkIssueContext(ctx context.Context, inwardIssue, outwardIssue, linkType string) error {
 	body, err := json.Marshal(linkRequest{
 		InwardIssue: struct {
 			Key string `json:"key"`
@@ -238,7 +301,7 @@ func (c *Client) LinkIssue(inwardIssue, outwardIssue, linkType string) error {
 		return err
 	}
 
-	res, err := c.PostV2(context.Background(), "/issueLink", body, Header{
+	res, err := c.PostV2(ctx, "/issueLink", body, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -258,8 +321,13 @@ func (c *Client) LinkIssue(inwardIssue, outwardIssue, linkType string) error {
 
 // UnlinkIssue disconnects two issues using DELETE /issueLink/{linkId} endpoint.
 func (c *Client) UnlinkIssue(linkID string) error {
+	return c.UnlinkIssueContext(context.Background(), linkID)
+}
+
+// UnlinkIssueContext is the same as UnlinkIssue but accepts a context.
+func (c *Client) UnlinkIssueContext(ctx context.Context, linkID string) error {
 	deleteLinkURL := fmt.Sprintf("/issueLink/%s", linkID)
-	res, err := c.DeleteV2(context.Background(), deleteLinkURL, Header{
+	res, err := c.DeleteV2(ctx, deleteLinkURL, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -279,7 +347,12 @@ func (c *Client) UnlinkIssue(linkID string) error {
 
 // GetLinkID gets linkID between two issues.
 func (c *Client) GetLinkID(inwardIssue, outwardIssue string) (string, error) {
-	i, err := c.GetIssueV2(inwardIssue)
+	return c.GetLinkIDContext(context.Background(), inwardIssue, outwardIssue)
+}
+
+// GetLinkIDContext is the same as GetLinkID but accepts a context.
+func (c *Client) GetLinkIDContext(ctx context.Context, inwardIssue, outwardIssue string) (string, error) {
+	i, err := c.GetIssueV2Context(ctx, inwardIssue)
 	if err != nil {
 		return "", err
 	}
@@ -305,22 +378,66 @@ type issueCommentProperty struct {
 	Value issueCommentPropertyValue `json:"value"`
 }
 type issueCommentRequest struct {
-	Body       string                 `json:"body"`
+	Body       any                    `json:"body"`
 	Properties []issueCommentProperty `json:"properties"`
 }
 
-// AddIssueComment adds comment to an issue using POST /issue/{key}/comment endpoint.
+// AddIssueComment adds comment to an issue using v2 version of the POST /issue/{key}/comment endpoint.
+// The comment is converted from CommonMark to Jira wiki markup.
 func (c *Client) AddIssueComment(key, comment string, internal bool) error {
-	body, err := json.Marshal(&issueCommentRequest{Body: md.ToJiraMD(comment), Properties: []issueCommentProperty{{Key: "sd.public.comment", Value: issueCommentPropertyValue{Internal: internal}}}})
+	return c.AddIssueCommentContext(context.Background(), key, comment, internal)
+}
+
+// AddIssueCommentContext is the same as AddIssueComment but accepts a context.
+func (c *Client) AddIssueCommentContext(ctx context.Context, key, comment string, internal bool) error {
+	return c.addIssueComment(ctx, key, comment, internal, apiVersion2)
+}
+
+// AddIssueCommentV3 adds comment to an issue using v3 version of the POST /issue/{key}/comment endpoint.
+// The comment is converted from CommonMark to ADF.
+func (c *Client) AddIssueCommentV3(key, comment string, internal bool) error {
+	return c.AddIssueCommentV3Context(context.Background(), key, comment, internal)
+}
+
+// AddIssueCommentV3Context is the same as AddIssueCommentV3 but accepts a context.
+func (c *Client) AddIssueCommentV3Context(ctx context.Context, key, comment string, internal bool) error {
+	return c.addIssueComment(ctx, key, comment, internal, apiVersion3)
+}
+
+func (c *Client) addIssueComment(ctx context.Context, key, comment string, internal bool, ver string) error {
+	data := issueCommentRequest{
+		Properties: []issueCommentProperty{{Key: "sd.public.comment", Value: issueCommentPropertyValue{Internal: internal}}},
+	}
+	if ver == apiVersion2 {
+		data.Body = md.ToJiraMD(comment)
+	} else {
+		doc := adf.FromMarkdown(comment)
+		if err := doc.Validate(); err != nil {
+			return fmt.Errorf("%w: comment: %w", ErrValidation, err)
+		}
+		data.Body = doc
+	}
+
+	body, err := json.Marshal(&data)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf("/issue/%s/comment", key)
-	res, err := c.PostV2(context.Background(), path, body, Header{
+	header := Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
-	})
+	}
+
+	var res *http.Response
+
+	switch ver {
+	case apiVersion2:
+		res, err = c.PostV2(ctx, path, body, header)
+	default:
+		res, err = c.Post(ctx, path, body, header)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -344,6 +461,11 @@ type issueWorklogRequest struct {
 // AddIssueWorklog adds worklog to an issue using POST /issue/{key}/worklog endpoint.
 // Leave param `started` empty to use the server's current datetime as start date.
 func (c *Client) AddIssueWorklog(key, started, timeSpent, comment, newEstimate string) error {
+	return c.AddIssueWorklogContext(context.Background(), key, started, timeSpent, comment, newEstimate)
+}
+
+// AddIssueWorklogContext is the same as AddIssueWorklog but accepts a context.
+func (c *Client) AddIssueWorklogContext(ctx context.Context, key, started, timeSpent, comment, newEstimate string) error {
 	worklogReq := issueWorklogRequest{
 		TimeSpent: timeSpent,
 		Comment:   md.ToJiraMD(comment),
@@ -360,7 +482,7 @@ func (c *Client) AddIssueWorklog(key, started, timeSpent, comment, newEstimate s
 	if newEstimate != "" {
 		path = fmt.Sprintf("%s?adjustEstimate=new&newEstimate=%s", path, newEstimate)
 	}
-	res, err := c.PostV2(context.Background(), path, body, Header{
+	res, err := c.PostV2(ctx, path, body, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -380,7 +502,12 @@ func (c *Client) AddIssueWorklog(key, started, timeSpent, comment, newEstimate s
 
 // GetField gets all fields configured for a Jira instance using GET /field endpiont.
 func (c *Client) GetField() ([]*Field, error) {
-	res, err := c.GetV2(context.Background(), "/field", Header{
+	return c.GetFieldContext(context.Background())
+}
+
+// GetFieldContext is the same as GetField but accepts a context.
+func (c *Client) GetFieldContext(ctx context.Context) ([]*Field, error) {
+	res, err := c.GetV2(ctx, "/field", Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -430,6 +557,11 @@ type remotelinkRequest struct {
 
 // RemoteLinkIssue adds a remote link to an issue using POST /issue/{issueId}/remotelink endpoint.
 func (c *Client) RemoteLinkIssue(issueID, title, url string) error {
+	return c.RemoteLinkIssueContext(context.Background(), issueID, title, url)
+}
+
+// RemoteLinkIssueContext is the same as RemoteLinkIssue but accepts a context.
+func (c *Client) RemoteLinkIssueContext(ctx context.Context, issueID, title, url string) error {
 	body, err := json.Marshal(remotelinkRequest{
 		RemoteObject: struct {
 			URL   string `json:"url"`
@@ -442,7 +574,7 @@ func (c *Client) RemoteLinkIssue(issueID, title, url string) error {
 
 	path := fmt.Sprintf("/issue/%s/remotelink", issueID)
 
-	res, err := c.PostV2(context.Background(), path, body, Header{
+	res, err := c.PostV2(ctx, path, body, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -462,15 +594,25 @@ func (c *Client) RemoteLinkIssue(issueID, title, url string) error {
 
 // WatchIssue adds user as a watcher using v2 version of the POST /issue/{key}/watchers endpoint.
 func (c *Client) WatchIssue(key, watcher string) error {
-	return c.watchIssue(key, watcher, apiVersion3)
+	return c.WatchIssueContext(context.Background(), key, watcher)
+}
+
+// WatchIssueContext is the same as WatchIssue but accepts a context.
+func (c *Client) WatchIssueContext(ctx context.Context, key, watcher string) error {
+	return c.watchIssue(ctx, key, watcher, apiVersion3)
 }
 
 // WatchIssueV2 adds user as a watcher using using v2 version of the POST /issue/{key}/watchers endpoint.
 func (c *Client) WatchIssueV2(key, watcher string) error {
-	return c.watchIssue(key, watcher, apiVersion2)
+	return c.WatchIssueV2Context(context.Background(), key, watcher)
+}
+
+// WatchIssueV2Context is the same as WatchIssueV2 but accepts a context.
+func (c *Client) WatchIssueV2Context(ctx context.Context, key, watcher string) error {
+	return c.watchIssue(ctx, key, watcher, apiVersion2)
 }
 
-func (c *Client) watchIssue(key, watcher, ver string) error {
+func (c *Client) watchIssue(ctx context.Context, key, watcher, ver string) error {
 	path := fmt.Sprintf("/issue/%s/watchers", key)
 
 	var (
@@ -491,9 +633,9 @@ func (c *Client) watchIssue(key, watcher, ver string) error {
 
 	switch ver {
 	case apiVersion2:
-		res, err = c.PostV2(context.Background(), path, body, header)
+		res, err = c.PostV2(ctx, path, body, header)
 	default:
-		res, err = c.Post(context.Background(), path, body, header)
+		res, err = c.Post(ctx, path, body, header)
 	}
 
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/eliziario/jira-lib/pkg/adf"
+	"github.com/eliziario/jira-lib/pkg/jira/filter/issue"
 )
 
 const (
@@ -207,6 +209,40 @@ func TestGetIssueV2(t *testing.T) {
 	assert.Error(t, &ErrUnexpectedResponse{}, err)
 }
 
+func TestGetIssueWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/TEST-1", r.URL.Path)
+		assert.Equal(t, url.Values{
+			"fields":     []string{"summary,status"},
+			"expand":     []string{"changelog,renderedFields"},
+			"properties": []string{"prop1"},
+		}, r.URL.Query())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"key": "TEST-1",
+			"fields": {"summary": "Issue summary"},
+			"renderedFields": {"description": "<p>Rendered</p>"},
+			"changelog": {"total": 1, "histories": [{"id": "1", "items": [{"field": "status", "fromString": "To Do", "toString": "Done"}]}]}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	actual, err := client.GetIssueV2(
+		"TEST-1",
+		issue.NewFieldsFilter("summary", "status"),
+		issue.NewExpandFilter(ExpandChangelog, ExpandRenderedFields),
+		issue.NewPropertiesFilter("prop1"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "Issue summary", actual.Fields.Summary)
+	assert.Equal(t, "<p>Rendered</p>", actual.RenderedFields["description"])
+	assert.Equal(t, 1, actual.Changelog.Total)
+	assert.Equal(t, "Done", actual.Changelog.Histories[0].Items[0].ToString)
+}
+
 func TestGetIssueRaw(t *testing.T) {
 	cases := []struct {
 		title              string
@@ -554,6 +590,43 @@ func TestAddIssueComment(t *testing.T) {
 	assert.Error(t, &ErrUnexpectedResponse{}, err)
 }
 
+func TestAddIssueCommentV3(t *testing.T) {
+	var unexpectedStatusCode bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/rest/api/3/issue/TEST-1/comment", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Accept"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		actualBody := new(strings.Builder)
+		_, _ = io.Copy(actualBody, r.Body)
+
+		expectedBody := `{"body":{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"a "},{"type":"text","text":"comment","marks":[{"type":"strong"}]}]}]},` +
+			`"properties":[{"key":"sd.public.comment","value":{"internal":false}}]}`
+
+		assert.JSONEq(t, expectedBody, actualBody.String())
+
+		if unexpectedStatusCode {
+			w.WriteHeader(400)
+		} else {
+			w.WriteHeader(201)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	err := client.AddIssueCommentV3("TEST-1", "a **comment**", false)
+	assert.NoError(t, err)
+
+	unexpectedStatusCode = true
+
+	err = client.AddIssueCommentV3("TEST-1", "a **comment**", false)
+	assert.Error(t, &ErrUnexpectedResponse{}, err)
+}
+
 func TestAddIssueWorklog(t *testing.T) {
 	var unexpectedStatusCode bool
 
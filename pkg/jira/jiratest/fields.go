@@ -0,0 +1,279 @@
+package jiratest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+// setField sets a field of the issue to a value of a create or edit request.
+func (s *Server) setField(iss *Issue, id string, v any) error {
+	p := s.findProject(iss.Project)
+
+	switch id {
+	case "summary":
+		str, ok := v.(string)
+		if !ok && v != nil {
+			return fmt.Errorf("Operation value must be a string")
+		}
+		iss.Summary = str
+	case "description":
+		iss.Description = v
+	case "issuetype":
+		it := p.issueType(nameOf(v))
+		if it == nil {
+			return fmt.Errorf("valid issue type is required")
+		}
+		iss.Type = it.Name
+	case "parent":
+		if v == nil {
+			iss.Parent = ""
+			return nil
+		}
+		ref := nameOf(v)
+		if m, ok := v.(map[string]any); ok && m["set"] == jira.AssigneeNone {
+			iss.Parent = ""
+			return nil
+		}
+		if ref == "" {
+			return nil
+		}
+		parent := s.findIssue(ref)
+		if parent == nil || parent.Key == iss.Key {
+			return fmt.Errorf("Could not find issue by id or key.")
+		}
+		iss.Parent = parent.Key
+	case "priority":
+		name := nameOf(v)
+		if name == "" {
+			return fmt.Errorf("Priority name is required")
+		}
+		iss.Priority = name
+	case "assignee", "reporter":
+		ref, err := s.userRef(p, id, v)
+		if err != nil {
+			return err
+		}
+		if id == "assignee" {
+			iss.Assignee = ref
+		} else {
+			iss.Reporter = ref
+		}
+	case "labels":
+		labels := namesOf(v)
+		if slices.ContainsFunc(labels, func(l string) bool { return strings.ContainsAny(l, " \t") }) {
+			return fmt.Errorf("The label can't contain spaces.")
+		}
+		iss.Labels = labels
+	case "components", "fixVersions", "versions":
+		names := namesOf(v)
+		if err := s.checkNames(p, id, names); err != nil {
+			return err
+		}
+		switch id {
+		case "components":
+			iss.Components = names
+		case "fixVersions":
+			iss.FixVersions = names
+		default:
+			iss.AffectsVersions = names
+		}
+	case "duedate", "environment", "timetracking":
+		setCustom(iss, id, v)
+	default:
+		f := s.findField(id)
+		if f == nil {
+			return fmt.Errorf("Field '%s' cannot be set. It is not on the appropriate screen, or unknown.", id)
+		}
+
+		switch {
+		case strings.EqualFold(f.Name, fieldEpicLink):
+			parent := s.findIssue(nameOf(v))
+			if v != nil && (parent == nil || !s.isEpic(parent)) {
+				return fmt.Errorf("The issue %s is not an epic.", nameOf(v))
+			}
+			return s.setField(iss, "parent", v)
+		case strings.EqualFold(f.Name, fieldSprint):
+			if ids := namesOf(v); len(ids) > 0 {
+				id, _ := strconv.Atoi(ids[len(ids)-1])
+				if s.findSprint(id) == nil {
+					return fmt.Errorf("Sprint with id %s does not exist.", ids[len(ids)-1])
+				}
+				iss.Sprint = id
+			} else {
+				iss.Sprint = 0
+			}
+		default:
+			setCustom(iss, id, v)
+		}
+	}
+
+	return nil
+}
+
+// userRef resolves the user of an assignee or reporter field. A nil user
+// clears the field and -1 sets it to the project lead.
+func (s *Server) userRef(p *Project, id string, v any) (string, error) {
+	if m, ok := v.(map[string]any); ok {
+		v = nil
+		for _, k := range []string{"accountId", "name", "key"} {
+			if val, ok := m[k]; ok {
+				v = val
+				break
+			}
+		}
+	}
+	if v == nil {
+		return "", nil
+	}
+
+	ref := nameOf(v)
+	if ref == "-1" && id == "assignee" {
+		return p.Lead, nil
+	}
+	if s.findUser(ref) == nil {
+		return "", fmt.Errorf("User '%s' does not exist.", ref)
+	}
+	return ref, nil
+}
+
+// checkNames checks the components or versions exist in the project.
+func (s *Server) checkNames(p *Project, id string, names []string) error {
+	for _, name := range names {
+		if id == "components" {
+			if !slices.ContainsFunc(p.Components, func(c string) bool { return strings.EqualFold(c, name) }) {
+				return fmt.Errorf("Component name '%s' is not valid", name)
+			}
+			continue
+		}
+		if !slices.ContainsFunc(p.Versions, func(v jira.ProjectVersion) bool { return strings.EqualFold(v.Name, name) }) {
+			return fmt.Errorf("Version name '%s' is not valid", name)
+		}
+	}
+	return nil
+}
+
+// applyUpdate applies the set, add and remove operations of an edit request.
+// Errors are added to errs by field.
+func (s *Server) applyUpdate(iss *Issue, update map[string][]map[string]any, errs map[string]string) {
+	for id, ops := range update {
+		for _, op := range ops {
+			for name, v := range op {
+				var err error
+				switch name {
+				case "set":
+					err = s.setField(iss, id, v)
+				case "add", "remove":
+					err = s.updateList(iss, id, name == "add", v)
+				default:
+					err = fmt.Errorf("Unsupported operation '%s'.", name)
+				}
+				if err != nil {
+					errs[id] = err.Error()
+				}
+			}
+		}
+	}
+}
+
+// updateList adds a value to a list field or removes it.
+func (s *Server) updateList(iss *Issue, id string, add bool, v any) error {
+	name := nameOf(v)
+
+	var list *[]string
+	switch id {
+	case "labels":
+		list = &iss.Labels
+	case "components", "fixVersions", "versions":
+		if add {
+			if err := s.checkNames(s.findProject(iss.Project), id, []string{name}); err != nil {
+				return err
+			}
+		}
+		switch id {
+		case "components":
+			list = &iss.Components
+		case "fixVersions":
+			list = &iss.FixVersions
+		default:
+			list = &iss.AffectsVersions
+		}
+	default:
+		if s.findField(id) == nil {
+			return fmt.Errorf("Field '%s' cannot be set. It is not on the appropriate screen, or unknown.", id)
+		}
+		items, _ := iss.CustomFields[id].([]any)
+		items = slices.DeleteFunc(slices.Clone(items), func(item any) bool { return strings.EqualFold(nameOf(item), name) })
+		if add {
+			items = append(items, v)
+		}
+		setCustom(iss, id, items)
+		return nil
+	}
+
+	*list = slices.DeleteFunc(*list, func(item string) bool { return strings.EqualFold(item, name) })
+	if add {
+		*list = append(*list, name)
+	}
+	return nil
+}
+
+func setCustom(iss *Issue, id string, v any) {
+	if v == nil {
+		delete(iss.CustomFields, id)
+		return
+	}
+	if iss.CustomFields == nil {
+		iss.CustomFields = make(map[string]any)
+	}
+	iss.CustomFields[id] = v
+}
+
+// nameOf returns the identifying value of a field value, eg: the name of
+// a priority, the key of an issue or the value of an option.
+func nameOf(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case map[string]any:
+		for _, k := range []string{"name", "key", "value", "accountId", "id"} {
+			if s, ok := val[k].(string); ok && s != "" {
+				return s
+			}
+			if f, ok := val[k].(float64); ok {
+				return strconv.FormatFloat(f, 'f', -1, 64)
+			}
+		}
+	}
+	return ""
+}
+
+// namesOf returns the identifying values of a list field value.
+func namesOf(v any) []string {
+	var items []any
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case []any:
+		items = val
+	case []string:
+		return slices.Clone(val)
+	default:
+		items = []any{v}
+	}
+
+	var out []string
+	for _, item := range items {
+		if name := nameOf(item); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
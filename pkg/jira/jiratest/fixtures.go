@@ -0,0 +1,254 @@
+package jiratest
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+// Fixtures hold the data a server is seeded with.
+//
+// Users are referenced by account id, name, email or display name and
+// issues by key. Lists are appended to the data of the server, except link
+// types, fields and the workflow which replace the defaults when set.
+type Fixtures struct {
+	// DeploymentType is Cloud, Server or DataCenter. Defaults to Cloud.
+	DeploymentType string `json:"deploymentType,omitempty"`
+	// Version of the server. Defaults to 1001.0.0 on cloud and 9.12.0 otherwise.
+	Version string `json:"version,omitempty"`
+	// Myself references the authenticated user. Defaults to the first user.
+	Myself string `json:"myself,omitempty"`
+
+	Users    []User        `json:"users,omitempty"`
+	Projects []Project     `json:"projects,omitempty"`
+	Issues   []Issue       `json:"issues,omitempty"`
+	Links    []Link        `json:"links,omitempty"`
+	Boards   []Board       `json:"boards,omitempty"`
+	Sprints  []jira.Sprint `json:"sprints,omitempty"`
+
+	// LinkTypes defaults to Blocks, Cloners, Duplicate and Relates.
+	LinkTypes []jira.IssueLinkType `json:"linkTypes,omitempty"`
+	// Fields are the custom fields. System fields are always defined.
+	// Defaults to Epic Link, Sprint and Story Points.
+	Fields []jira.Field `json:"fields,omitempty"`
+	// Workflow holds the transitions shared by all issues. New issues get
+	// the status the first transition leads to. Defaults to To Do, In Progress
+	// and Done.
+	Workflow []Transition `json:"workflow,omitempty"`
+}
+
+// User is a Jira user.
+type User struct {
+	AccountID   string `json:"accountId"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email,omitempty"`
+	Inactive    bool   `json:"inactive,omitempty"`
+}
+
+// Project is a Jira project.
+type Project struct {
+	ID   string `json:"id,omitempty"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	// Lead references the project lead, who is the default assignee.
+	Lead string `json:"lead,omitempty"`
+	// Style is classic or next-gen. Defaults to classic.
+	Style string `json:"style,omitempty"`
+	// IssueTypes default to Epic, Story, Task, Bug and Sub-task.
+	IssueTypes []jira.IssueType      `json:"issueTypes,omitempty"`
+	Versions   []jira.ProjectVersion `json:"versions,omitempty"`
+	Components []string              `json:"components,omitempty"`
+}
+
+// Issue is a Jira issue.
+type Issue struct {
+	ID string `json:"id,omitempty"`
+	// Key is assigned in sequence within the project if empty.
+	Key     string `json:"key,omitempty"`
+	Project string `json:"project"`
+	// Type defaults to Task.
+	Type        string `json:"type,omitempty"`
+	Summary     string `json:"summary"`
+	Description any    `json:"description,omitempty"`
+	// Status defaults to the initial status of the workflow.
+	Status     string `json:"status,omitempty"`
+	Resolution string `json:"resolution,omitempty"`
+	// Priority defaults to Medium.
+	Priority        string   `json:"priority,omitempty"`
+	Assignee        string   `json:"assignee,omitempty"`
+	Reporter        string   `json:"reporter,omitempty"`
+	Labels          []string `json:"labels,omitempty"`
+	Components      []string `json:"components,omitempty"`
+	FixVersions     []string `json:"fixVersions,omitempty"`
+	AffectsVersions []string `json:"affectsVersions,omitempty"`
+	// Parent is the key of the parent issue, the epic or the parent of a sub-task.
+	Parent string `json:"parent,omitempty"`
+	// Sprint is the id of the sprint the issue belongs to.
+	Sprint   int      `json:"sprint,omitempty"`
+	Watchers []string `json:"watchers,omitempty"`
+
+	Comments    []Comment    `json:"comments,omitempty"`
+	Worklogs    []Worklog    `json:"worklogs,omitempty"`
+	RemoteLinks []RemoteLink `json:"remoteLinks,omitempty"`
+	Changelog   []History    `json:"changelog,omitempty"`
+
+	// CustomFields are indexed by field id, eg: customfield_10016.
+	CustomFields map[string]any `json:"customFields,omitempty"`
+
+	// Created and Updated default to the time the issue is seeded.
+	Created time.Time `json:"created,omitempty"`
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// Comment is an issue comment.
+type Comment struct {
+	ID       string    `json:"id,omitempty"`
+	Author   string    `json:"author,omitempty"`
+	Body     any       `json:"body"`
+	Internal bool      `json:"internal,omitempty"`
+	Created  time.Time `json:"created,omitempty"`
+}
+
+// Worklog is time logged on an issue.
+type Worklog struct {
+	ID        string    `json:"id,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	TimeSpent string    `json:"timeSpent"`
+	Comment   string    `json:"comment,omitempty"`
+	Started   time.Time `json:"started,omitempty"`
+}
+
+// RemoteLink is a link from an issue to a web page.
+type RemoteLink struct {
+	ID    string `json:"id,omitempty"`
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// History is a group of changes made to an issue at once.
+type History struct {
+	ID      string    `json:"id,omitempty"`
+	Author  string    `json:"author,omitempty"`
+	Created time.Time `json:"created,omitempty"`
+	Items   []Change  `json:"items"`
+}
+
+// Change is a change of a single field.
+type Change struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+}
+
+// Link is a link between two issues, eg: inward blocks outward.
+type Link struct {
+	ID string `json:"id,omitempty"`
+	// Type is the name of the link type.
+	Type    string `json:"type"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// Board is an agile board.
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// Type is scrum or kanban. Defaults to scrum.
+	Type    string `json:"type,omitempty"`
+	Project string `json:"project"`
+}
+
+// Transition is a workflow transition.
+type Transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// To is the status the transition leads to.
+	To string `json:"to"`
+	// From are the statuses the transition is available from. Empty means all.
+	From []string `json:"from,omitempty"`
+	// Resolution is set on issues moved by the transition, an empty one clears it.
+	Resolution string `json:"resolution,omitempty"`
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f Fixtures
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("jiratest: parse %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+func defaultIssueTypes() []jira.IssueType {
+	return []jira.IssueType{
+		{ID: "10000", Name: jira.IssueTypeEpic, Handle: jira.IssueTypeEpic},
+		{ID: "10001", Name: "Story", Handle: "Story"},
+		{ID: "10002", Name: "Task", Handle: "Task"},
+		{ID: "10003", Name: "Bug", Handle: "Bug"},
+		{ID: "10004", Name: jira.IssueTypeSubTask, Handle: jira.IssueTypeSubTask, Subtask: true},
+	}
+}
+
+func defaultLinkTypes() []jira.IssueLinkType {
+	return []jira.IssueLinkType{
+		{ID: "10000", Name: "Blocks", Inward: "is blocked by", Outward: "blocks"},
+		{ID: "10001", Name: "Cloners", Inward: "is cloned by", Outward: "clones"},
+		{ID: "10002", Name: "Duplicate", Inward: "is duplicated by", Outward: "duplicates"},
+		{ID: "10003", Name: "Relates", Inward: "relates to", Outward: "relates to"},
+	}
+}
+
+func defaultWorkflow() []Transition {
+	return []Transition{
+		{ID: "11", Name: "To Do", To: "To Do"},
+		{ID: "21", Name: "In Progress", To: "In Progress"},
+		{ID: "31", Name: "Done", To: "Done", Resolution: "Done"},
+	}
+}
+
+func defaultFields() []jira.Field {
+	custom := func(id, name, dataType, items string, customID int) jira.Field {
+		f := jira.Field{ID: id, Name: name, Custom: true}
+		f.Schema.DataType, f.Schema.Items, f.Schema.FieldID = dataType, items, customID
+		return f
+	}
+	return []jira.Field{
+		custom("customfield_10014", fieldEpicLink, "any", "", 10014),
+		custom("customfield_10016", "Story Points", "number", "", 10016),
+		custom("customfield_10020", fieldSprint, "array", "json", 10020),
+	}
+}
+
+// systemFields are the fields every issue has, indexed by id.
+var systemFields = map[string]struct {
+	name, dataType, items string
+}{
+	"summary":      {"Summary", "string", ""},
+	"description":  {"Description", "string", ""},
+	"project":      {"Project", "project", ""},
+	"issuetype":    {"Issue Type", "issuetype", ""},
+	"parent":       {"Parent", "issuelink", ""},
+	"status":       {"Status", "status", ""},
+	"resolution":   {"Resolution", "resolution", ""},
+	"priority":     {"Priority", "priority", ""},
+	"assignee":     {"Assignee", "user", ""},
+	"reporter":     {"Reporter", "user", ""},
+	"labels":       {"Labels", "array", "string"},
+	"components":   {"Components", "array", "component"},
+	"fixVersions":  {"Fix versions", "array", "version"},
+	"versions":     {"Affects versions", "array", "version"},
+	"timetracking": {"Time tracking", "timetracking", ""},
+	"duedate":      {"Due date", "date", ""},
+	"environment":  {"Environment", "string", ""},
+	"created":      {"Created", "datetime", ""},
+	"updated":      {"Updated", "datetime", ""},
+}
@@ -0,0 +1,1051 @@
+package jiratest
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+	"github.com/eliziario/jira-lib/pkg/jql"
+)
+
+const defaultMaxResults = 50
+
+// errBadRequest carries validation errors of a request.
+type errBadRequest struct {
+	messages []string
+	fields   map[string]string
+}
+
+func (e *errBadRequest) Error() string {
+	return strings.Join(e.messages, ", ")
+}
+
+func badRequest(format string, args ...any) *errBadRequest {
+	return &errBadRequest{messages: []string{fmt.Sprintf(format, args...)}}
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	api := func(pattern string, h http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" /rest/api/{version}"+path, s.handle(func(w http.ResponseWriter, r *http.Request) {
+			if v := r.PathValue("version"); v != "2" && v != "3" {
+				http.NotFound(w, r)
+				return
+			}
+			h(w, r)
+		}))
+	}
+	agile := func(pattern string, h http.HandlerFunc) {
+		method, path, _ := strings.Cut(pattern, " ")
+		mux.HandleFunc(method+" /rest/agile/1.0"+path, s.handle(h))
+	}
+
+	api("GET /serverInfo", s.handleServerInfo)
+	api("GET /myself", s.handleMyself)
+	api("GET /field", s.handleFields)
+	api("GET /project", s.handleProjects)
+	api("GET /project/{project}/versions", s.handleVersions)
+	api("GET /user/assignable/search", s.handleAssignable)
+	api("GET /issueLinkType", s.handleLinkTypes)
+	api("POST /issueLink", s.handleCreateLink)
+	api("DELETE /issueLink/{id}", s.handleDeleteLink)
+	api("GET /issue/createmeta", s.handleCreateMeta)
+	api("GET /issue/createmeta/{project}/issuetypes", s.handleCreateMetaIssueTypes)
+	api("GET /issue/createmeta/{project}/issuetypes/{type}", s.handleCreateMetaFields)
+	api("POST /issue", s.handleCreateIssue)
+	api("GET /issue/{key}", s.handleGetIssue)
+	api("PUT /issue/{key}", s.handleEditIssue)
+	api("DELETE /issue/{key}", s.handleDeleteIssue)
+	api("PUT /issue/{key}/assignee", s.handleAssign)
+	api("GET /issue/{key}/transitions", s.handleTransitions)
+	api("POST /issue/{key}/transitions", s.handleTransition)
+	api("POST /issue/{key}/comment", s.handleComment)
+	api("POST /issue/{key}/worklog", s.handleWorklog)
+	api("POST /issue/{key}/remotelink", s.handleRemoteLink)
+	api("POST /issue/{key}/watchers", s.handleWatch)
+	api("GET /search", s.handleSearch)
+	api("GET /search/jql", s.handleSearchJQL)
+	api("POST /search/approximate-count", s.handleCount)
+
+	agile("GET /board", s.handleBoards)
+	agile("GET /board/{id}/sprint", s.handleBoardSprints)
+	agile("GET /sprint/{id}", s.handleGetSprint)
+	agile("PUT /sprint/{id}", s.handleUpdateSprint)
+	agile("GET /sprint/{id}/issue", s.handleSprintIssues)
+	agile("POST /sprint/{id}/issue", s.handleMoveToSprint)
+	agile("GET /epic/{key}/issue", s.handleEpicIssues)
+	agile("POST /epic/{key}/issue", s.handleMoveToEpic)
+
+	return mux
+}
+
+// handle serializes the requests, so that handlers can access the data freely.
+func (s *Server) handle(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		h(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format used by Jira.
+func writeError(w http.ResponseWriter, status int, err error) {
+	body := jsonObject{"errorMessages": []string{}, "errors": map[string]string{}}
+	if e, ok := err.(*errBadRequest); ok {
+		if e.messages != nil {
+			body["errorMessages"] = e.messages
+		}
+		if e.fields != nil {
+			body["errors"] = e.fields
+		}
+	} else {
+		body["errorMessages"] = []string{err.Error()}
+	}
+	writeJSON(w, status, body)
+}
+
+func notFound(w http.ResponseWriter, format string, args ...any) {
+	writeError(w, http.StatusNotFound, fmt.Errorf(format, args...))
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	data, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(data, v)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, badRequest("Invalid request payload. Refer to the REST API documentation and try again."))
+		return false
+	}
+	return true
+}
+
+func intParam(r *http.Request, name string, def int) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil && n >= 0 {
+		return n
+	}
+	return def
+}
+
+func listParam(r *http.Request, name string) []string {
+	var out []string
+	for _, v := range strings.Split(r.URL.Query().Get(name), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// page returns the items in [startAt, startAt+maxResults) and whether it's the last page.
+func page[T any](items []T, startAt, maxResults int) ([]T, bool) {
+	start := min(startAt, len(items))
+	end := min(start+maxResults, len(items))
+	return items[start:end], end >= len(items)
+}
+
+func (s *Server) handleServerInfo(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, s.info)
+}
+
+func (s *Server) handleMyself(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, userJSON(s.me()))
+}
+
+func (s *Server) handleFields(w http.ResponseWriter, _ *http.Request) {
+	ids := make([]string, 0, len(systemFields))
+	for id := range systemFields {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	out := make([]jira.Field, 0, len(ids)+len(s.fields))
+	for _, id := range ids {
+		f := jira.Field{ID: id, Name: systemFields[id].name}
+		f.Schema.DataType, f.Schema.Items = systemFields[id].dataType, systemFields[id].items
+		out = append(out, f)
+	}
+	out = append(out, s.fields...)
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleProjects(w http.ResponseWriter, _ *http.Request) {
+	out := make([]jsonObject, 0, len(s.projects))
+	for _, p := range s.projects {
+		out = append(out, s.renderProject(p))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleVersions(w http.ResponseWriter, r *http.Request) {
+	p := s.findProject(r.PathValue("project"))
+	if p == nil {
+		notFound(w, "No project could be found with key '%s'.", r.PathValue("project"))
+		return
+	}
+	writeJSON(w, http.StatusOK, append([]jira.ProjectVersion{}, p.Versions...))
+}
+
+func (s *Server) handleAssignable(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if key := q.Get("project"); key != "" && s.findProject(key) == nil {
+		notFound(w, "No project could be found with key '%s'.", key)
+		return
+	}
+
+	query := strings.ToLower(q.Get("query"))
+	var users []jsonObject
+	for _, u := range s.users {
+		switch {
+		case u.Inactive:
+		case q.Get("accountId") != "" && u.AccountID != q.Get("accountId"):
+		case q.Get("username") != "" && !strings.EqualFold(u.Name, q.Get("username")):
+		case query != "" && !strings.Contains(strings.ToLower(u.DisplayName+"\n"+u.Email+"\n"+u.Name), query):
+		default:
+			users = append(users, userJSON(u))
+		}
+	}
+
+	users, _ = page(users, intParam(r, "startAt", 0), intParam(r, "maxResults", defaultMaxResults))
+	writeJSON(w, http.StatusOK, append([]jsonObject{}, users...))
+}
+
+func (s *Server) handleLinkTypes(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, jsonObject{"issueLinkTypes": s.linkTypes})
+}
+
+func (s *Server) handleCreateLink(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Type struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"type"`
+		InwardIssue  struct{ Key, ID string } `json:"inwardIssue"`
+		OutwardIssue struct{ Key, ID string } `json:"outwardIssue"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	lt := s.findLinkType(cmp.Or(req.Type.Name, req.Type.ID))
+	if lt == nil {
+		notFound(w, "No issue link type with name '%s' found.", req.Type.Name)
+		return
+	}
+	inward := s.findIssue(cmp.Or(req.InwardIssue.Key, req.InwardIssue.ID))
+	outward := s.findIssue(cmp.Or(req.OutwardIssue.Key, req.OutwardIssue.ID))
+	if inward == nil || outward == nil {
+		notFound(w, "Issue Does Not Exist")
+		return
+	}
+
+	s.links = append(s.links, &Link{ID: s.nextID(), Type: lt.Name, Inward: inward.Key, Outward: outward.Key})
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleDeleteLink(w http.ResponseWriter, r *http.Request) {
+	i := slices.IndexFunc(s.links, func(l *Link) bool { return l.ID == r.PathValue("id") })
+	if i < 0 {
+		notFound(w, "No issue link with id '%s' exists.", r.PathValue("id"))
+		return
+	}
+	s.links = slices.Delete(s.links, i, i+1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleCreateMeta(w http.ResponseWriter, r *http.Request) {
+	keys := listParam(r, "projectKeys")
+	names := listParam(r, "issuetypeNames")
+	withFields := strings.Contains(r.URL.Query().Get("expand"), "projects.issuetypes.fields")
+
+	projects := make([]jsonObject, 0)
+	for _, p := range s.projects {
+		if len(keys) > 0 && !slices.ContainsFunc(keys, func(k string) bool { return strings.EqualFold(k, p.Key) }) {
+			continue
+		}
+
+		types := make([]jsonObject, 0, len(p.IssueTypes))
+		for _, it := range p.IssueTypes {
+			if len(names) > 0 && !slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, it.Name) }) {
+				continue
+			}
+			t := jsonObject{"id": it.ID, "name": it.Name, "untranslatedName": it.Handle, "subtask": it.Subtask}
+			if withFields {
+				fields := make(jsonObject)
+				for _, f := range s.renderFieldMeta(it) {
+					fields[f["fieldId"].(string)] = f
+				}
+				t["fields"] = fields
+			}
+			types = append(types, t)
+		}
+		projects = append(projects, jsonObject{"id": p.ID, "key": p.Key, "name": p.Name, "issuetypes": types})
+	}
+
+	writeJSON(w, http.StatusOK, jsonObject{"projects": projects})
+}
+
+func (s *Server) handleCreateMetaIssueTypes(w http.ResponseWriter, r *http.Request) {
+	p := s.findProject(r.PathValue("project"))
+	if p == nil {
+		notFound(w, "No project could be found with key '%s'.", r.PathValue("project"))
+		return
+	}
+
+	types, last := page(p.IssueTypes, intParam(r, "startAt", 0), intParam(r, "maxResults", defaultMaxResults))
+	writeJSON(w, http.StatusOK, jsonObject{
+		"startAt":    intParam(r, "startAt", 0),
+		"maxResults": intParam(r, "maxResults", defaultMaxResults),
+		"total":      len(p.IssueTypes),
+		"isLast":     last,
+		"values":     append([]jira.IssueType{}, types...),
+	})
+}
+
+func (s *Server) handleCreateMetaFields(w http.ResponseWriter, r *http.Request) {
+	p := s.findProject(r.PathValue("project"))
+	if p == nil {
+		notFound(w, "No project could be found with key '%s'.", r.PathValue("project"))
+		return
+	}
+	it := p.issueType(r.PathValue("type"))
+	if it == nil {
+		notFound(w, "Issue type with id '%s' does not exist.", r.PathValue("type"))
+		return
+	}
+
+	fields := s.renderFieldMeta(*it)
+	writeJSON(w, http.StatusOK, jsonObject{
+		"startAt":    0,
+		"maxResults": len(fields),
+		"total":      len(fields),
+		"isLast":     true,
+		"values":     fields,
+	})
+}
+
+type issueRequest struct {
+	Fields map[string]any              `json:"fields"`
+	Update map[string][]map[string]any `json:"update"`
+}
+
+func (s *Server) handleCreateIssue(w http.ResponseWriter, r *http.Request) {
+	var req issueRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	p := s.findProject(nameOf(req.Fields["project"]))
+	if p == nil {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"project": "valid project is required"}})
+		return
+	}
+	it := p.issueType(nameOf(req.Fields["issuetype"]))
+	if it == nil {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"issuetype": "valid issue type is required"}})
+		return
+	}
+
+	iss := Issue{Project: p.Key, Type: it.Name, Reporter: s.me().AccountID}
+	errs := make(map[string]string)
+	for id, v := range req.Fields {
+		if id == "project" || id == "issuetype" {
+			continue
+		}
+		if err := s.setField(&iss, id, v); err != nil {
+			errs[id] = err.Error()
+		}
+	}
+	s.applyUpdate(&iss, req.Update, errs)
+
+	if iss.Summary == "" {
+		errs["summary"] = "You must specify a summary of the issue."
+	}
+	if it.Subtask && iss.Parent == "" {
+		errs["parent"] = "Issue type is a sub-task but parent issue key or id not specified."
+	}
+	if len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: errs})
+		return
+	}
+
+	created, err := s.addIssue(&iss)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, jsonObject{
+		"id":   created.ID,
+		"key":  created.Key,
+		"self": s.issueURL(r.PathValue("version"), created.ID),
+	})
+}
+
+// issue finds the issue of the request or writes a not found error.
+func (s *Server) issue(w http.ResponseWriter, r *http.Request) *Issue {
+	iss := s.findIssue(r.PathValue("key"))
+	if iss == nil {
+		notFound(w, "Issue does not exist or you do not have permission to see it.")
+	}
+	return iss
+}
+
+func (s *Server) handleGetIssue(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+
+	s.history = slices.DeleteFunc(s.history, func(k string) bool { return k == iss.Key })
+	s.history = slices.Insert(s.history, 0, iss.Key)
+
+	writeJSON(w, http.StatusOK, s.renderIssue(iss, r.PathValue("version"), listParam(r, "expand")))
+}
+
+func (s *Server) handleEditIssue(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req issueRequest
+	if !decode(w, r, &req) {
+		return
+	}
+
+	edited := cloneIssue(iss)
+	errs := make(map[string]string)
+	for id, v := range req.Fields {
+		if err := s.setField(edited, id, v); err != nil {
+			errs[id] = err.Error()
+		}
+	}
+	s.applyUpdate(edited, req.Update, errs)
+	if edited.Summary == "" {
+		errs["summary"] = "You must specify a summary of the issue."
+	}
+	if len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: errs})
+		return
+	}
+
+	s.replace(iss, edited)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replace replaces the issue with its edited copy and records the changes.
+func (s *Server) replace(iss, edited *Issue) {
+	before := s.snapshot(iss)
+	*iss = *edited
+	after := s.snapshot(iss)
+
+	var changes []Change
+	for _, field := range []string{"summary", "description", "issuetype", "status", "resolution", "priority",
+		"assignee", "reporter", "labels", "components", "fixVersions", "versions", "parent", "sprint"} {
+		changes = append(changes, Change{Field: field, From: before[field], To: after[field]})
+	}
+	s.record(iss, changes...)
+}
+
+// snapshot returns the values of the fields tracked in the changelog.
+func (s *Server) snapshot(iss *Issue) map[string]string {
+	user := func(ref string) string {
+		if u := s.findUser(ref); u != nil {
+			return u.DisplayName
+		}
+		return ref
+	}
+	sprint := ""
+	if sp := s.findSprint(iss.Sprint); sp != nil {
+		sprint = sp.Name
+	}
+	return map[string]string{
+		"summary":     iss.Summary,
+		"description": plainText(iss.Description),
+		"issuetype":   iss.Type,
+		"status":      iss.Status,
+		"resolution":  iss.Resolution,
+		"priority":    iss.Priority,
+		"assignee":    user(iss.Assignee),
+		"reporter":    user(iss.Reporter),
+		"labels":      strings.Join(iss.Labels, " "),
+		"components":  strings.Join(iss.Components, ", "),
+		"fixVersions": strings.Join(iss.FixVersions, ", "),
+		"versions":    strings.Join(iss.AffectsVersions, ", "),
+		"parent":      iss.Parent,
+		"sprint":      sprint,
+	}
+}
+
+func (s *Server) handleDeleteIssue(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+
+	var subtasks []string
+	for _, sub := range s.issues {
+		if sub.Parent == iss.Key && s.issueType(sub).Subtask {
+			subtasks = append(subtasks, sub.Key)
+		}
+	}
+	if len(subtasks) > 0 && r.URL.Query().Get("deleteSubtasks") != "true" {
+		writeError(w, http.StatusBadRequest, badRequest("The issue has subtasks, set deleteSubtasks to delete them."))
+		return
+	}
+
+	deleted := append(subtasks, iss.Key)
+	s.issues = slices.DeleteFunc(s.issues, func(i *Issue) bool { return slices.Contains(deleted, i.Key) })
+	s.links = slices.DeleteFunc(s.links, func(l *Link) bool {
+		return slices.Contains(deleted, l.Inward) || slices.Contains(deleted, l.Outward)
+	})
+	s.history = slices.DeleteFunc(s.history, func(k string) bool { return slices.Contains(deleted, k) })
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAssign assigns the issue to the user given by accountId, or name
+// on v2. A null user unassigns the issue and -1 assigns the project lead.
+func (s *Server) handleAssign(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req map[string]any
+	if !decode(w, r, &req) {
+		return
+	}
+
+	edited := cloneIssue(iss)
+	if err := s.setField(edited, "assignee", req); err != nil {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"assignee": err.Error()}})
+		return
+	}
+
+	s.replace(iss, edited)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleTransitions(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, jsonObject{"expand": "transitions", "transitions": s.renderTransitions(iss)})
+}
+
+func (s *Server) handleTransition(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req struct {
+		issueRequest
+		Transition struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"transition"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	i := slices.IndexFunc(s.availableTransitions(iss), func(t Transition) bool {
+		return t.ID == req.Transition.ID || req.Transition.ID == "" && strings.EqualFold(t.Name, req.Transition.Name)
+	})
+	if i < 0 {
+		writeError(w, http.StatusBadRequest, badRequest("Transition id '%s' is not valid for this issue.", req.Transition.ID))
+		return
+	}
+	t := s.availableTransitions(iss)[i]
+
+	edited := cloneIssue(iss)
+	edited.Status, edited.Resolution = t.To, t.Resolution
+
+	errs := make(map[string]string)
+	for id, v := range req.Fields {
+		var err error
+		if id == "resolution" {
+			edited.Resolution = nameOf(v)
+		} else {
+			err = s.setField(edited, id, v)
+		}
+		if err != nil {
+			errs[id] = err.Error()
+		}
+	}
+	s.applyUpdate(edited, req.Update, errs)
+	if len(errs) > 0 {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: errs})
+		return
+	}
+
+	s.replace(iss, edited)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleComment(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req struct {
+		Body       any `json:"body"`
+		Properties []struct {
+			Key   string `json:"key"`
+			Value struct {
+				Internal bool `json:"internal"`
+			} `json:"value"`
+		} `json:"properties"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if plainText(req.Body) == "" {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"comment": "Comment body can not be empty!"}})
+		return
+	}
+
+	c := Comment{ID: s.nextID(), Author: s.me().AccountID, Body: req.Body, Created: time.Now()}
+	for _, p := range req.Properties {
+		if p.Key == "sd.public.comment" {
+			c.Internal = p.Value.Internal
+		}
+	}
+	iss.Comments = append(iss.Comments, c)
+	iss.Updated = c.Created
+
+	writeJSON(w, http.StatusCreated, s.renderComment(&c))
+}
+
+func (s *Server) handleWorklog(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req struct {
+		Started   string `json:"started"`
+		TimeSpent string `json:"timeSpent"`
+		Comment   string `json:"comment"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.TimeSpent == "" {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"timeLogged": "You must indicate the time spent working."}})
+		return
+	}
+
+	wl := Worklog{ID: s.nextID(), Author: s.me().AccountID, TimeSpent: req.TimeSpent, Comment: req.Comment, Started: time.Now()}
+	if req.Started != "" {
+		started, err := time.Parse(timeFormat, req.Started)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"started": "Invalid date format."}})
+			return
+		}
+		wl.Started = started
+	}
+	iss.Worklogs = append(iss.Worklogs, wl)
+	iss.Updated = time.Now()
+
+	writeJSON(w, http.StatusCreated, s.renderWorklog(iss, &wl))
+}
+
+func (s *Server) handleRemoteLink(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+	var req struct {
+		Object struct {
+			URL   string `json:"url"`
+			Title string `json:"title"`
+		} `json:"object"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Object.URL == "" || req.Object.Title == "" {
+		writeError(w, http.StatusBadRequest, &errBadRequest{fields: map[string]string{"object": "The url and title of the link are required."}})
+		return
+	}
+
+	rl := RemoteLink{ID: s.nextID(), URL: req.Object.URL, Title: req.Object.Title}
+	iss.RemoteLinks = append(iss.RemoteLinks, rl)
+
+	writeJSON(w, http.StatusCreated, jsonObject{
+		"id":   rl.ID,
+		"self": fmt.Sprintf("%s/remotelink/%s", s.issueURL(r.PathValue("version"), iss.ID), rl.ID),
+	})
+}
+
+// handleWatch adds the user in the body to the watchers, or the current user if there is none.
+func (s *Server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	iss := s.issue(w, r)
+	if iss == nil {
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	u := s.me()
+	if len(strings.TrimSpace(string(data))) > 0 {
+		var ref string
+		if err := json.Unmarshal(data, &ref); err != nil {
+			writeError(w, http.StatusBadRequest, badRequest("Invalid request payload. Refer to the REST API documentation and try again."))
+			return
+		}
+		if u = s.findUser(ref); u == nil {
+			notFound(w, "The user \"%s\" does not exist.", ref)
+			return
+		}
+	}
+
+	if !slices.ContainsFunc(iss.Watchers, func(ref string) bool { return userMatches(u, ref) }) {
+		iss.Watchers = append(iss.Watchers, u.AccountID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	issues, err := s.search(r.URL.Query().Get("jql"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	writeJSON(w, http.StatusOK, s.renderSearch(issues, startAt, maxResults, r.PathValue("version"), listParam(r, "expand")))
+}
+
+// handleSearchJQL implements the cloud search endpoint paginated with page tokens.
+// Page tokens encode the offset of the page.
+func (s *Server) handleSearchJQL(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query().Get("jql")
+	if !jql.IsBounded(q) {
+		writeError(w, http.StatusBadRequest, badRequest("Unbounded JQL queries are not allowed here. Please add a search restriction to your query."))
+		return
+	}
+	issues, err := s.search(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var startAt int
+	if token := r.URL.Query().Get("nextPageToken"); token != "" {
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			startAt, err = strconv.Atoi(string(data))
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, badRequest("The provided next page token is invalid or expired."))
+			return
+		}
+	}
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	if maxResults == 0 {
+		maxResults = defaultMaxResults
+	}
+
+	items, last := page(issues, startAt, maxResults)
+	out := jsonObject{"issues": s.renderIssues(items, r.PathValue("version"), listParam(r, "expand")), "isLast": last}
+	if !last {
+		out["nextPageToken"] = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(startAt + len(items))))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleCount(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		JQL string `json:"jql"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	if !jql.IsBounded(req.JQL) {
+		writeError(w, http.StatusBadRequest, badRequest("Unbounded JQL queries are not allowed here. Please add a search restriction to your query."))
+		return
+	}
+	issues, err := s.search(req.JQL)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, jsonObject{"count": len(issues)})
+}
+
+func (s *Server) renderIssues(issues []*Issue, ver string, expand []string) []jsonObject {
+	out := make([]jsonObject, 0, len(issues))
+	for _, iss := range issues {
+		out = append(out, s.renderIssue(iss, ver, expand))
+	}
+	return out
+}
+
+func (s *Server) renderSearch(issues []*Issue, startAt, maxResults int, ver string, expand []string) jsonObject {
+	items, _ := page(issues, startAt, maxResults)
+	return jsonObject{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(issues),
+		"issues":     s.renderIssues(items, ver, expand),
+	}
+}
+
+// searchIn filters the issues with the optional jql of the request.
+func (s *Server) searchIn(r *http.Request, issues []*Issue) ([]*Issue, error) {
+	q := r.URL.Query().Get("jql")
+	if q == "" {
+		return issues, nil
+	}
+	query, err := s.compileJQL(q)
+	if err != nil {
+		return nil, err
+	}
+	return query.apply(issues), nil
+}
+
+func (s *Server) handleBoards(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var boards []jsonObject
+	for _, b := range s.boards {
+		switch {
+		case q.Get("projectKeyOrId") != "" && s.findProject(q.Get("projectKeyOrId")) != s.findProject(b.Project):
+		case q.Get("type") != "" && !strings.EqualFold(q.Get("type"), b.Type):
+		case !strings.Contains(strings.ToLower(b.Name), strings.ToLower(q.Get("name"))):
+		default:
+			boards = append(boards, s.renderBoard(b))
+		}
+	}
+
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	values, last := page(boards, startAt, maxResults)
+	writeJSON(w, http.StatusOK, jsonObject{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(boards),
+		"isLast":     last,
+		"values":     append([]jsonObject{}, values...),
+	})
+}
+
+func (s *Server) handleBoardSprints(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	if s.findBoard(id) == nil {
+		notFound(w, "Board does not exist or you do not have permission to see it.")
+		return
+	}
+	states := listParam(r, "state")
+
+	var sprints []*jira.Sprint
+	for _, sp := range s.sprints {
+		if sp.BoardID == id && (len(states) == 0 || slices.Contains(states, sp.Status)) {
+			sprints = append(sprints, sp)
+		}
+	}
+
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	values, last := page(sprints, startAt, maxResults)
+	writeJSON(w, http.StatusOK, jsonObject{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"isLast":     last,
+		"values":     append([]*jira.Sprint{}, values...),
+	})
+}
+
+// sprint finds the sprint of the request or writes a not found error.
+func (s *Server) sprint(w http.ResponseWriter, r *http.Request) *jira.Sprint {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	sp := s.findSprint(id)
+	if sp == nil {
+		notFound(w, "Sprint does not exist or you do not have permission to see it.")
+	}
+	return sp
+}
+
+func (s *Server) handleGetSprint(w http.ResponseWriter, r *http.Request) {
+	if sp := s.sprint(w, r); sp != nil {
+		writeJSON(w, http.StatusOK, sp)
+	}
+}
+
+// handleUpdateSprint updates the name, dates and state of the sprint.
+// Sprints can only move forward from future to active to closed.
+func (s *Server) handleUpdateSprint(w http.ResponseWriter, r *http.Request) {
+	sp := s.sprint(w, r)
+	if sp == nil {
+		return
+	}
+	var req jira.Sprint
+	if !decode(w, r, &req) {
+		return
+	}
+
+	states := []string{jira.SprintStateFuture, jira.SprintStateActive, jira.SprintStateClosed}
+	if req.Status != "" && slices.Index(states, req.Status) < slices.Index(states, sp.Status) {
+		writeError(w, http.StatusBadRequest, badRequest("Sprint cannot move from %s to %s.", sp.Status, req.Status))
+		return
+	}
+
+	now := time.Now().Format(timeFormat)
+	if req.Name != "" {
+		sp.Name = req.Name
+	}
+	if req.StartDate != "" {
+		sp.StartDate = req.StartDate
+	}
+	if req.EndDate != "" {
+		sp.EndDate = req.EndDate
+	}
+	if req.Status != "" && req.Status != sp.Status {
+		sp.Status = req.Status
+		if sp.Status == jira.SprintStateActive && sp.StartDate == "" {
+			sp.StartDate = now
+		}
+		if sp.Status == jira.SprintStateClosed {
+			sp.CompleteDate = now
+		}
+	}
+
+	writeJSON(w, http.StatusOK, sp)
+}
+
+func (s *Server) handleSprintIssues(w http.ResponseWriter, r *http.Request) {
+	sp := s.sprint(w, r)
+	if sp == nil {
+		return
+	}
+
+	var issues []*Issue
+	for _, iss := range s.issues {
+		if iss.Sprint == sp.ID {
+			issues = append(issues, iss)
+		}
+	}
+	issues, err := s.searchIn(r, issues)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	writeJSON(w, http.StatusOK, s.renderSearch(issues, startAt, maxResults, "2", nil))
+}
+
+func (s *Server) handleMoveToSprint(w http.ResponseWriter, r *http.Request) {
+	sp := s.sprint(w, r)
+	if sp == nil {
+		return
+	}
+	if sp.Status == jira.SprintStateClosed {
+		writeError(w, http.StatusBadRequest, badRequest("Issues can't be moved to a closed sprint."))
+		return
+	}
+
+	s.moveIssues(w, r, func(iss *Issue) { iss.Sprint = sp.ID })
+}
+
+// epic finds the epic of the request or writes an error.
+func (s *Server) epic(w http.ResponseWriter, r *http.Request) *Issue {
+	iss := s.issue(w, r)
+	if iss != nil && !s.isEpic(iss) {
+		writeError(w, http.StatusBadRequest, badRequest("Issue %s is not an epic.", iss.Key))
+		return nil
+	}
+	return iss
+}
+
+func (s *Server) handleEpicIssues(w http.ResponseWriter, r *http.Request) {
+	epic := s.epic(w, r)
+	if epic == nil {
+		return
+	}
+
+	var issues []*Issue
+	for _, iss := range s.issues {
+		if iss.Parent == epic.Key {
+			issues = append(issues, iss)
+		}
+	}
+	issues, err := s.searchIn(r, issues)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	startAt := intParam(r, "startAt", 0)
+	maxResults := intParam(r, "maxResults", defaultMaxResults)
+	writeJSON(w, http.StatusOK, s.renderSearch(issues, startAt, maxResults, "2", nil))
+}
+
+// handleMoveToEpic moves issues to the epic, or out of their epic if the key is none.
+func (s *Server) handleMoveToEpic(w http.ResponseWriter, r *http.Request) {
+	if r.PathValue("key") == "none" {
+		s.moveIssues(w, r, func(iss *Issue) {
+			if s.isEpicKey(iss.Parent) {
+				iss.Parent = ""
+			}
+		})
+		return
+	}
+
+	if epic := s.epic(w, r); epic != nil {
+		s.moveIssues(w, r, func(iss *Issue) { iss.Parent = epic.Key })
+	}
+}
+
+// moveIssues applies the move to the issues listed in the request body.
+func (s *Server) moveIssues(w http.ResponseWriter, r *http.Request, move func(iss *Issue)) {
+	var req struct {
+		Issues []string `json:"issues"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+
+	issues := make([]*Issue, 0, len(req.Issues))
+	for _, key := range req.Issues {
+		iss := s.findIssue(key)
+		if iss == nil {
+			writeError(w, http.StatusBadRequest, badRequest("Issue %s does not exist.", key))
+			return
+		}
+		issues = append(issues, iss)
+	}
+
+	for _, iss := range issues {
+		edited := cloneIssue(iss)
+		move(edited)
+		s.replace(iss, edited)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,471 @@
+// Package jiratest provides an in-memory Jira server for integration tests.
+//
+// The server implements the endpoints called by the jira package: issue CRUD,
+// search with basic JQL evaluation, transitions, comments, worklogs, links,
+// boards, sprints, epics, versions, users and create metadata. It's seeded
+// from fixtures, so workflows can be tested end to end offline.
+//
+//	srv := jiratest.NewServer()
+//	defer srv.Close()
+//
+//	err := srv.Seed(&jiratest.Fixtures{
+//		Users:    []jiratest.User{{AccountID: "a1", DisplayName: "Jane Doe"}},
+//		Projects: []jiratest.Project{{Key: "TEST", Name: "Test"}},
+//		Issues:   []jiratest.Issue{{Project: "TEST", Summary: "First issue"}},
+//	})
+//
+//	client := srv.Client()
+//
+// Credentials aren't checked. Descriptions and comment bodies are returned
+// as they were sent, they aren't converted between plain text and ADF.
+package jiratest
+
+import (
+	"fmt"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+const (
+	fieldEpicLink = "Epic Link"
+	fieldSprint   = "Sprint"
+)
+
+// anonymous is the authenticated user of servers without users.
+var anonymous = User{AccountID: "anonymous", Name: "anonymous", DisplayName: "Anonymous"}
+
+// Server is an in-memory Jira server. It's safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	info      jira.ServerInfo
+	myself    string
+	users     []*User
+	projects  []*Project
+	issues    []*Issue
+	links     []*Link
+	boards    []*Board
+	sprints   []*jira.Sprint
+	linkTypes []jira.IssueLinkType
+	fields    []jira.Field
+	workflow  []Transition
+	history   []string
+	lastID    int
+}
+
+// NewServer starts a cloud server with the default link types, fields and
+// workflow. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := Server{
+		linkTypes: defaultLinkTypes(),
+		fields:    defaultFields(),
+		workflow:  defaultWorkflow(),
+		lastID:    10000,
+	}
+	s.setServerInfo(jira.DeploymentTypeCloud, "")
+	s.Server = httptest.NewServer(s.routes())
+
+	return &s
+}
+
+// Client returns a client of the server.
+func (s *Server) Client(opts ...jira.ClientFunc) *jira.Client {
+	s.mu.Lock()
+	login := s.me().Email
+	s.mu.Unlock()
+
+	return jira.NewClient(jira.Config{
+		Server:   s.URL,
+		Login:    login,
+		APIToken: "jiratest",
+	}, opts...)
+}
+
+// Seed adds the fixtures to the server.
+func (s *Server) Seed(f *Fixtures) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if f.DeploymentType != "" || f.Version != "" {
+		deployment := f.DeploymentType
+		if deployment == "" {
+			deployment = s.info.DeploymentType
+		}
+		s.setServerInfo(deployment, f.Version)
+	}
+	if f.Myself != "" {
+		s.myself = f.Myself
+	}
+	if len(f.LinkTypes) > 0 {
+		s.linkTypes = slices.Clone(f.LinkTypes)
+	}
+	if len(f.Fields) > 0 {
+		s.fields = slices.Clone(f.Fields)
+	}
+	if len(f.Workflow) > 0 {
+		s.workflow = slices.Clone(f.Workflow)
+	}
+
+	for _, u := range f.Users {
+		s.users = append(s.users, &u)
+	}
+	for _, p := range f.Projects {
+		if err := s.addProject(p); err != nil {
+			return err
+		}
+	}
+	for _, b := range f.Boards {
+		if s.findProject(b.Project) == nil {
+			return fmt.Errorf("jiratest: board %d: unknown project %q", b.ID, b.Project)
+		}
+		if b.Type == "" {
+			b.Type = "scrum"
+		}
+		s.boards = append(s.boards, &b)
+	}
+	for _, sp := range f.Sprints {
+		s.sprints = append(s.sprints, &sp)
+	}
+	for _, iss := range f.Issues {
+		if _, err := s.addIssue(cloneIssue(&iss)); err != nil {
+			return err
+		}
+	}
+	for _, l := range f.Links {
+		if s.findIssue(l.Inward) == nil || s.findIssue(l.Outward) == nil {
+			return fmt.Errorf("jiratest: link %s %s %s: unknown issue", l.Inward, l.Type, l.Outward)
+		}
+		if l.ID == "" {
+			l.ID = s.nextID()
+		}
+		s.links = append(s.links, &l)
+	}
+
+	return nil
+}
+
+// Issue returns a copy of the issue, eg: to check the outcome of a workflow.
+func (s *Server) Issue(key string) (Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	iss := s.findIssue(key)
+	if iss == nil {
+		return Issue{}, false
+	}
+	return *cloneIssue(iss), true
+}
+
+// Links returns the links between issues.
+func (s *Server) Links() []Link {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Link, 0, len(s.links))
+	for _, l := range s.links {
+		out = append(out, *l)
+	}
+	return out
+}
+
+func (s *Server) setServerInfo(deployment, version string) {
+	if version == "" {
+		version = "9.12.0"
+		if strings.EqualFold(deployment, jira.DeploymentTypeCloud) {
+			version = "1001.0.0"
+		}
+	}
+
+	info := jira.ServerInfo{
+		Version:        version,
+		DeploymentType: deployment,
+		BuildNumber:    100000,
+	}
+	for _, part := range strings.Split(version, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		info.VersionNumbers = append(info.VersionNumbers, n)
+	}
+	info.DefaultLocale.Locale = "en_US"
+
+	s.info = info
+}
+
+func (s *Server) isCloud() bool {
+	return strings.EqualFold(s.info.DeploymentType, jira.DeploymentTypeCloud)
+}
+
+func (s *Server) nextID() string {
+	s.lastID++
+	return strconv.Itoa(s.lastID)
+}
+
+func (s *Server) addProject(p Project) error {
+	if p.Key == "" {
+		return fmt.Errorf("jiratest: project without key")
+	}
+	if s.findProject(p.Key) != nil {
+		return fmt.Errorf("jiratest: project %q already exists", p.Key)
+	}
+
+	p.IssueTypes = slices.Clone(p.IssueTypes)
+	p.Versions = slices.Clone(p.Versions)
+	p.Components = slices.Clone(p.Components)
+
+	if p.ID == "" {
+		p.ID = s.nextID()
+	}
+	if p.Style == "" {
+		p.Style = "classic"
+	}
+	if len(p.IssueTypes) == 0 {
+		p.IssueTypes = defaultIssueTypes()
+	}
+	for i := range p.Versions {
+		if p.Versions[i].ID == "" {
+			p.Versions[i].ID = s.nextID()
+		}
+		p.Versions[i].ProjectID, _ = strconv.Atoi(p.ID)
+	}
+	s.projects = append(s.projects, &p)
+
+	return nil
+}
+
+// addIssue fills the defaults of the issue and stores it.
+func (s *Server) addIssue(iss *Issue) (*Issue, error) {
+	if iss.Project == "" && iss.Key != "" {
+		iss.Project, _, _ = strings.Cut(iss.Key, "-")
+	}
+	p := s.findProject(iss.Project)
+	if p == nil {
+		return nil, fmt.Errorf("jiratest: issue %q: unknown project %q", iss.Key, iss.Project)
+	}
+	iss.Project = p.Key
+
+	switch {
+	case iss.Key == "":
+		iss.Key = fmt.Sprintf("%s-%d", p.Key, s.lastIssueNumber(p.Key)+1)
+	case s.findIssue(iss.Key) != nil:
+		return nil, fmt.Errorf("jiratest: issue %q already exists", iss.Key)
+	}
+	if iss.ID == "" {
+		iss.ID = s.nextID()
+	}
+	if iss.Type == "" {
+		iss.Type = "Task"
+	}
+	if iss.Status == "" && len(s.workflow) > 0 {
+		iss.Status = s.workflow[0].To
+	}
+	if iss.Priority == "" {
+		iss.Priority = "Medium"
+	}
+	if iss.Created.IsZero() {
+		iss.Created = time.Now()
+	}
+	if iss.Updated.IsZero() {
+		iss.Updated = iss.Created
+	}
+	for i := range iss.Comments {
+		c := &iss.Comments[i]
+		if c.ID == "" {
+			c.ID = s.nextID()
+		}
+		if c.Created.IsZero() {
+			c.Created = iss.Created
+		}
+	}
+	for i := range iss.Worklogs {
+		if iss.Worklogs[i].ID == "" {
+			iss.Worklogs[i].ID = s.nextID()
+		}
+	}
+	for i := range iss.RemoteLinks {
+		if iss.RemoteLinks[i].ID == "" {
+			iss.RemoteLinks[i].ID = s.nextID()
+		}
+	}
+	s.issues = append(s.issues, iss)
+
+	return iss, nil
+}
+
+func (s *Server) lastIssueNumber(project string) int {
+	var last int
+	for _, iss := range s.issues {
+		if iss.Project != project {
+			continue
+		}
+		if _, n := splitKey(iss.Key); n > last {
+			last = n
+		}
+	}
+	return last
+}
+
+// findIssue finds an issue by key or id.
+func (s *Server) findIssue(keyOrID string) *Issue {
+	for _, iss := range s.issues {
+		if strings.EqualFold(iss.Key, keyOrID) || iss.ID == keyOrID {
+			return iss
+		}
+	}
+	return nil
+}
+
+// findProject finds a project by key or id.
+func (s *Server) findProject(keyOrID string) *Project {
+	for _, p := range s.projects {
+		if strings.EqualFold(p.Key, keyOrID) || p.ID == keyOrID {
+			return p
+		}
+	}
+	return nil
+}
+
+// findUser finds a user by account id, name, email or display name.
+func (s *Server) findUser(ref string) *User {
+	if ref == "" {
+		return nil
+	}
+	for _, u := range s.users {
+		if userMatches(u, ref) {
+			return u
+		}
+	}
+	if userMatches(&anonymous, ref) {
+		return &anonymous
+	}
+	return nil
+}
+
+func userMatches(u *User, ref string) bool {
+	return u.AccountID == ref ||
+		strings.EqualFold(u.Name, ref) ||
+		strings.EqualFold(u.Email, ref) ||
+		strings.EqualFold(u.DisplayName, ref)
+}
+
+// me returns the authenticated user.
+func (s *Server) me() *User {
+	if u := s.findUser(s.myself); u != nil {
+		return u
+	}
+	if len(s.users) > 0 {
+		return s.users[0]
+	}
+	return &anonymous
+}
+
+func (s *Server) findSprint(id int) *jira.Sprint {
+	for _, sp := range s.sprints {
+		if sp.ID == id {
+			return sp
+		}
+	}
+	return nil
+}
+
+func (s *Server) findBoard(id int) *Board {
+	for _, b := range s.boards {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Server) findLinkType(name string) *jira.IssueLinkType {
+	for i, lt := range s.linkTypes {
+		if strings.EqualFold(lt.Name, name) || lt.ID == name {
+			return &s.linkTypes[i]
+		}
+	}
+	return nil
+}
+
+// findField finds a custom field by id or name.
+func (s *Server) findField(idOrName string) *jira.Field {
+	for i, f := range s.fields {
+		if f.ID == idOrName || strings.EqualFold(f.Name, idOrName) {
+			return &s.fields[i]
+		}
+	}
+	return nil
+}
+
+func (p *Project) issueType(name string) *jira.IssueType {
+	for i, it := range p.IssueTypes {
+		if strings.EqualFold(it.Name, name) || it.ID == name {
+			return &p.IssueTypes[i]
+		}
+	}
+	return nil
+}
+
+func (s *Server) issueType(iss *Issue) jira.IssueType {
+	if p := s.findProject(iss.Project); p != nil {
+		if it := p.issueType(iss.Type); it != nil {
+			return *it
+		}
+	}
+	return jira.IssueType{Name: iss.Type}
+}
+
+func (s *Server) isEpic(iss *Issue) bool {
+	return strings.EqualFold(iss.Type, jira.IssueTypeEpic)
+}
+
+// record adds the changes to the changelog of the issue.
+func (s *Server) record(iss *Issue, changes ...Change) {
+	var items []Change
+	for _, c := range changes {
+		if c.From != c.To {
+			items = append(items, c)
+		}
+	}
+
+	iss.Updated = time.Now()
+	if len(items) == 0 {
+		return
+	}
+	iss.Changelog = append(iss.Changelog, History{
+		ID:      s.nextID(),
+		Author:  s.me().AccountID,
+		Created: iss.Updated,
+		Items:   items,
+	})
+}
+
+// splitKey splits an issue key into the project key and the issue number.
+func splitKey(key string) (string, int) {
+	project, num, _ := strings.Cut(key, "-")
+	n, _ := strconv.Atoi(num)
+	return project, n
+}
+
+func cloneIssue(iss *Issue) *Issue {
+	out := *iss
+	out.Labels = slices.Clone(iss.Labels)
+	out.Components = slices.Clone(iss.Components)
+	out.FixVersions = slices.Clone(iss.FixVersions)
+	out.AffectsVersions = slices.Clone(iss.AffectsVersions)
+	out.Watchers = slices.Clone(iss.Watchers)
+	out.Comments = slices.Clone(iss.Comments)
+	out.Worklogs = slices.Clone(iss.Worklogs)
+	out.RemoteLinks = slices.Clone(iss.RemoteLinks)
+	out.Changelog = slices.Clone(iss.Changelog)
+	out.CustomFields = maps.Clone(iss.CustomFields)
+	return &out
+}
@@ -0,0 +1,285 @@
+package jiratest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+	"github.com/eliziario/jira-lib/pkg/jira/filter/issue"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	created := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, srv.Seed(&Fixtures{
+		Users: []User{
+			{AccountID: "a1", Name: "jane", DisplayName: "Jane Doe", Email: "jane@example.com"},
+			{AccountID: "a2", Name: "john", DisplayName: "John Smith", Email: "john@example.com"},
+		},
+		Projects: []Project{{
+			Key:        "TEST",
+			Name:       "Test Project",
+			Lead:       "a1",
+			Versions:   []jira.ProjectVersion{{Name: "v1.0", Released: true}, {Name: "v2.0"}},
+			Components: []string{"API", "UI"},
+		}},
+		Issues: []Issue{
+			{Project: "TEST", Type: "Epic", Summary: "Payments", Created: created},
+			{Project: "TEST", Type: "Bug", Summary: "Card declined", Priority: "High", Assignee: "a1", Labels: []string{"backend"}, Parent: "TEST-1", Sprint: 1, Created: created.Add(time.Hour)},
+			{Project: "TEST", Type: "Story", Summary: "Refund flow", Assignee: "a2", Status: "In Progress", Sprint: 1, Created: created.Add(2 * time.Hour)},
+			{Project: "TEST", Type: "Task", Summary: "Update docs", Status: "Done", Resolution: "Done", Created: created.Add(3 * time.Hour)},
+		},
+		Boards:  []Board{{ID: 1, Name: "TEST board", Project: "TEST"}},
+		Sprints: []jira.Sprint{{ID: 1, Name: "Sprint 1", Status: jira.SprintStateActive, BoardID: 1}, {ID: 2, Name: "Sprint 2", Status: jira.SprintStateFuture, BoardID: 1}},
+	}))
+
+	return srv
+}
+
+func TestServerIssueWorkflow(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+
+	created, err := client.Create(&jira.CreateRequest{
+		Project:    "TEST",
+		IssueType:  "Task",
+		Summary:    "Retry failed payments",
+		Body:       "Retry with backoff",
+		Assignee:   "a2",
+		Labels:     []string{"backend"},
+		Components: []string{"API"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-5", created.Key)
+
+	_, err = client.Create(&jira.CreateRequest{Project: "TEST", IssueType: "Task", Components: []string{"DB"}})
+	assert.ErrorIs(t, err, jira.ErrValidation)
+	assert.Equal(t, map[string]string{
+		"summary":    "You must specify a summary of the issue.",
+		"components": "Component name 'DB' is not valid",
+	}, err.(*jira.ErrUnexpectedResponse).FieldErrors())
+
+	assert.NoError(t, client.Edit("TEST-5", &jira.EditRequest{Summary: "Retry declined payments", Labels: []string{"-backend", "payments"}, FixVersions: []string{"v2.0"}}))
+	assert.NoError(t, client.AssignIssue("TEST-5", "a1"))
+
+	transitions, err := client.Transitions("TEST-5")
+	assert.NoError(t, err)
+	assert.Len(t, transitions, 3)
+
+	status, err := client.Transition("TEST-5", &jira.TransitionRequest{Transition: &jira.TransitionRequestData{ID: "31"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 204, status)
+
+	assert.NoError(t, client.AddIssueComment("TEST-5", "Fixed in v2.0", false))
+	assert.NoError(t, client.AddIssueWorklog("TEST-5", "2024-01-16T09:00:00.000+0000", "2h", "Implementation", ""))
+	assert.NoError(t, client.WatchIssue("TEST-5", "a2"))
+	assert.NoError(t, client.RemoteLinkIssue("TEST-5", "Design", "https://example.com/design"))
+	assert.NoError(t, client.LinkIssue("TEST-5", "TEST-2", "Blocks"))
+
+	iss, err := client.GetIssue("TEST-5", issue.NewExpandFilter(jira.ExpandChangelog))
+	assert.NoError(t, err)
+	assert.Equal(t, "Retry declined payments", iss.Fields.Summary)
+	assert.Equal(t, []string{"payments"}, iss.Fields.Labels)
+	assert.Equal(t, "Jane Doe", iss.Fields.Assignee.Name)
+	assert.Equal(t, "Jane Doe", iss.Fields.Reporter.Name)
+	assert.Equal(t, "Done", iss.Fields.Status.Name)
+	assert.Equal(t, "Done", iss.Fields.Resolution.Name)
+	assert.Equal(t, 1, iss.Fields.Comment.Total)
+	assert.Equal(t, 1, iss.Fields.Watches.WatchCount)
+	assert.Equal(t, "TEST-2", iss.Fields.IssueLinks[0].OutwardIssue.Key)
+	assert.Len(t, iss.Changelog.Histories, 3)
+
+	linkID, err := client.GetLinkID("TEST-2", "TEST-5")
+	assert.NoError(t, err)
+	assert.NoError(t, client.UnlinkIssue(linkID))
+	assert.Empty(t, srv.Links())
+
+	got, ok := srv.Issue("TEST-5")
+	assert.True(t, ok)
+	assert.Equal(t, "Retry with backoff", plainText(got.Description))
+	assert.Equal(t, "Fixed in v2.0", plainText(got.Comments[0].Body))
+	assert.Equal(t, []string{"v2.0"}, got.FixVersions)
+	assert.Equal(t, "2h", got.Worklogs[0].TimeSpent)
+	assert.Equal(t, "https://example.com/design", got.RemoteLinks[0].URL)
+
+	_, err = client.Transition("TEST-5", &jira.TransitionRequest{Transition: &jira.TransitionRequestData{ID: "99"}})
+	assert.ErrorIs(t, err, jira.ErrValidation)
+
+	assert.NoError(t, client.DeleteIssue("TEST-5", false))
+	_, err = client.GetIssue("TEST-5")
+	assert.ErrorIs(t, err, jira.ErrNotFound)
+}
+
+func TestServerSearch(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	res, err := client.Search("project = TEST AND status != Done ORDER BY created ASC", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "TEST-3"}, issueKeys(res.Issues))
+
+	res, err = client.Search("project = TEST AND status != Done ORDER BY created ASC", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TEST-2"}, issueKeys(res.Issues))
+
+	res, err = client.SearchV2("assignee IS NOT EMPTY", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, res.Total)
+	assert.Equal(t, []string{"TEST-2"}, issueKeys(res.Issues))
+
+	var keys []string
+	for iss, err := range client.SearchIter(ctx, "project = TEST", 3) {
+		assert.NoError(t, err)
+		keys = append(keys, iss.Key)
+	}
+	assert.Equal(t, []string{"TEST-4", "TEST-3", "TEST-2", "TEST-1"}, keys)
+
+	count, err := client.Count("sprint in openSprints()")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	_, err = client.SearchPage(ctx, "ORDER BY key", "", 10)
+	assert.ErrorIs(t, err, jira.ErrValidation)
+
+	_, err = client.Search("severity = High", 0, 10)
+	assert.ErrorIs(t, err, jira.ErrValidation)
+}
+
+func TestServerAgile(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+
+	boards, err := client.Boards("TEST", "scrum")
+	assert.NoError(t, err)
+	assert.Equal(t, []*jira.Board{{ID: 1, Name: "TEST board", Type: "scrum"}}, boards.Boards)
+
+	sprints, err := client.Sprints(1, "state=active,future", 0, 50)
+	assert.NoError(t, err)
+	assert.Len(t, sprints.Sprints, 2)
+	assert.True(t, sprints.IsLast)
+
+	assert.NoError(t, client.SprintIssuesAdd("2", "TEST-3"))
+	res, err := client.SprintIssues(2, "", 0, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TEST-3"}, issueKeys(res.Issues))
+
+	assert.NoError(t, client.EndSprint(1))
+	sprint, err := client.GetSprint(1)
+	assert.NoError(t, err)
+	assert.Equal(t, jira.SprintStateClosed, sprint.Status)
+	assert.NotEmpty(t, sprint.CompleteDate)
+	assert.Error(t, client.SprintIssuesAdd("1", "TEST-4"))
+
+	assert.NoError(t, client.EpicIssuesAdd("TEST-1", "TEST-3", "TEST-4"))
+	assert.NoError(t, client.EpicIssuesRemove("TEST-4"))
+	res, err = client.EpicIssues("TEST-1", "type = Story", 0, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TEST-3"}, issueKeys(res.Issues))
+
+	err = client.EpicIssuesAdd("TEST-2", "TEST-3")
+	assert.ErrorIs(t, err, jira.ErrValidation)
+}
+
+func TestServerMetadata(t *testing.T) {
+	srv := newTestServer(t)
+	client := srv.Client()
+
+	me, err := client.Me()
+	assert.NoError(t, err)
+	assert.Equal(t, "jane@example.com", me.Email)
+
+	projects, err := client.Project()
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", projects[0].Lead.Name)
+
+	versions, err := client.Release("TEST")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.True(t, versions[0].Released)
+
+	users, err := client.UserSearch(&jira.UserSearchOptions{Project: "TEST", Query: "smith"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a2", users[0].AccountID)
+
+	linkTypes, err := client.GetIssueLinkTypes()
+	assert.NoError(t, err)
+	assert.Len(t, linkTypes, 4)
+
+	meta, err := client.GetCreateMeta(&jira.CreateMetaRequest{Projects: "TEST", IssueTypeNames: "Bug", Expand: "projects.issuetypes.fields"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bug", meta.Projects[0].IssueTypes[0].Name)
+	assert.Equal(t, "customfield_10016", meta.Projects[0].IssueTypes[0].Fields["customfield_10016"].Key)
+
+	caps, err := client.Capabilities()
+	assert.NoError(t, err)
+	assert.True(t, caps.IsCloud())
+
+	assert.NoError(t, srv.Seed(&Fixtures{DeploymentType: jira.DeploymentTypeServer}))
+	caps, err = srv.Client().Capabilities()
+	assert.NoError(t, err)
+	assert.False(t, caps.IsCloud())
+	assert.True(t, caps.IssueTypeCreateMeta)
+
+	types, err := client.GetCreateMetaForJiraServerV9(&jira.CreateMetaRequest{Projects: "TEST"})
+	assert.NoError(t, err)
+	assert.Len(t, types.Values, 5)
+}
+
+func TestLoadFixtures(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{
+		"users": [{"accountId": "a1", "displayName": "Jane Doe"}],
+		"projects": [{"key": "OPS", "name": "Operations"}],
+		"issues": [
+			{"key": "OPS-7", "summary": "Rotate keys", "assignee": "Jane Doe", "customFields": {"customfield_10016": 3}}
+		],
+		"workflow": [
+			{"id": "1", "name": "Open", "to": "Open"},
+			{"id": "2", "name": "Close", "to": "Closed", "from": ["Open"], "resolution": "Fixed"}
+		]
+	}`), 0o600))
+
+	f, err := LoadFixtures(path)
+	assert.NoError(t, err)
+
+	srv := NewServer()
+	defer srv.Close()
+	assert.NoError(t, srv.Seed(f))
+
+	client := srv.Client()
+	res, err := client.Search("cf[10016] >= 3 AND assignee = currentUser()", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"OPS-7"}, issueKeys(res.Issues))
+	assert.Equal(t, "Open", res.Issues[0].Fields.Status.Name)
+
+	created, err := client.Create(&jira.CreateRequest{Project: "OPS", IssueType: "Task", Summary: "Audit access"})
+	assert.NoError(t, err)
+	assert.Equal(t, "OPS-8", created.Key)
+
+	_, err = client.Transition("OPS-7", &jira.TransitionRequest{Transition: &jira.TransitionRequestData{ID: "2"}})
+	assert.NoError(t, err)
+	iss, _ := srv.Issue("OPS-7")
+	assert.Equal(t, "Fixed", iss.Resolution)
+
+	_, err = LoadFixtures(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+	assert.Error(t, srv.Seed(&Fixtures{Issues: []Issue{{Project: "NOPE", Summary: "Orphan"}}}))
+}
+
+func issueKeys(issues []*jira.Issue) []string {
+	keys := make([]string, 0, len(issues))
+	for _, iss := range issues {
+		keys = append(keys, iss.Key)
+	}
+	return keys
+}
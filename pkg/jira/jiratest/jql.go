@@ -0,0 +1,912 @@
+package jiratest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// The JQL support covers the clauses commonly used by clients: fields
+// compared with =, !=, ~, !~, >, >=, <, <=, IN, NOT IN and IS [NOT] EMPTY,
+// combined with AND, OR, NOT and parentheses, and an ORDER BY clause.
+// Issues are ordered by key descending unless the query orders them.
+
+type fieldKind int
+
+const (
+	kindValue fieldKind = iota
+	kindText
+	kindUser
+	kindKey
+	kindDate
+	kindNumber
+	kindSprint
+	kindPriority
+	kindRank
+)
+
+type jqlField struct {
+	name   string
+	kind   fieldKind
+	values func(iss *Issue) []string
+}
+
+type jqlCond func(iss *Issue) bool
+
+type jqlOrder struct {
+	field *jqlField
+	desc  bool
+}
+
+type jqlQuery struct {
+	where jqlCond
+	order []jqlOrder
+}
+
+var (
+	customFieldRe  = regexp.MustCompile(`(?i)^cf\[(\d+)]$`)
+	relativeDateRe = regexp.MustCompile(`^([-+]?)(\d+)([yMwdhm])$`)
+)
+
+var priorities = []string{"lowest", "low", "medium", "high", "highest"}
+
+// search returns the issues matching the query in order.
+func (s *Server) search(q string) ([]*Issue, error) {
+	query, err := s.compileJQL(q)
+	if err != nil {
+		return nil, err
+	}
+	return query.apply(s.issues), nil
+}
+
+func (q *jqlQuery) apply(issues []*Issue) []*Issue {
+	var out []*Issue
+	for _, iss := range issues {
+		if q.where == nil || q.where(iss) {
+			out = append(out, iss)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, o := range q.order {
+			c := o.field.compare(out[i], out[j])
+			if c == 0 {
+				continue
+			}
+			if o.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	return out
+}
+
+func (s *Server) compileJQL(q string) (*jqlQuery, error) {
+	toks, err := lexJQL(q)
+	if err != nil {
+		return nil, err
+	}
+	p := jqlParser{s: s, toks: toks}
+
+	var query jqlQuery
+	if !p.done() && !p.peekWord("order") {
+		if query.where, err = p.or(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peekWord("order") {
+		if query.order, err = p.orderBy(); err != nil {
+			return nil, err
+		}
+	}
+	if !p.done() {
+		return nil, p.unexpected()
+	}
+	if len(query.order) == 0 {
+		key, _ := s.jqlField("key")
+		query.order = []jqlOrder{{field: key, desc: true}}
+	}
+
+	return &query, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type jqlToken struct {
+	kind tokenKind
+	val  string
+}
+
+func lexJQL(q string) ([]jqlToken, error) {
+	var toks []jqlToken
+
+	rs := []rune(q)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, jqlToken{kind: tokLParen, val: "("})
+			i++
+		case r == ')':
+			toks = append(toks, jqlToken{kind: tokRParen, val: ")"})
+			i++
+		case r == ',':
+			toks = append(toks, jqlToken{kind: tokComma, val: ","})
+			i++
+		case r == '"' || r == '\'':
+			var val strings.Builder
+			j := i + 1
+			for ; j < len(rs) && rs[j] != r; j++ {
+				if rs[j] == '\\' && j+1 < len(rs) {
+					j++
+				}
+				val.WriteRune(rs[j])
+			}
+			if j == len(rs) {
+				return nil, fmt.Errorf("Error in the JQL Query: The quoted string %q has not been completed.", string(rs[i:]))
+			}
+			toks = append(toks, jqlToken{kind: tokString, val: val.String()})
+			i = j + 1
+		case strings.ContainsRune("=!~<>", r):
+			op := string(r)
+			if i+1 < len(rs) && rs[i+1] == '=' || r == '!' && i+1 < len(rs) && rs[i+1] == '~' {
+				op += string(rs[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("Error in the JQL Query: The character '!' is a reserved JQL character.")
+			}
+			toks = append(toks, jqlToken{kind: tokOp, val: op})
+			i += len(op)
+		default:
+			j := i
+			for j < len(rs) && !unicode.IsSpace(rs[j]) && !strings.ContainsRune("(),\"'=!~<>", rs[j]) {
+				j++
+			}
+			toks = append(toks, jqlToken{kind: tokWord, val: string(rs[i:j])})
+			i = j
+		}
+	}
+
+	return toks, nil
+}
+
+type jqlParser struct {
+	s    *Server
+	toks []jqlToken
+	pos  int
+}
+
+func (p *jqlParser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *jqlParser) peek() jqlToken {
+	if p.done() {
+		return jqlToken{kind: -1}
+	}
+	return p.toks[p.pos]
+}
+
+func (p *jqlParser) peekWord(word string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.val, word)
+}
+
+func (p *jqlParser) next() jqlToken {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *jqlParser) unexpected() error {
+	if p.done() {
+		return fmt.Errorf("Error in the JQL Query: Expecting more input at the end of the query.")
+	}
+	return fmt.Errorf("Error in the JQL Query: Unexpected %q.", p.peek().val)
+}
+
+func (p *jqlParser) or() (jqlCond, error) {
+	conds, err := p.list("or", p.and)
+	if err != nil {
+		return nil, err
+	}
+	return func(iss *Issue) bool {
+		return slices.ContainsFunc(conds, func(c jqlCond) bool { return c(iss) })
+	}, nil
+}
+
+func (p *jqlParser) and() (jqlCond, error) {
+	conds, err := p.list("and", p.not)
+	if err != nil {
+		return nil, err
+	}
+	return func(iss *Issue) bool {
+		return !slices.ContainsFunc(conds, func(c jqlCond) bool { return !c(iss) })
+	}, nil
+}
+
+// list parses conditions separated by the keyword.
+func (p *jqlParser) list(keyword string, parse func() (jqlCond, error)) ([]jqlCond, error) {
+	var conds []jqlCond
+	for {
+		c, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, c)
+
+		if !p.peekWord(keyword) {
+			return conds, nil
+		}
+		p.next()
+	}
+}
+
+func (p *jqlParser) not() (jqlCond, error) {
+	if p.peekWord("not") {
+		p.next()
+		c, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return func(iss *Issue) bool { return !c(iss) }, nil
+	}
+
+	if p.peek().kind == tokLParen {
+		p.next()
+		c, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokRParen {
+			return nil, fmt.Errorf("Error in the JQL Query: Expecting ')'.")
+		}
+		return c, nil
+	}
+
+	return p.clause()
+}
+
+func (p *jqlParser) clause() (jqlCond, error) {
+	t := p.next()
+	if t.kind != tokWord && t.kind != tokString {
+		p.pos--
+		return nil, p.unexpected()
+	}
+	f, err := p.s.jqlField(t.val)
+	if err != nil {
+		return nil, err
+	}
+
+	op, err := p.operator()
+	if err != nil {
+		return nil, err
+	}
+	if !f.supports(op) {
+		return nil, fmt.Errorf("The operator '%s' is not supported by the '%s' field.", strings.ToUpper(op), f.name)
+	}
+
+	if op == "is" || op == "is not" {
+		if !p.peekWord("empty") && !p.peekWord("null") {
+			return nil, p.unexpected()
+		}
+		p.next()
+		return emptyCond(f, op == "is"), nil
+	}
+
+	vals, empty, err := p.operand(op == "in" || op == "not in")
+	if err != nil {
+		return nil, err
+	}
+	if empty {
+		switch op {
+		case "=":
+			return emptyCond(f, true), nil
+		case "!=":
+			return emptyCond(f, false), nil
+		}
+		return nil, fmt.Errorf("The operator '%s' does not support the EMPTY value.", strings.ToUpper(op))
+	}
+
+	switch op {
+	case "=", "in":
+		return func(iss *Issue) bool { return f.matchAny(p.s, iss, vals) }, nil
+	case "!=", "not in":
+		return func(iss *Issue) bool {
+			return len(f.values(iss)) > 0 && !f.matchAny(p.s, iss, vals)
+		}, nil
+	}
+	if len(vals) != 1 {
+		return nil, fmt.Errorf("The operator '%s' only supports a single value.", strings.ToUpper(op))
+	}
+
+	switch op {
+	case "~", "!~":
+		negate := op == "!~"
+		return func(iss *Issue) bool { return f.contains(iss, vals[0]) != negate }, nil
+	}
+
+	bound, err := f.parse(vals[0])
+	if err != nil {
+		return nil, err
+	}
+	return func(iss *Issue) bool {
+		for _, v := range f.values(iss) {
+			c, ok := f.compareValue(v, bound)
+			if !ok {
+				continue
+			}
+			switch op {
+			case ">":
+				return c > 0
+			case ">=":
+				return c >= 0
+			case "<":
+				return c < 0
+			case "<=":
+				return c <= 0
+			}
+		}
+		return false
+	}, nil
+}
+
+func (p *jqlParser) operator() (string, error) {
+	t := p.next()
+	if t.kind == tokOp {
+		return t.val, nil
+	}
+	if t.kind != tokWord {
+		p.pos--
+		return "", p.unexpected()
+	}
+
+	switch strings.ToLower(t.val) {
+	case "in":
+		return "in", nil
+	case "not":
+		if !p.peekWord("in") {
+			return "", p.unexpected()
+		}
+		p.next()
+		return "not in", nil
+	case "is":
+		if p.peekWord("not") {
+			p.next()
+			return "is not", nil
+		}
+		return "is", nil
+	case "was", "changed":
+		return "", fmt.Errorf("jiratest: the %s operator isn't supported", strings.ToUpper(t.val))
+	}
+
+	p.pos--
+	return "", p.unexpected()
+}
+
+// operand parses a value, a function call or a list of them.
+// It reports whether the operand is EMPTY.
+func (p *jqlParser) operand(list bool) ([]string, bool, error) {
+	if list && p.peek().kind == tokLParen {
+		p.next()
+
+		var vals []string
+		for {
+			v, empty, err := p.operand(false)
+			if err != nil {
+				return nil, false, err
+			}
+			if !empty {
+				vals = append(vals, v...)
+			}
+
+			switch p.next().kind {
+			case tokComma:
+				continue
+			case tokRParen:
+				return vals, false, nil
+			}
+			p.pos--
+			return nil, false, p.unexpected()
+		}
+	}
+
+	t := p.next()
+	switch {
+	case t.kind == tokString:
+		return []string{t.val}, false, nil
+	case t.kind != tokWord:
+		p.pos--
+		return nil, false, p.unexpected()
+	case p.peek().kind == tokLParen:
+		return p.function(t.val)
+	case strings.EqualFold(t.val, "empty") || strings.EqualFold(t.val, "null"):
+		return nil, true, nil
+	}
+	return []string{t.val}, false, nil
+}
+
+func (p *jqlParser) function(name string) ([]string, bool, error) {
+	p.next()
+
+	var args []string
+	for p.peek().kind != tokRParen {
+		t := p.next()
+		switch t.kind {
+		case tokWord, tokString:
+			args = append(args, t.val)
+		case tokComma:
+		default:
+			p.pos--
+			return nil, false, p.unexpected()
+		}
+	}
+	p.next()
+
+	vals, err := p.s.jqlFunction(name, args)
+	return vals, false, err
+}
+
+func (p *jqlParser) orderBy() ([]jqlOrder, error) {
+	p.next()
+	if !p.peekWord("by") {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	var order []jqlOrder
+	for {
+		t := p.next()
+		if t.kind != tokWord && t.kind != tokString {
+			p.pos--
+			return nil, p.unexpected()
+		}
+		f, err := p.s.jqlField(t.val)
+		if err != nil {
+			return nil, err
+		}
+		if f.kind == kindText && f.name != "summary" {
+			return nil, fmt.Errorf("Field '%s' does not support sorting.", f.name)
+		}
+
+		o := jqlOrder{field: f}
+		switch {
+		case p.peekWord("asc"):
+			p.next()
+		case p.peekWord("desc"):
+			p.next()
+			o.desc = true
+		}
+		order = append(order, o)
+
+		if p.peek().kind != tokComma {
+			return order, nil
+		}
+		p.next()
+	}
+}
+
+func emptyCond(f *jqlField, empty bool) jqlCond {
+	return func(iss *Issue) bool { return (len(f.values(iss)) == 0) == empty }
+}
+
+// jqlField resolves a field by name, eg: assignee, "Epic Link" or cf[10016].
+func (s *Server) jqlField(name string) (*jqlField, error) {
+	field := func(kind fieldKind, values func(iss *Issue) []string) (*jqlField, error) {
+		return &jqlField{name: strings.ToLower(name), kind: kind, values: values}, nil
+	}
+
+	switch strings.ToLower(name) {
+	case "project":
+		return field(kindValue, func(iss *Issue) []string {
+			if p := s.findProject(iss.Project); p != nil {
+				return []string{p.Key, p.Name, p.ID}
+			}
+			return []string{iss.Project}
+		})
+	case "key", "issuekey", "issue", "id":
+		return field(kindKey, func(iss *Issue) []string { return []string{iss.Key, iss.ID} })
+	case "summary":
+		return field(kindText, func(iss *Issue) []string { return nonEmpty(iss.Summary) })
+	case "description":
+		return field(kindText, func(iss *Issue) []string { return nonEmpty(plainText(iss.Description)) })
+	case "environment":
+		return field(kindText, func(iss *Issue) []string { return nonEmpty(plainText(iss.CustomFields["environment"])) })
+	case "comment":
+		return field(kindText, commentTexts)
+	case "text":
+		return field(kindText, func(iss *Issue) []string {
+			return append(nonEmpty(iss.Summary, plainText(iss.Description)), commentTexts(iss)...)
+		})
+	case "status":
+		return field(kindValue, func(iss *Issue) []string { return nonEmpty(iss.Status) })
+	case "resolution":
+		return field(kindValue, func(iss *Issue) []string { return nonEmpty(iss.Resolution) })
+	case "type", "issuetype":
+		return field(kindValue, func(iss *Issue) []string {
+			it := s.issueType(iss)
+			return nonEmpty(it.Name, it.ID)
+		})
+	case "priority":
+		return field(kindPriority, func(iss *Issue) []string { return nonEmpty(iss.Priority) })
+	case "assignee":
+		return field(kindUser, func(iss *Issue) []string { return nonEmpty(iss.Assignee) })
+	case "reporter", "creator":
+		return field(kindUser, func(iss *Issue) []string { return nonEmpty(iss.Reporter) })
+	case "watcher":
+		return field(kindUser, func(iss *Issue) []string { return iss.Watchers })
+	case "labels":
+		return field(kindValue, func(iss *Issue) []string { return iss.Labels })
+	case "component":
+		return field(kindValue, func(iss *Issue) []string { return iss.Components })
+	case "fixversion":
+		return field(kindValue, func(iss *Issue) []string { return iss.FixVersions })
+	case "affectedversion":
+		return field(kindValue, func(iss *Issue) []string { return iss.AffectsVersions })
+	case "parent":
+		return field(kindValue, func(iss *Issue) []string {
+			if parent := s.findIssue(iss.Parent); parent != nil {
+				return []string{parent.Key, parent.ID}
+			}
+			return nonEmpty(iss.Parent)
+		})
+	case "epic link", "parentepic":
+		return field(kindValue, func(iss *Issue) []string {
+			if parent := s.findIssue(iss.Parent); parent != nil && s.isEpic(parent) {
+				return []string{parent.Key, parent.ID}
+			}
+			return nil
+		})
+	case "sprint":
+		return field(kindSprint, func(iss *Issue) []string {
+			if sp := s.findSprint(iss.Sprint); sp != nil {
+				return []string{strconv.Itoa(sp.ID), sp.Name}
+			}
+			return nil
+		})
+	case "created", "createddate":
+		return field(kindDate, func(iss *Issue) []string { return []string{iss.Created.Format(time.RFC3339Nano)} })
+	case "updated", "updateddate":
+		return field(kindDate, func(iss *Issue) []string { return []string{iss.Updated.Format(time.RFC3339Nano)} })
+	case "rank":
+		return field(kindRank, func(iss *Issue) []string { return []string{iss.ID} })
+	}
+
+	id := name
+	if m := customFieldRe.FindStringSubmatch(name); m != nil {
+		id = "customfield_" + m[1]
+	}
+	if cf := s.findField(id); cf != nil {
+		kind := kindValue
+		if cf.Schema.DataType == "number" {
+			kind = kindNumber
+		}
+		return &jqlField{name: strings.ToLower(cf.Name), kind: kind, values: func(iss *Issue) []string {
+			return namesOf(iss.CustomFields[cf.ID])
+		}}, nil
+	}
+
+	return nil, fmt.Errorf("Field '%s' does not exist or you do not have permission to view it.", name)
+}
+
+// jqlFunction evaluates a function to the values it stands for.
+func (s *Server) jqlFunction(name string, args []string) ([]string, error) {
+	sprints := func(state string) []string {
+		var ids []string
+		for _, sp := range s.sprints {
+			if strings.EqualFold(sp.Status, state) {
+				ids = append(ids, strconv.Itoa(sp.ID))
+			}
+		}
+		return ids
+	}
+	date := func(t time.Time) ([]string, error) {
+		if len(args) > 0 {
+			d, err := parseRelative(t, args[0])
+			if err != nil {
+				return nil, err
+			}
+			t = d
+		}
+		return []string{t.Format(time.RFC3339Nano)}, nil
+	}
+
+	now := time.Now()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch strings.ToLower(name) {
+	case "currentuser":
+		return []string{s.me().AccountID}, nil
+	case "opensprints":
+		return sprints("active"), nil
+	case "closedsprints":
+		return sprints("closed"), nil
+	case "futuresprints":
+		return sprints("future"), nil
+	case "issuehistory":
+		return slices.Clone(s.history), nil
+	case "watchedissues":
+		me := s.me()
+		var keys []string
+		for _, iss := range s.issues {
+			if slices.ContainsFunc(iss.Watchers, func(ref string) bool { return userMatches(me, ref) }) {
+				keys = append(keys, iss.Key)
+			}
+		}
+		return keys, nil
+	case "now":
+		return date(now)
+	case "startofday":
+		return date(day)
+	case "endofday":
+		return date(day.AddDate(0, 0, 1).Add(-time.Millisecond))
+	case "startofweek":
+		return date(day.AddDate(0, 0, -int(day.Weekday())))
+	case "startofmonth":
+		return date(day.AddDate(0, 0, 1-day.Day()))
+	case "startofyear":
+		return date(time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location()))
+	}
+
+	return nil, fmt.Errorf("Unable to find JQL function '%s()'.", name)
+}
+
+func (f *jqlField) supports(op string) bool {
+	switch op {
+	case "is", "is not":
+		return f.kind != kindRank
+	case "~", "!~":
+		return f.kind == kindText
+	case ">", ">=", "<", "<=":
+		switch f.kind {
+		case kindKey, kindDate, kindNumber, kindPriority:
+			return true
+		}
+		return false
+	}
+	return f.kind != kindText && f.kind != kindRank
+}
+
+func (f *jqlField) matchAny(s *Server, iss *Issue, vals []string) bool {
+	for _, v := range f.values(iss) {
+		for _, want := range vals {
+			if f.match(s, v, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *jqlField) match(s *Server, v, want string) bool {
+	switch f.kind {
+	case kindUser:
+		if u := s.findUser(v); u != nil && userMatches(u, want) {
+			return true
+		}
+	case kindDate, kindNumber:
+		bound, err := f.parse(want)
+		if err != nil {
+			return false
+		}
+		c, ok := f.compareValue(v, bound)
+		return ok && c == 0
+	}
+	return strings.EqualFold(v, want)
+}
+
+// contains reports whether all the words of the query are in the text.
+// Trailing wildcards are ignored.
+func (f *jqlField) contains(iss *Issue, query string) bool {
+	words := strings.Fields(strings.ToLower(strings.ReplaceAll(query, "*", "")))
+	for _, text := range f.values(iss) {
+		text = strings.ToLower(text)
+		if !slices.ContainsFunc(words, func(w string) bool { return !strings.Contains(text, w) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses an operand of a comparison into the form compared by compareValue.
+func (f *jqlField) parse(v string) (string, error) {
+	switch f.kind {
+	case kindDate:
+		t, err := parseDate(v)
+		if err != nil {
+			return "", err
+		}
+		return t.Format(time.RFC3339Nano), nil
+	case kindNumber:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "", fmt.Errorf("The value '%s' is not valid for the '%s' field.", v, f.name)
+		}
+	case kindKey:
+		if _, n := splitKey(v); n == 0 {
+			return "", fmt.Errorf("The issue key '%s' for field '%s' is invalid.", v, f.name)
+		}
+	}
+	return v, nil
+}
+
+// compareValue compares a value of the field with a parsed operand.
+// It reports false if they can't be compared, eg: keys of different projects.
+func (f *jqlField) compareValue(v, bound string) (int, bool) {
+	switch f.kind {
+	case kindDate:
+		a, errA := time.Parse(time.RFC3339Nano, v)
+		b, errB := time.Parse(time.RFC3339Nano, bound)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		return a.Truncate(time.Minute).Compare(b.Truncate(time.Minute)), true
+	case kindNumber:
+		a, errA := strconv.ParseFloat(v, 64)
+		b, errB := strconv.ParseFloat(bound, 64)
+		if errA != nil || errB != nil {
+			return 0, false
+		}
+		return compareFloat(a, b), true
+	case kindKey:
+		pa, na := splitKey(v)
+		pb, nb := splitKey(bound)
+		if na == 0 || !strings.EqualFold(pa, pb) {
+			return 0, false
+		}
+		return na - nb, true
+	case kindPriority:
+		return priorityRank(v) - priorityRank(bound), true
+	}
+	return strings.Compare(strings.ToLower(v), strings.ToLower(bound)), true
+}
+
+// compare compares the issues by the field for sorting.
+// Issues without value are sorted last.
+func (f *jqlField) compare(a, b *Issue) int {
+	va, vb := f.values(a), f.values(b)
+	switch {
+	case len(va) == 0 && len(vb) == 0:
+		return 0
+	case len(va) == 0:
+		return 1
+	case len(vb) == 0:
+		return -1
+	}
+
+	switch f.kind {
+	case kindKey:
+		pa, na := splitKey(va[0])
+		pb, nb := splitKey(vb[0])
+		if c := strings.Compare(pa, pb); c != 0 {
+			return c
+		}
+		return na - nb
+	case kindRank:
+		ia, _ := strconv.Atoi(va[0])
+		ib, _ := strconv.Atoi(vb[0])
+		return ia - ib
+	case kindSprint:
+		ia, _ := strconv.Atoi(va[0])
+		ib, _ := strconv.Atoi(vb[0])
+		return ia - ib
+	}
+
+	c, _ := f.compareValue(va[0], vb[0])
+	return c
+}
+
+func priorityRank(name string) int {
+	return slices.Index(priorities, strings.ToLower(name))
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// parseDate parses absolute dates, eg: 2024-01-31 or "2024/01/31 14:00",
+// and dates relative to now, eg: -7d.
+func parseDate(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t, nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02", "2006-01-02 15:04", "2006/01/02 15:04"} {
+		if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return parseRelative(time.Now(), v)
+}
+
+func parseRelative(t time.Time, v string) (time.Time, error) {
+	m := relativeDateRe.FindStringSubmatch(v)
+	if m == nil {
+		return time.Time{}, fmt.Errorf("Date value '%s' is invalid. Valid formats include: 'yyyy/MM/dd HH:mm', 'yyyy-MM-dd HH:mm', 'yyyy/MM/dd', 'yyyy-MM-dd', or a period format e.g. '-5d'.", v)
+	}
+
+	n, _ := strconv.Atoi(m[2])
+	if m[1] == "-" {
+		n = -n
+	}
+
+	switch m[3] {
+	case "y":
+		return t.AddDate(n, 0, 0), nil
+	case "M":
+		return t.AddDate(0, n, 0), nil
+	case "w":
+		return t.AddDate(0, 0, 7*n), nil
+	case "d":
+		return t.AddDate(0, 0, n), nil
+	case "h":
+		return t.Add(time.Duration(n) * time.Hour), nil
+	}
+	return t.Add(time.Duration(n) * time.Minute), nil
+}
+
+func commentTexts(iss *Issue) []string {
+	var out []string
+	for _, c := range iss.Comments {
+		out = append(out, nonEmpty(plainText(c.Body))...)
+	}
+	return out
+}
+
+func nonEmpty(vals ...string) []string {
+	var out []string
+	for _, v := range vals {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// plainText returns the text of plain text and ADF values.
+func plainText(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case map[string]any:
+		var parts []string
+		if text, ok := val["text"].(string); ok {
+			parts = append(parts, text)
+		}
+		if content, ok := val["content"].([]any); ok {
+			for _, c := range content {
+				if text := plainText(c); text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+		return strings.Join(parts, " ")
+	}
+	return fmt.Sprint(v)
+}
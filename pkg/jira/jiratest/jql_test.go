@@ -0,0 +1,59 @@
+package jiratest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchJQL(t *testing.T) {
+	srv := newTestServer(t)
+
+	cases := []struct {
+		name string
+		jql  string
+		keys []string
+		err  string
+	}{
+		{name: "default order", jql: "project = TEST", keys: []string{"TEST-4", "TEST-3", "TEST-2", "TEST-1"}},
+		{name: "order by", jql: "project = TEST ORDER BY created ASC", keys: []string{"TEST-1", "TEST-2", "TEST-3", "TEST-4"}},
+		{name: "and or", jql: "type = Bug OR (type = Story AND status = \"In Progress\")", keys: []string{"TEST-3", "TEST-2"}},
+		{name: "not", jql: "project = TEST AND NOT status = Done ORDER BY key", keys: []string{"TEST-1", "TEST-2", "TEST-3"}},
+		{name: "in", jql: "type IN (Task, Story) ORDER BY key", keys: []string{"TEST-3", "TEST-4"}},
+		{name: "not in", jql: "type NOT IN (Task, Story) ORDER BY key", keys: []string{"TEST-1", "TEST-2"}},
+		{name: "text", jql: "summary ~ \"card decl*\"", keys: []string{"TEST-2"}},
+		{name: "not text", jql: "project = TEST AND summary !~ flow ORDER BY key", keys: []string{"TEST-1", "TEST-2", "TEST-4"}},
+		{name: "empty", jql: "assignee IS EMPTY ORDER BY key", keys: []string{"TEST-1", "TEST-4"}},
+		{name: "user", jql: "assignee = \"John Smith\"", keys: []string{"TEST-3"}},
+		{name: "current user", jql: "assignee = currentUser()", keys: []string{"TEST-2"}},
+		{name: "labels", jql: "labels = backend", keys: []string{"TEST-2"}},
+		{name: "parent", jql: "parent = TEST-1", keys: []string{"TEST-2"}},
+		{name: "epic link", jql: "\"Epic Link\" = TEST-1", keys: []string{"TEST-2"}},
+		{name: "sprint", jql: "sprint = 1 ORDER BY key", keys: []string{"TEST-2", "TEST-3"}},
+		{name: "open sprints", jql: "sprint IN openSprints() ORDER BY key", keys: []string{"TEST-2", "TEST-3"}},
+		{name: "future sprints", jql: "sprint IN futureSprints()", keys: nil},
+		{name: "key range", jql: "key > TEST-2 ORDER BY key", keys: []string{"TEST-3", "TEST-4"}},
+		{name: "priority", jql: "priority >= High", keys: []string{"TEST-2"}},
+		{name: "date", jql: "created >= \"2024-01-15 12:00\"", keys: []string{"TEST-4", "TEST-3"}},
+		{name: "resolution", jql: "resolution = Done", keys: []string{"TEST-4"}},
+		{name: "unknown field", jql: "severity = High", err: "Field 'severity' does not exist or you do not have permission to view it."},
+		{name: "unsupported operator", jql: "summary = flow", err: "The operator '=' is not supported by the 'summary' field."},
+		{name: "unterminated", jql: "project = TEST AND", err: "Error in the JQL Query: Expecting more input at the end of the query."},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			issues, err := srv.search(tc.jql)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var keys []string
+			for _, iss := range issues {
+				keys = append(keys, iss.Key)
+			}
+			assert.Equal(t, tc.keys, keys)
+		})
+	}
+}
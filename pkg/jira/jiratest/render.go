@@ -0,0 +1,365 @@
+package jiratest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+// timeFormat is the format of timestamps in Jira responses.
+const timeFormat = "2006-01-02T15:04:05.000-0700"
+
+// jsonObject is a JSON object of a response.
+type jsonObject = map[string]any
+
+func (s *Server) renderUser(ref string) any {
+	if ref == "" {
+		return nil
+	}
+	u := s.findUser(ref)
+	if u == nil {
+		return jsonObject{"accountId": ref, "name": ref, "displayName": ref, "active": false}
+	}
+	return userJSON(u)
+}
+
+func userJSON(u *User) jsonObject {
+	out := jsonObject{
+		"accountId":    u.AccountID,
+		"displayName":  u.DisplayName,
+		"emailAddress": u.Email,
+		"active":       !u.Inactive,
+		"timeZone":     "UTC",
+	}
+	if u.Name != "" {
+		out["name"] = u.Name
+		out["key"] = u.Name
+	}
+	return out
+}
+
+func named(names []string) []jsonObject {
+	out := make([]jsonObject, 0, len(names))
+	for _, n := range names {
+		out = append(out, jsonObject{"name": n})
+	}
+	return out
+}
+
+func (s *Server) issueURL(ver, id string) string {
+	return fmt.Sprintf("%s/rest/api/%s/issue/%s", s.URL, ver, id)
+}
+
+// renderRef renders an issue referenced by another one, eg: a parent or a sub-task.
+func (s *Server) renderRef(iss *Issue, ver string) jsonObject {
+	return jsonObject{
+		"id":   iss.ID,
+		"key":  iss.Key,
+		"self": s.issueURL(ver, iss.ID),
+		"fields": jsonObject{
+			"summary":   iss.Summary,
+			"status":    jsonObject{"name": iss.Status},
+			"priority":  jsonObject{"name": iss.Priority},
+			"issuetype": s.issueType(iss),
+		},
+	}
+}
+
+func (s *Server) renderIssue(iss *Issue, ver string, expand []string) jsonObject {
+	me := s.me()
+
+	var resolution any
+	if iss.Resolution != "" {
+		resolution = jsonObject{"name": iss.Resolution}
+	}
+
+	fields := jsonObject{
+		"summary":     iss.Summary,
+		"description": iss.Description,
+		"labels":      append([]string{}, iss.Labels...),
+		"resolution":  resolution,
+		"issuetype":   s.issueType(iss),
+		"assignee":    s.renderUser(iss.Assignee),
+		"reporter":    s.renderUser(iss.Reporter),
+		"priority":    jsonObject{"name": iss.Priority},
+		"status":      jsonObject{"name": iss.Status},
+		"components":  named(iss.Components),
+		"fixVersions": named(iss.FixVersions),
+		"versions":    named(iss.AffectsVersions),
+		"watches": jsonObject{
+			"isWatching": slices.ContainsFunc(iss.Watchers, func(ref string) bool { return userMatches(me, ref) }),
+			"watchCount": len(iss.Watchers),
+		},
+		"comment":     s.renderComments(iss),
+		"worklog":     s.renderWorklogs(iss),
+		"subtasks":    s.renderSubtasks(iss, ver),
+		"issuelinks":  s.renderLinks(iss, ver),
+		"created":     iss.Created.Format(timeFormat),
+		"updated":     iss.Updated.Format(timeFormat),
+		"project":     s.renderProject(s.findProject(iss.Project)),
+		"environment": iss.CustomFields["environment"],
+		"duedate":     iss.CustomFields["duedate"],
+	}
+	if iss.Parent != "" {
+		parent := jsonObject{"key": iss.Parent}
+		if p := s.findIssue(iss.Parent); p != nil {
+			parent = s.renderRef(p, ver)
+		}
+		fields["parent"] = parent
+
+		if cf := s.findField(fieldEpicLink); cf != nil && s.isEpicKey(iss.Parent) {
+			fields[cf.ID] = iss.Parent
+		}
+	}
+	if cf := s.findField(fieldSprint); cf != nil {
+		var sprints []any
+		if sp := s.findSprint(iss.Sprint); sp != nil {
+			sprints = append(sprints, jsonObject{"id": sp.ID, "name": sp.Name, "state": sp.Status, "boardId": sp.BoardID})
+		}
+		fields[cf.ID] = sprints
+	}
+	for id, v := range iss.CustomFields {
+		if strings.HasPrefix(id, "customfield_") {
+			fields[id] = v
+		}
+	}
+
+	out := jsonObject{
+		"id":     iss.ID,
+		"key":    iss.Key,
+		"self":   s.issueURL(ver, iss.ID),
+		"fields": fields,
+	}
+	for _, e := range expand {
+		switch e {
+		case jira.ExpandTransitions:
+			out["transitions"] = s.renderTransitions(iss)
+		case jira.ExpandChangelog:
+			out["changelog"] = s.renderChangelog(iss)
+		case jira.ExpandNames:
+			names := make(map[string]string)
+			for id, f := range systemFields {
+				names[id] = f.name
+			}
+			for _, f := range s.fields {
+				names[f.ID] = f.Name
+			}
+			out["names"] = names
+		}
+	}
+
+	return out
+}
+
+func (s *Server) isEpicKey(key string) bool {
+	iss := s.findIssue(key)
+	return iss != nil && s.isEpic(iss)
+}
+
+func (s *Server) renderComment(c *Comment) jsonObject {
+	return jsonObject{
+		"id":        c.ID,
+		"author":    s.renderUser(c.Author),
+		"body":      c.Body,
+		"created":   c.Created.Format(timeFormat),
+		"updated":   c.Created.Format(timeFormat),
+		"jsdPublic": !c.Internal,
+	}
+}
+
+func (s *Server) renderComments(iss *Issue) jsonObject {
+	comments := make([]jsonObject, 0, len(iss.Comments))
+	for i := range iss.Comments {
+		comments = append(comments, s.renderComment(&iss.Comments[i]))
+	}
+	return jsonObject{
+		"comments":   comments,
+		"startAt":    0,
+		"maxResults": len(comments),
+		"total":      len(comments),
+	}
+}
+
+func (s *Server) renderWorklog(iss *Issue, w *Worklog) jsonObject {
+	return jsonObject{
+		"id":        w.ID,
+		"issueId":   iss.ID,
+		"author":    s.renderUser(w.Author),
+		"comment":   w.Comment,
+		"timeSpent": w.TimeSpent,
+		"started":   w.Started.Format(timeFormat),
+	}
+}
+
+func (s *Server) renderWorklogs(iss *Issue) jsonObject {
+	worklogs := make([]jsonObject, 0, len(iss.Worklogs))
+	for i := range iss.Worklogs {
+		worklogs = append(worklogs, s.renderWorklog(iss, &iss.Worklogs[i]))
+	}
+	return jsonObject{
+		"worklogs":   worklogs,
+		"startAt":    0,
+		"maxResults": len(worklogs),
+		"total":      len(worklogs),
+	}
+}
+
+func (s *Server) renderSubtasks(iss *Issue, ver string) []jsonObject {
+	out := make([]jsonObject, 0)
+	for _, sub := range s.issues {
+		if sub.Parent == iss.Key && s.issueType(sub).Subtask {
+			out = append(out, s.renderRef(sub, ver))
+		}
+	}
+	return out
+}
+
+// renderLinks renders the links of the issue. Each link holds the issue
+// on the other end, eg: the outward issue for links where the issue is inward.
+func (s *Server) renderLinks(iss *Issue, ver string) []jsonObject {
+	out := make([]jsonObject, 0)
+	for _, l := range s.links {
+		var side, other string
+		switch iss.Key {
+		case l.Inward:
+			side, other = "outwardIssue", l.Outward
+		case l.Outward:
+			side, other = "inwardIssue", l.Inward
+		default:
+			continue
+		}
+
+		lt := jira.IssueLinkType{Name: l.Type}
+		if t := s.findLinkType(l.Type); t != nil {
+			lt = *t
+		}
+		link := jsonObject{"id": l.ID, "type": lt}
+		if o := s.findIssue(other); o != nil {
+			link[side] = s.renderRef(o, ver)
+		}
+		out = append(out, link)
+	}
+	return out
+}
+
+func (s *Server) renderChangelog(iss *Issue) jsonObject {
+	histories := make([]jsonObject, 0, len(iss.Changelog))
+	for _, h := range iss.Changelog {
+		items := make([]jsonObject, 0, len(h.Items))
+		for _, c := range h.Items {
+			items = append(items, jsonObject{
+				"field":      c.Field,
+				"fieldtype":  "jira",
+				"fromString": c.From,
+				"toString":   c.To,
+			})
+		}
+		histories = append(histories, jsonObject{
+			"id":      h.ID,
+			"author":  s.renderUser(h.Author),
+			"created": h.Created.Format(timeFormat),
+			"items":   items,
+		})
+	}
+	return jsonObject{
+		"startAt":    0,
+		"maxResults": len(histories),
+		"total":      len(histories),
+		"histories":  histories,
+	}
+}
+
+// availableTransitions returns the transitions available from the status of the issue.
+func (s *Server) availableTransitions(iss *Issue) []Transition {
+	var out []Transition
+	for _, t := range s.workflow {
+		if len(t.From) == 0 || slices.ContainsFunc(t.From, func(from string) bool { return strings.EqualFold(from, iss.Status) }) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func (s *Server) renderTransitions(iss *Issue) []jsonObject {
+	out := make([]jsonObject, 0)
+	for _, t := range s.availableTransitions(iss) {
+		out = append(out, jsonObject{
+			"id":          t.ID,
+			"name":        t.Name,
+			"to":          jsonObject{"name": t.To},
+			"isAvailable": true,
+			"hasScreen":   false,
+		})
+	}
+	return out
+}
+
+func (s *Server) renderProject(p *Project) jsonObject {
+	if p == nil {
+		return nil
+	}
+	return jsonObject{
+		"id":             p.ID,
+		"key":            p.Key,
+		"name":           p.Name,
+		"style":          p.Style,
+		"projectTypeKey": "software",
+		"lead":           s.renderUser(p.Lead),
+	}
+}
+
+func (s *Server) renderBoard(b *Board) jsonObject {
+	location := jsonObject{"projectKey": b.Project}
+	if p := s.findProject(b.Project); p != nil {
+		location["projectId"], _ = strconv.Atoi(p.ID)
+		location["projectName"] = p.Name
+	}
+	return jsonObject{
+		"id":       b.ID,
+		"name":     b.Name,
+		"type":     b.Type,
+		"location": location,
+	}
+}
+
+// renderFieldMeta renders the create metadata of the fields of an issue type.
+func (s *Server) renderFieldMeta(it jira.IssueType) []jsonObject {
+	ids := []string{"summary", "description", "project", "issuetype", "priority", "assignee",
+		"reporter", "labels", "components", "fixVersions", "versions", "duedate"}
+	if it.Subtask {
+		ids = append(ids, "parent")
+	}
+
+	var out []jsonObject
+	for _, id := range ids {
+		f := systemFields[id]
+		schema := jsonObject{"type": f.dataType, "system": id}
+		if f.items != "" {
+			schema["items"] = f.items
+		}
+		out = append(out, jsonObject{
+			"fieldId":  id,
+			"key":      id,
+			"name":     f.name,
+			"required": id == "summary" || id == "project" || id == "issuetype" || id == "parent",
+			"schema":   schema,
+		})
+	}
+	for _, f := range s.fields {
+		schema := jsonObject{"type": f.Schema.DataType, "customId": f.Schema.FieldID}
+		if f.Schema.Items != "" {
+			schema["items"] = f.Schema.Items
+		}
+		out = append(out, jsonObject{
+			"fieldId":  f.ID,
+			"key":      f.ID,
+			"name":     f.Name,
+			"required": false,
+			"schema":   schema,
+		})
+	}
+	return out
+}
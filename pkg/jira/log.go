@@ -0,0 +1,239 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	redacted = "REDACTED"
+
+	// maxLoggedBody is the maximum number of body bytes included in dumps.
+	maxLoggedBody = 64 << 10
+)
+
+// sensitiveHeaders are always redacted from logs.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// LogOptions configures how requests are logged.
+type LogOptions struct {
+	// RequestLevel is the level of completed requests.
+	RequestLevel slog.Level
+	// ErrorLevel is the level of failed requests and unexpected status codes.
+	ErrorLevel slog.Level
+	// RetryLevel is the level of retry attempts.
+	RetryLevel slog.Level
+	// Dump includes the headers and bodies of requests and responses.
+	Dump bool
+	// RedactHeaders are additional headers to redact.
+	// Credentials and cookies are always redacted.
+	RedactHeaders []string
+	// RedactFields are query params and JSON body fields to redact, eg: password.
+	RedactFields []string
+}
+
+// DefaultLogOptions returns the options used by WithLogger. Completed requests are
+// logged at debug level, retries at info level and failures at warn level.
+func DefaultLogOptions() LogOptions {
+	return LogOptions{
+		RequestLevel: slog.LevelDebug,
+		ErrorLevel:   slog.LevelWarn,
+		RetryLevel:   slog.LevelInfo,
+	}
+}
+
+// WithLogger is a functional opt to log requests using the given logger.
+//
+// Nothing is logged unless a logger is provided, except in debug mode where
+// requests are dumped to stderr. Credentials are always redacted.
+func WithLogger(l *slog.Logger) ClientFunc {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithLogOptions is a functional opt to configure the log levels and redaction.
+func WithLogOptions(o LogOptions) ClientFunc {
+	return func(c *Client) {
+		c.logOpts = &o
+	}
+}
+
+// setupLogger resolves the logger and options after all opts are applied.
+func (c *Client) setupLogger() {
+	opts := DefaultLogOptions()
+	if c.logOpts != nil {
+		opts = *c.logOpts
+	}
+
+	if c.debug {
+		if c.logger == nil {
+			c.logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		}
+		opts.Dump = true
+	}
+
+	c.logOpts = &opts
+}
+
+func (c *Client) logRequest(ctx context.Context, req *http.Request, body []byte, res *http.Response, err error, attempt int, latency time.Duration) {
+	if c.logger == nil {
+		return
+	}
+
+	level := c.logOpts.RequestLevel
+	if err != nil || res == nil || res.StatusCode >= http.StatusBadRequest {
+		level = c.logOpts.ErrorLevel
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", c.redactURL(req.URL)),
+		slog.Int("attempt", attempt+1),
+		slog.Duration("latency", latency),
+	}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if c.logOpts.Dump {
+		attrs = append(attrs, slog.Group("request",
+			slog.Any("headers", c.redactHeaders(req.Header)),
+			slog.String("body", c.redactBody(body)),
+		))
+		if res != nil {
+			attrs = append(attrs, slog.Group("response",
+				slog.Any("headers", c.redactHeaders(res.Header)),
+				slog.String("body", c.redactBody(peekBody(res))),
+			))
+		}
+	}
+
+	c.logger.LogAttrs(ctx, level, "jira request", attrs...)
+}
+
+func (c *Client) logRetry(ctx context.Context, a RetryAttempt) {
+	if c.logger == nil || !c.logger.Enabled(ctx, c.logOpts.RetryLevel) {
+		return
+	}
+
+	endpoint := a.URL
+	if u, err := url.Parse(a.URL); err == nil {
+		endpoint = c.redactURL(u)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", a.Method),
+		slog.String("url", endpoint),
+		slog.Int("attempt", a.Attempt),
+		slog.Duration("wait", a.Wait),
+	}
+	if a.StatusCode != 0 {
+		attrs = append(attrs, slog.Int("status", a.StatusCode))
+	}
+	if a.Err != nil {
+		attrs = append(attrs, slog.String("error", a.Err.Error()))
+	}
+
+	c.logger.LogAttrs(ctx, c.logOpts.RetryLevel, "jira request retry", attrs...)
+}
+
+func (c *Client) isSensitiveField(name string) bool {
+	return slices.ContainsFunc(c.logOpts.RedactFields, func(f string) bool {
+		return strings.EqualFold(f, name)
+	})
+}
+
+func (c *Client) redactURL(u *url.URL) string {
+	cp := *u
+	cp.User = nil
+
+	q := cp.Query()
+	for k := range q {
+		if c.isSensitiveField(k) {
+			q[k] = []string{redacted}
+		}
+	}
+	cp.RawQuery = q.Encode()
+
+	return cp.String()
+}
+
+func (c *Client) redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		sensitive := func(s string) bool { return strings.EqualFold(s, k) }
+		if slices.ContainsFunc(sensitiveHeaders, sensitive) || slices.ContainsFunc(c.logOpts.RedactHeaders, sensitive) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+// redactBody redacts sensitive fields from JSON bodies.
+// Bodies that are not JSON are logged as is.
+func (c *Client) redactBody(body []byte) string {
+	out := body
+
+	var v any
+	if len(c.logOpts.RedactFields) > 0 && json.Unmarshal(body, &v) == nil {
+		if b, err := json.Marshal(c.redactValue(v)); err == nil {
+			out = b
+		}
+	}
+
+	if len(out) > maxLoggedBody {
+		return string(out[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+func (c *Client) redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if c.isSensitiveField(k) {
+				val[k] = redacted
+				continue
+			}
+			val[k] = c.redactValue(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = c.redactValue(item)
+		}
+	}
+	return v
+}
+
+// peekBody reads the response body and restores it so that it can be read again.
+func peekBody(res *http.Response) []byte {
+	if res.Body == nil || res.Body == http.NoBody {
+		return nil
+	}
+
+	data, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,99 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithLogger(t *testing.T) {
+	var attempts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "test", "password": "hunter2"}`, string(body))
+
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{"id": 1, "token": "secret-token"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	opts := DefaultLogOptions()
+	opts.Dump = true
+	opts.RedactHeaders = []string{"X-Api-Key"}
+	opts.RedactFields = []string{"password", "token"}
+
+	client := NewClient(
+		Config{Server: server.URL, Login: "user", APIToken: "secret-api-token"},
+		WithTimeout(3*time.Second),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryNonIdempotent: true}),
+		WithLogger(logger),
+		WithLogOptions(opts),
+	)
+
+	res, err := client.Post(context.Background(), "/user?token=secret-query", []byte(`{"name": "test", "password": "hunter2"}`), Header{
+		"X-Api-Key":     "secret-key",
+		"Authorization": "Bearer secret-header",
+	})
+	assert.NoError(t, err)
+
+	// The response body can still be read after being logged.
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "token": "secret-token"}`, string(body))
+	_ = res.Body.Close()
+
+	out := buf.String()
+	for _, secret := range []string{"secret-api-token", "secret-key", "secret-header", "secret-query", "hunter2", "secret-token", "secret-cookie"} {
+		assert.NotContains(t, out, secret)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 3)
+
+	var entries []map[string]any
+	for _, line := range lines {
+		var entry map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+
+	assert.Equal(t, "WARN", entries[0]["level"])
+	assert.Equal(t, float64(503), entries[0]["status"])
+	assert.Equal(t, "INFO", entries[1]["level"])
+	assert.Equal(t, "jira request retry", entries[1]["msg"])
+	assert.Equal(t, "DEBUG", entries[2]["level"])
+	assert.Equal(t, "POST", entries[2]["method"])
+	assert.Equal(t, float64(200), entries[2]["status"])
+	assert.Equal(t, float64(2), entries[2]["attempt"])
+	assert.Equal(t, server.URL+"/rest/api/3/user?token=REDACTED", entries[2]["url"])
+}
+
+func TestLoggerDisabledByDefault(t *testing.T) {
+	client := NewClient(Config{Server: "http://localhost"})
+	assert.Nil(t, client.logger)
+
+	client = NewClient(Config{Server: "http://localhost", Debug: true})
+	assert.NotNil(t, client.logger)
+	assert.True(t, client.logOpts.Dump)
+}
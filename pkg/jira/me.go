@@ -16,7 +16,12 @@ type Me struct {
 
 // Me fetches response from /myself endpoint.
 func (c *Client) Me() (*Me, error) {
-	res, err := c.GetV2(context.Background(), "/myself", nil)
+	return c.MeContext(context.Background())
+}
+
+// MeContext is the same as Me but accepts a context.
+func (c *Client) MeContext(ctx context.Context) (*Me, error) {
+	res, err := c.GetV2(ctx, "/myself", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,210 @@
+package jira
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// ErrInvalidCACert denotes a CA certificate without any valid PEM certificate.
+var ErrInvalidCACert = fmt.Errorf("jira: no valid certificate found in mtls CA cert")
+
+// certLoader loads mTLS certificates and reloads the ones read from
+// files when the files change, eg: when certificates are rotated.
+type certLoader struct {
+	cfg MTLSConfig
+
+	mu      sync.Mutex
+	cert    *tls.Certificate
+	certMod [2]time.Time
+	roots   *x509.CertPool
+	rootMod time.Time
+}
+
+func newCertLoader(cfg MTLSConfig) (*certLoader, error) {
+	l := certLoader{cfg: cfg}
+
+	if err := l.loadRoots(); err != nil {
+		return nil, err
+	}
+	if err := l.loadCert(); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// configure adds the mTLS specific configuration to the transport.
+func (l *certLoader) configure(t *http.Transport) {
+	tc := t.TLSClientConfig
+	tc.Renegotiation = tls.RenegotiateFreelyAsClient
+
+	if l.cert != nil {
+		tc.GetClientCertificate = l.clientCertificate
+	}
+	if l.roots == nil {
+		return
+	}
+	tc.RootCAs = l.roots
+	if l.cfg.CaCert == "" || tc.InsecureSkipVerify {
+		return
+	}
+
+	// The transport uses a fixed pool, so TLS connections are made here
+	// to pick up a rotated CA certificate. Connections through a proxy
+	// keep using the CA certificate loaded when the client was created.
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{}).DialContext
+	}
+	t.DialTLSContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return l.dialTLS(ctx, dial, tc, network, addr)
+	}
+}
+
+func (l *certLoader) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cfg.Certificate == nil && l.cfg.ClientCert != "" && l.cfg.ClientKey != "" {
+		// Keep using the current certificate if the new one can't be loaded,
+		// eg: when the files are caught in the middle of a rotation.
+		_ = l.reloadCert()
+	}
+	return l.cert, nil
+}
+
+// dialTLS connects to the address and verifies the server certificate
+// against the current CA certificate and the dialled host.
+func (l *certLoader) dialTLS(ctx context.Context, dial func(context.Context, string, string) (net.Conn, error), tc *tls.Config, network, addr string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	_ = l.reloadRoots()
+	cfg := tc.Clone()
+	cfg.RootCAs = l.roots
+	l.mu.Unlock()
+
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+
+	conn, err := dial(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	tlsConn := tls.Client(conn, cfg)
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return tlsConn, nil
+}
+
+func (l *certLoader) loadCert() error {
+	switch {
+	case l.cfg.Certificate != nil:
+		l.cert = l.cfg.Certificate
+	case len(l.cfg.ClientCertPEM) > 0 || len(l.cfg.ClientKeyPEM) > 0:
+		cert, err := tls.X509KeyPair(l.cfg.ClientCertPEM, l.cfg.ClientKeyPEM)
+		if err != nil {
+			return fmt.Errorf("jira: load mtls client certificate: %w", err)
+		}
+		l.cert = &cert
+	case l.cfg.ClientCert != "" || l.cfg.ClientKey != "":
+		return l.reloadCert()
+	}
+	return nil
+}
+
+// reloadCert reads the key pair from files if they changed since the last read.
+func (l *certLoader) reloadCert() error {
+	mod, err := modTimes(l.cfg.ClientCert, l.cfg.ClientKey)
+	if err != nil {
+		return fmt.Errorf("jira: load mtls client certificate: %w", err)
+	}
+	if l.cert != nil && mod == l.certMod {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(l.cfg.ClientCert, l.cfg.ClientKey)
+	if err != nil {
+		return fmt.Errorf("jira: load mtls client certificate: %w", err)
+	}
+	l.cert, l.certMod = &cert, mod
+
+	return nil
+}
+
+func (l *certLoader) loadRoots() error {
+	if len(l.cfg.CaCertPEM) > 0 {
+		pool, err := l.rootPool(l.cfg.CaCertPEM)
+		if err != nil {
+			return err
+		}
+		l.roots = pool
+		return nil
+	}
+	if l.cfg.CaCert != "" {
+		return l.reloadRoots()
+	}
+	return nil
+}
+
+// reloadRoots reads the CA certificate from file if it changed since the last read.
+func (l *certLoader) reloadRoots() error {
+	mod, err := modTimes(l.cfg.CaCert)
+	if err != nil {
+		return fmt.Errorf("jira: load mtls CA cert: %w", err)
+	}
+	if l.roots != nil && mod[0] == l.rootMod {
+		return nil
+	}
+
+	data, err := os.ReadFile(l.cfg.CaCert)
+	if err != nil {
+		return fmt.Errorf("jira: load mtls CA cert: %w", err)
+	}
+	pool, err := l.rootPool(data)
+	if err != nil {
+		return err
+	}
+	l.roots, l.rootMod = pool, mod[0]
+
+	return nil
+}
+
+func (l *certLoader) rootPool(pem []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if l.cfg.UseSystemRoots {
+		sys, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, fmt.Errorf("jira: load system cert pool: %w", err)
+		}
+		pool = sys
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, ErrInvalidCACert
+	}
+	return pool, nil
+}
+
+func modTimes(paths ...string) ([2]time.Time, error) {
+	var out [2]time.Time
+	for i, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return out, err
+		}
+		out[i] = fi.ModTime()
+	}
+	return out, nil
+}
@@ -0,0 +1,226 @@
+package jira
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert creates a certificate signed by the parent, or a CA without
+// parent, for the given IPs, 127.0.0.1 by default.
+func newTestCert(t *testing.T, cn string, parent *testCert, ips ...string) *testCert {
+	if len(ips) == 0 {
+		ips = []string{"127.0.0.1"}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  make([]net.IP, 0, len(ips)),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	for _, ip := range ips {
+		tmpl.IPAddresses = append(tmpl.IPAddresses, net.ParseIP(ip))
+	}
+
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func newMTLSServer(t *testing.T, ca, serverCert *testCert, seen *string) *httptest.Server {
+	pair, err := tls.X509KeyPair(serverCert.certPEM, serverCert.keyPEM)
+	assert.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*seen = r.TLS.PeerCertificates[0].Subject.CommonName
+		w.WriteHeader(200)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+	}
+	server.StartTLS()
+
+	return server
+}
+
+func TestMTLSConfigErrors(t *testing.T) {
+	mtls := AuthTypeMTLS
+
+	_, err := New(Config{Server: "https://localhost", AuthType: &mtls, MTLSConfig: MTLSConfig{CaCert: "/does/not/exist"}})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = New(Config{Server: "https://localhost", AuthType: &mtls, MTLSConfig: MTLSConfig{CaCertPEM: []byte("invalid")}})
+	assert.ErrorIs(t, err, ErrInvalidCACert)
+
+	// NewClient doesn't exit, the error is returned by requests instead.
+	client := NewClient(Config{Server: "https://localhost", AuthType: &mtls, MTLSConfig: MTLSConfig{
+		ClientCertPEM: []byte("invalid"),
+		ClientKeyPEM:  []byte("invalid"),
+	}})
+
+	_, err = client.GetV2(context.Background(), "/myself", nil)
+	assert.ErrorContains(t, err, "load mtls client certificate")
+}
+
+func TestMTLSWithPEM(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+	client := newTestCert(t, "client", ca)
+
+	var seen string
+	server := newMTLSServer(t, ca, newTestCert(t, "server", ca), &seen)
+	defer server.Close()
+
+	mtls := AuthTypeMTLS
+	c, err := New(Config{Server: server.URL, AuthType: &mtls, MTLSConfig: MTLSConfig{
+		CaCertPEM:      ca.certPEM,
+		ClientCertPEM:  client.certPEM,
+		ClientKeyPEM:   client.keyPEM,
+		UseSystemRoots: true,
+	}}, WithTimeout(3*time.Second))
+	assert.NoError(t, err)
+
+	res, err := c.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+	assert.Equal(t, "client", seen)
+
+	// A parsed certificate can be used as well.
+	pair, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	assert.NoError(t, err)
+
+	c, err = New(Config{Server: server.URL, AuthType: &mtls, MTLSConfig: MTLSConfig{CaCertPEM: ca.certPEM, Certificate: &pair}})
+	assert.NoError(t, err)
+
+	res, err = c.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+}
+
+func TestMTLSReloadsRotatedFiles(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+
+	var seen string
+	server := newMTLSServer(t, ca, newTestCert(t, "server", ca), &seen)
+	defer server.Close()
+
+	dir := t.TempDir()
+	paths := MTLSConfig{
+		CaCert:     filepath.Join(dir, "ca.pem"),
+		ClientCert: filepath.Join(dir, "client.pem"),
+		ClientKey:  filepath.Join(dir, "client.key"),
+	}
+
+	write := func(cert *testCert, mod time.Time) {
+		assert.NoError(t, os.WriteFile(paths.ClientCert, cert.certPEM, 0o600))
+		assert.NoError(t, os.WriteFile(paths.ClientKey, cert.keyPEM, 0o600))
+		assert.NoError(t, os.Chtimes(paths.ClientCert, mod, mod))
+		assert.NoError(t, os.Chtimes(paths.ClientKey, mod, mod))
+	}
+
+	assert.NoError(t, os.WriteFile(paths.CaCert, ca.certPEM, 0o600))
+	write(newTestCert(t, "first", ca), time.Now().Add(-time.Minute))
+
+	mtls := AuthTypeMTLS
+	c, err := New(Config{Server: server.URL, AuthType: &mtls, MTLSConfig: paths}, WithTimeout(3*time.Second))
+	assert.NoError(t, err)
+
+	res, err := c.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+	assert.Equal(t, "first", seen)
+
+	write(newTestCert(t, "second", ca), time.Now())
+	c.transport.(*http.Transport).CloseIdleConnections()
+
+	res, err = c.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+	assert.Equal(t, "second", seen)
+
+	// The server certificate is still verified against the CA.
+	assert.NoError(t, os.WriteFile(paths.CaCert, newTestCert(t, "other", nil).certPEM, 0o600))
+	assert.NoError(t, os.Chtimes(paths.CaCert, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	c.transport.(*http.Transport).CloseIdleConnections()
+
+	_, err = c.GetV2(context.Background(), "/myself", nil)
+	assert.Error(t, err)
+}
+
+func TestMTLSVerifiesIPHost(t *testing.T) {
+	ca := newTestCert(t, "ca", nil)
+
+	var seen string
+	server := newMTLSServer(t, ca, newTestCert(t, "server", ca, "10.9.9.9"), &seen)
+	defer server.Close()
+
+	client := newTestCert(t, "client", ca)
+	paths := MTLSConfig{
+		CaCert:        filepath.Join(t.TempDir(), "ca.pem"),
+		ClientCertPEM: client.certPEM,
+		ClientKeyPEM:  client.keyPEM,
+	}
+	assert.NoError(t, os.WriteFile(paths.CaCert, ca.certPEM, 0o600))
+
+	mtls := AuthTypeMTLS
+	c, err := New(Config{Server: server.URL, AuthType: &mtls, MTLSConfig: paths}, WithTimeout(3*time.Second))
+	assert.NoError(t, err)
+
+	// The certificate is signed by the CA but issued for another IP.
+	_, err = c.GetV2(context.Background(), "/myself", nil)
+	var hostErr x509.HostnameError
+	assert.ErrorAs(t, err, &hostErr)
+	assert.Empty(t, seen)
+}
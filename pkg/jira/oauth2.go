@@ -0,0 +1,345 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// OAuth2TokenURL is the Atlassian OAuth 2.0 (3LO) token endpoint.
+	OAuth2TokenURL = "https://auth.atlassian.com/oauth/token"
+
+	oauth2GatewayURL = "https://api.atlassian.com/ex/jira/"
+
+	// tokenExpiryDelta is how early tokens are refreshed before they expire.
+	tokenExpiryDelta = 30 * time.Second
+)
+
+// accessibleResourcesURL lists the sites an OAuth 2.0 token has access to.
+var accessibleResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+
+var (
+	// ErrNoTokenSource denotes oauth2 auth type was used without a token source.
+	ErrNoTokenSource = fmt.Errorf("jira: oauth2 auth requires a token source")
+	// ErrNoToken denotes a token source that didn't return an access token.
+	ErrNoToken = fmt.Errorf("jira: oauth2 token source returned no access token")
+	// ErrNoRefreshToken denotes an expired token that can't be refreshed.
+	ErrNoRefreshToken = fmt.Errorf("jira: oauth2 token expired and has no refresh token")
+	// ErrCloudIDNotFound denotes the site isn't accessible with the token.
+	ErrCloudIDNotFound = fmt.Errorf("jira: no accessible resource found for the site")
+)
+
+// Token is an OAuth 2.0 access token.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the token is set and not about to expire.
+// Tokens without an expiry never expire.
+func (t *Token) Valid() bool {
+	if t == nil || t.AccessToken == "" {
+		return false
+	}
+	return t.Expiry.IsZero() || time.Now().Add(tokenExpiryDelta).Before(t.Expiry)
+}
+
+// TokenSource supplies OAuth 2.0 access tokens.
+//
+// Implementations must be safe for concurrent use. Tokens returned by a source
+// created with OAuth2Config.TokenSource are refreshed automatically.
+type TokenSource interface {
+	Token() (*Token, error)
+}
+
+// ContextTokenSource is a TokenSource that accepts the context of the
+// request that needs the token, eg: to cancel a refresh.
+type ContextTokenSource interface {
+	TokenSource
+	TokenContext(ctx context.Context) (*Token, error)
+}
+
+type staticTokenSource struct {
+	t *Token
+}
+
+// StaticTokenSource returns a source that always returns the same token.
+func StaticTokenSource(t *Token) TokenSource {
+	return staticTokenSource{t: t}
+}
+
+func (s staticTokenSource) Token() (*Token, error) {
+	return s.t, nil
+}
+
+// OAuth2Config holds the OAuth 2.0 (3LO) app credentials used to refresh tokens.
+type OAuth2Config struct {
+	ClientID     string
+	ClientSecret string
+	// TokenURL defaults to OAuth2TokenURL.
+	TokenURL string
+	// HTTPClient is used for token requests. Defaults to the http client of
+	// the jira client refreshing the token, or http.DefaultClient otherwise.
+	HTTPClient *http.Client
+	// OnRefresh is called with the new token after each refresh. Atlassian
+	// rotates refresh tokens, so the new token should be persisted.
+	OnRefresh func(*Token)
+}
+
+// TokenSource returns a source that returns the token until it expires and
+// refreshes it using its refresh token afterwards.
+func (c OAuth2Config) TokenSource(t *Token) TokenSource {
+	return &refreshTokenSource{conf: c, current: t}
+}
+
+type refreshTokenSource struct {
+	conf OAuth2Config
+
+	mu      sync.Mutex
+	current *Token
+}
+
+func (s *refreshTokenSource) Token() (*Token, error) {
+	return s.TokenContext(context.Background())
+}
+
+func (s *refreshTokenSource) TokenContext(ctx context.Context) (*Token, error) {
+	return s.token(ctx, nil)
+}
+
+// token returns the token, refreshing it with hc unless the config has an
+// http client.
+func (s *refreshTokenSource) token(ctx context.Context, hc *http.Client) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current.Valid() {
+		return s.current, nil
+	}
+	return s.refresh(ctx, hc)
+}
+
+// invalidate forces a refresh on next use, eg: when the token was revoked.
+func (s *refreshTokenSource) invalidate(rejected *Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Another request might have refreshed the token already.
+	if s.current == rejected && s.current != nil {
+		t := *s.current
+		t.AccessToken = ""
+		s.current = &t
+	}
+}
+
+func (s *refreshTokenSource) refresh(ctx context.Context, hc *http.Client) (*Token, error) {
+	if s.current == nil || s.current.RefreshToken == "" {
+		return nil, ErrNoRefreshToken
+	}
+
+	tokenURL := s.conf.TokenURL
+	if tokenURL == "" {
+		tokenURL = OAuth2TokenURL
+	}
+	if s.conf.HTTPClient != nil {
+		hc = s.conf.HTTPClient
+	}
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     s.conf.ClientID,
+		"client_secret": s.conf.ClientSecret,
+		"refresh_token": s.current.RefreshToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("jira: refresh oauth2 token: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, formatUnexpectedResponse(res)
+	}
+
+	var out struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+
+	t := Token{
+		AccessToken:  out.AccessToken,
+		RefreshToken: out.RefreshToken,
+		TokenType:    out.TokenType,
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = s.current.RefreshToken
+	}
+	if out.ExpiresIn > 0 {
+		t.Expiry = time.Now().Add(time.Duration(out.ExpiresIn) * time.Second)
+	}
+
+	s.current = &t
+	if s.conf.OnRefresh != nil {
+		s.conf.OnRefresh(&t)
+	}
+
+	return &t, nil
+}
+
+// OAuth2BaseURL returns the base URL used to access a site with OAuth 2.0 tokens.
+func OAuth2BaseURL(cloudID string) string {
+	return oauth2GatewayURL + cloudID
+}
+
+// AccessibleResource is a site an OAuth 2.0 token has access to.
+type AccessibleResource struct {
+	ID     string   `json:"id"`
+	URL    string   `json:"url"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// ResolveCloudID finds the cloud id of the site, eg: https://example.atlassian.net,
+// among the resources accessible with the token. Resources are requested with
+// the HTTPClient of the OAuth2Config of the source, if any.
+func ResolveCloudID(ctx context.Context, ts TokenSource, site string) (string, error) {
+	hc := http.DefaultClient
+	if rs, ok := ts.(*refreshTokenSource); ok && rs.conf.HTTPClient != nil {
+		hc = rs.conf.HTTPClient
+	}
+
+	t, err := tokenContext(ctx, ts, hc)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, accessibleResourcesURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+t.AccessToken)
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return "", formatUnexpectedResponse(res)
+	}
+
+	var resources []AccessibleResource
+	if err := json.NewDecoder(res.Body).Decode(&resources); err != nil {
+		return "", err
+	}
+
+	want, err := url.Parse(strings.TrimSuffix(site, "/"))
+	if err != nil {
+		return "", err
+	}
+	for _, r := range resources {
+		if u, err := url.Parse(r.URL); err == nil && strings.EqualFold(u.Host, want.Host) {
+			return r.ID, nil
+		}
+	}
+	return "", ErrCloudIDNotFound
+}
+
+// tokenContext returns a token of the source with the context, refreshing
+// it with hc unless its config has an http client.
+func tokenContext(ctx context.Context, ts TokenSource, hc *http.Client) (*Token, error) {
+	var (
+		t   *Token
+		err error
+	)
+
+	switch s := ts.(type) {
+	case nil:
+		return nil, ErrNoTokenSource
+	case *refreshTokenSource:
+		t, err = s.token(ctx, hc)
+	case ContextTokenSource:
+		t, err = s.TokenContext(ctx)
+	default:
+		t, err = ts.Token()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || t.AccessToken == "" {
+		return nil, ErrNoToken
+	}
+	return t, nil
+}
+
+// oauth2RoundTrip sends the request with an access token and retries it once
+// with a refreshed token if the server rejects the current one. Tokens are
+// refreshed with the transport of the client.
+func oauth2RoundTrip(ts TokenSource, next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if ts == nil {
+		return nil, ErrNoTokenSource
+	}
+	hc := &http.Client{Transport: next}
+
+	t, err := tokenContext(req.Context(), ts, hc)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+t.AccessToken)
+
+	res, err := next.RoundTrip(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+
+	rs, ok := ts.(*refreshTokenSource)
+	if !ok || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		return res, nil
+	}
+	rs.invalidate(t)
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return res, nil
+		}
+	}
+
+	t, err = tokenContext(req.Context(), ts, hc)
+	if err != nil {
+		return res, nil
+	}
+	discard(res)
+
+	retry.Header.Set("Authorization", "Bearer "+t.AccessToken)
+	return next.RoundTrip(retry)
+}
@@ -0,0 +1,216 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTokenServer(t *testing.T, refreshes *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "refresh_token", body["grant_type"])
+		assert.Equal(t, "client-id", body["client_id"])
+		assert.Equal(t, "client-secret", body["client_secret"])
+		assert.Equal(t, fmt.Sprintf("refresh-%d", *refreshes), body["refresh_token"])
+
+		*refreshes++
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "access-%d", "refresh_token": "refresh-%d", "token_type": "Bearer", "expires_in": 3600}`, *refreshes, *refreshes)
+	}))
+}
+
+func TestOAuth2RefreshesExpiredToken(t *testing.T) {
+	var refreshes int
+
+	tokenServer := newTokenServer(t, &refreshes)
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-1", r.Header.Get("Authorization"))
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var persisted *Token
+
+	ts := OAuth2Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		TokenURL:     tokenServer.URL,
+		OnRefresh:    func(t *Token) { persisted = t },
+	}.TokenSource(&Token{
+		AccessToken:  "access-0",
+		RefreshToken: "refresh-0",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+
+	oauth2 := AuthTypeOAuth2
+	client := NewClient(Config{Server: server.URL, AuthType: &oauth2, TokenSource: ts}, WithTimeout(3*time.Second))
+
+	for range 2 {
+		res, err := client.Get(context.Background(), "/myself", nil)
+		assert.NoError(t, err)
+		_ = res.Body.Close()
+	}
+
+	// The token is refreshed once and reused afterwards.
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, "refresh-1", persisted.RefreshToken)
+	assert.True(t, persisted.Valid())
+}
+
+func TestOAuth2RefreshesRejectedToken(t *testing.T) {
+	var refreshes int
+
+	tokenServer := newTokenServer(t, &refreshes)
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"body":"comment"}`, string(body))
+
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	ts := OAuth2Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		TokenURL:     tokenServer.URL,
+	}.TokenSource(&Token{AccessToken: "revoked", RefreshToken: "refresh-0"})
+
+	oauth2 := AuthTypeOAuth2
+	client := NewClient(Config{Server: server.URL, AuthType: &oauth2, TokenSource: ts}, WithTimeout(3*time.Second))
+
+	res, err := client.Post(context.Background(), "/issue/TEST-1/comment", []byte(`{"body":"comment"}`), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, res.StatusCode)
+	_ = res.Body.Close()
+	assert.Equal(t, 1, refreshes)
+
+	// Static tokens are never refreshed.
+	client = NewClient(Config{Server: server.URL, AuthType: &oauth2, TokenSource: StaticTokenSource(&Token{AccessToken: "revoked"})})
+
+	res, err = client.Post(context.Background(), "/issue/TEST-1/comment", []byte(`{"body":"comment"}`), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	_ = res.Body.Close()
+
+	// A token source is required.
+	client = NewClient(Config{Server: server.URL, AuthType: &oauth2})
+
+	_, err = client.Get(context.Background(), "/myself", nil)
+	assert.ErrorIs(t, err, ErrNoTokenSource)
+}
+
+func TestOAuth2CloudID(t *testing.T) {
+	oauth2 := AuthTypeOAuth2
+	client := NewClient(Config{Server: "https://example.atlassian.net", AuthType: &oauth2, CloudID: "cloud-123"})
+
+	assert.Equal(t, "https://api.atlassian.com/ex/jira/cloud-123", client.server)
+	assert.Equal(t, APIFamilyAgile, client.apiFamily(client.server+baseURLv1+"/board"))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "other-id", "url": "https://other.atlassian.net", "name": "other"},
+			{"id": "cloud-123", "url": "https://example.atlassian.net", "name": "example"}
+		]`))
+	}))
+	defer server.Close()
+
+	defer func(u string) { accessibleResourcesURL = u }(accessibleResourcesURL)
+	accessibleResourcesURL = server.URL
+
+	ts := StaticTokenSource(&Token{AccessToken: "access"})
+
+	id, err := ResolveCloudID(context.Background(), ts, "https://example.atlassian.net/")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloud-123", id)
+
+	_, err = ResolveCloudID(context.Background(), ts, "https://missing.atlassian.net")
+	assert.ErrorIs(t, err, ErrCloudIDNotFound)
+}
+
+func TestOAuth2RefreshUsesClientTransport(t *testing.T) {
+	var refreshes int
+
+	tokenServer := newTokenServer(t, &refreshes)
+	defer tokenServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var hosts []string
+	transport := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		hosts = append(hosts, req.URL.Host)
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	expired := &Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Minute)}
+	ts := OAuth2Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		TokenURL:     tokenServer.URL,
+	}.TokenSource(expired)
+
+	oauth2 := AuthTypeOAuth2
+	client := NewClient(Config{Server: server.URL, AuthType: &oauth2, TokenSource: ts}, WithTransport(transport))
+
+	// A cancelled request doesn't refresh the token.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Get(ctx, "/myself", nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 0, refreshes)
+
+	hosts = nil
+	res, err := client.Get(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	// The token is refreshed through the transport of the client.
+	assert.Equal(t, 1, refreshes)
+	assert.Equal(t, []string{tokenServer.Listener.Addr().String(), server.Listener.Addr().String()}, hosts)
+}
+
+func TestOAuth2RequiresAccessToken(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	oauth2 := AuthTypeOAuth2
+	for _, ts := range []TokenSource{StaticTokenSource(nil), StaticTokenSource(&Token{})} {
+		client := NewClient(Config{Server: server.URL, AuthType: &oauth2, TokenSource: ts})
+
+		_, err := client.ServerInfo()
+		assert.ErrorIs(t, err, ErrNoToken)
+
+		_, err = ResolveCloudID(context.Background(), ts, server.URL)
+		assert.ErrorIs(t, err, ErrNoToken)
+	}
+	assert.Equal(t, 0, requests)
+}
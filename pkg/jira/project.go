@@ -15,7 +15,12 @@ const (
 
 // Project fetches response from /project endpoint.
 func (c *Client) Project() ([]*Project, error) {
-	res, err := c.GetV2(context.Background(), "/project?expand=lead", nil)
+	return c.ProjectContext(context.Background())
+}
+
+// ProjectContext is the same as Project but accepts a context.
+func (c *Client) ProjectContext(ctx context.Context) ([]*Project, error) {
+	res, err := c.GetV2(ctx, "/project?expand=lead", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,128 @@
+package jira
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIFamily identifies a group of Jira REST endpoints sharing the same base path.
+type APIFamily string
+
+// API families.
+const (
+	// APIFamilyAgile represents /rest/agile/1.0 endpoints.
+	APIFamilyAgile APIFamily = "v1"
+	// APIFamilyV2 represents /rest/api/2 endpoints.
+	APIFamilyV2 APIFamily = "v2"
+	// APIFamilyV3 represents /rest/api/3 endpoints.
+	APIFamilyV3 APIFamily = "v3"
+)
+
+// RateLimiter is a token bucket limiter safe for concurrent use.
+//
+// A single limiter can be shared by multiple clients so that all
+// of them are throttled together.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter constructs a limiter allowing rps requests per second
+// on average with bursts of at most burst requests.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Wait blocks until a request is allowed or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return ctx.Err()
+	}
+
+	wait := l.reserve(time.Now())
+	if err := sleepContext(ctx, wait); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long the caller needs to wait for it.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns a reserved token that was not used.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(l.burst, l.tokens+1)
+}
+
+// WithRateLimiter is a functional opt to throttle requests using the given limiter.
+//
+// The limiter applies to all requests unless families are provided, in which case it
+// only applies to the requests sent to the given API families. A family specific limiter
+// takes precedence over the one that applies to all requests.
+func WithRateLimiter(l *RateLimiter, families ...APIFamily) ClientFunc {
+	return func(c *Client) {
+		if len(families) == 0 {
+			c.limiter = l
+			return
+		}
+		if c.familyLimiters == nil {
+			c.familyLimiters = make(map[APIFamily]*RateLimiter, len(families))
+		}
+		for _, f := range families {
+			c.familyLimiters[f] = l
+		}
+	}
+}
+
+func (c *Client) wait(ctx context.Context, endpoint string) error {
+	if l, ok := c.familyLimiters[c.apiFamily(endpoint)]; ok {
+		return l.Wait(ctx)
+	}
+	if c.limiter != nil {
+		return c.limiter.Wait(ctx)
+	}
+	return nil
+}
+
+func (c *Client) apiFamily(endpoint string) APIFamily {
+	path := strings.TrimPrefix(endpoint, c.server)
+
+	switch {
+	case strings.HasPrefix(path, baseURLv1):
+		return APIFamilyAgile
+	case strings.HasPrefix(path, baseURLv2):
+		return APIFamilyV2
+	default:
+		return APIFamilyV3
+	}
+}
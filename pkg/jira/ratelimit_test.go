@@ -0,0 +1,70 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := NewRateLimiter(10, 2)
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(now))
+	assert.Equal(t, 200*time.Millisecond, l.reserve(now))
+
+	// Tokens are refilled over time but never exceed the burst.
+	l = NewRateLimiter(10, 2)
+	_ = l.reserve(now)
+	_ = l.reserve(now)
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(time.Second)))
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(time.Second)))
+	assert.Equal(t, 100*time.Millisecond, l.reserve(now.Add(time.Second)))
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := NewRateLimiter(0.001, 1)
+	assert.NoError(t, l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestClientRateLimiterPerFamily(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	agile := NewRateLimiter(0.001, 1)
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithRateLimiter(agile, APIFamilyAgile))
+
+	assert.Equal(t, APIFamilyAgile, client.apiFamily(server.URL+baseURLv1+"/board"))
+	assert.Equal(t, APIFamilyV2, client.apiFamily(server.URL+baseURLv2+"/myself"))
+	assert.Equal(t, APIFamilyV3, client.apiFamily(server.URL+baseURLv3+"/issue"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	resp, err := client.GetV1(ctx, "/board", nil)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+
+	// The agile limiter is exhausted, other families are not throttled.
+	for range 5 {
+		resp, err = client.GetV2(ctx, "/myself", nil)
+		assert.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	_, err = client.GetV1(ctx, "/board", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -9,8 +9,13 @@ import (
 
 // Release fetches response from /project/{projectIdOrKey}/version endpoint.
 func (c *Client) Release(project string) ([]*ProjectVersion, error) {
+	return c.ReleaseContext(context.Background(), project)
+}
+
+// ReleaseContext is the same as Release but accepts a context.
+func (c *Client) ReleaseContext(ctx context.Context, project string) ([]*ProjectVersion, error) {
 	path := fmt.Sprintf("/project/%s/versions", project)
-	res, err := c.Get(context.Background(), path, nil)
+	res, err := c.Get(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,359 @@
+// Package replay provides a round tripper that records HTTP interactions with a
+// Jira server to a cassette file and replays them, so that code built on the
+// jira package can be tested deterministically without a live instance.
+//
+//	rec, err := replay.New("testdata/issue.json", replay.Options{Mode: replay.ModeAuto})
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer func() { _ = rec.Save() }()
+//
+//	client := jira.NewClient(config, jira.WithTransport(rec))
+//
+// Credentials are scrubbed before interactions are saved.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const redacted = "REDACTED"
+
+// sensitiveHeaders are always scrubbed from cassettes.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// ErrNoInteraction denotes a request without recorded interaction in replay mode.
+var ErrNoInteraction = fmt.Errorf("replay: no recorded interaction matches the request")
+
+// Mode decides whether interactions are recorded or replayed.
+type Mode int
+
+const (
+	// ModeReplay replays recorded interactions and fails on unknown requests.
+	ModeReplay Mode = iota
+	// ModeRecord sends all requests and records the interactions,
+	// replacing the existing cassette on save.
+	ModeRecord
+	// ModeAuto replays the cassette if it exists and records it otherwise.
+	ModeAuto
+)
+
+// Options configures a Recorder.
+type Options struct {
+	Mode Mode
+	// Transport sends the requests while recording. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// RedactHeaders are additional headers to scrub.
+	// Credentials and cookies are always scrubbed.
+	RedactHeaders []string
+	// RedactFields are query params and JSON body fields to scrub, eg: password.
+	// They are ignored when matching requests.
+	RedactFields []string
+}
+
+// Cassette holds recorded interactions.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is a round tripper recording or replaying interactions.
+// It's safe for concurrent use.
+type Recorder struct {
+	path string
+	mode Mode
+	opts Options
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     map[*Interaction]bool
+}
+
+// New creates a recorder for the cassette at path.
+// The cassette must exist in replay mode.
+func New(path string, opts Options) (*Recorder, error) {
+	r := Recorder{
+		path: path,
+		mode: opts.Mode,
+		opts: opts,
+		used: make(map[*Interaction]bool),
+	}
+	if r.opts.Transport == nil {
+		r.opts.Transport = http.DefaultTransport
+	}
+
+	if r.mode == ModeRecord {
+		return &r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err) && r.mode == ModeAuto:
+		r.mode = ModeRecord
+		return &r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("replay: parse %s: %w", path, err)
+	}
+	r.mode = ModeReplay
+
+	return &r, nil
+}
+
+// Recording reports whether the recorder sends and records requests.
+func (r *Recorder) Recording() bool {
+	return r.mode == ModeRecord
+}
+
+// Interactions returns the recorded interactions.
+func (r *Recorder) Interactions() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.cassette.Interactions)
+}
+
+// Save writes the recorded interactions to the cassette.
+// Nothing is written in replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// RoundTrip implements http.RoundTripper interface.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	res, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	in := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    r.scrubURL(req.URL),
+			Header: r.scrubHeader(req.Header),
+			Body:   r.scrubBody(body),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     r.scrubHeader(res.Header),
+			Body:       r.scrubBody(resBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &in)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+// replay returns the first unused interaction matching the request.
+// Interactions are reused once all matching ones were used, eg: for polling.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := r.matchKey(req.Method, req.URL, body)
+
+	var last *Interaction
+	for _, in := range r.cassette.Interactions {
+		u, err := url.Parse(in.Request.URL)
+		if err != nil || r.matchKey(in.Request.Method, u, []byte(in.Request.Body)) != key {
+			continue
+		}
+		if !r.used[in] {
+			r.used[in] = true
+			return in.Response.response(req), nil
+		}
+		last = in
+	}
+	if last != nil {
+		return last.Response.response(req), nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL.RequestURI())
+}
+
+// matchKey normalizes the parts of a request used for matching: the method,
+// the path, the sorted query and the JSON body with sorted keys.
+func (r *Recorder) matchKey(method string, u *url.URL, body []byte) string {
+	q := u.Query()
+	for k := range q {
+		if r.isSensitiveField(k) {
+			delete(q, k)
+		}
+	}
+
+	b := strings.TrimSpace(string(body))
+	var v any
+	if json.Unmarshal(body, &v) == nil {
+		if out, err := json.Marshal(r.scrubValue(v, true)); err == nil {
+			b = string(out)
+		}
+	}
+
+	return method + " " + u.Path + "?" + q.Encode() + "\n" + b
+}
+
+func (r *Recorder) isSensitiveField(name string) bool {
+	return slices.ContainsFunc(r.opts.RedactFields, func(f string) bool {
+		return strings.EqualFold(f, name)
+	})
+}
+
+func (r *Recorder) scrubURL(u *url.URL) string {
+	cp := *u
+	cp.User = nil
+
+	q := cp.Query()
+	for k := range q {
+		if r.isSensitiveField(k) {
+			q[k] = []string{redacted}
+		}
+	}
+	cp.RawQuery = q.Encode()
+
+	return cp.String()
+}
+
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		sensitive := func(s string) bool { return strings.EqualFold(s, k) }
+		if slices.ContainsFunc(sensitiveHeaders, sensitive) || slices.ContainsFunc(r.opts.RedactHeaders, sensitive) {
+			out[k] = []string{redacted}
+		}
+	}
+	return out
+}
+
+// scrubBody scrubs sensitive fields from JSON bodies.
+// Bodies that are not JSON are recorded as is.
+func (r *Recorder) scrubBody(body []byte) string {
+	var v any
+	if len(r.opts.RedactFields) > 0 && json.Unmarshal(body, &v) == nil {
+		if out, err := json.Marshal(r.scrubValue(v, false)); err == nil {
+			return string(out)
+		}
+	}
+	return string(body)
+}
+
+// scrubValue replaces sensitive fields, or drops them when matching requests.
+func (r *Recorder) scrubValue(v any, drop bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if r.isSensitiveField(k) {
+				if drop {
+					delete(val, k)
+				} else {
+					val[k] = redacted
+				}
+				continue
+			}
+			val[k] = r.scrubValue(item, drop)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = r.scrubValue(item, drop)
+		}
+	}
+	return v
+}
+
+func (res Response) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+		StatusCode:    res.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        res.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(res.Body)),
+		ContentLength: int64(len(res.Body)),
+		Request:       req,
+	}
+}
+
+// readBody reads the request body and restores it so that it can be sent.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
@@ -0,0 +1,126 @@
+package replay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/jira"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var requests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-session")
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			_, _ = w.Write([]byte(`{"version": "9.4.0", "deploymentType": "Server"}`))
+		case "/rest/api/2/issue/TEST-1":
+			_, _ = w.Write([]byte(`{"key": "TEST-1", "fields": {"summary": "Recorded"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassettes", "issue.json")
+
+	rec, err := New(path, Options{Mode: ModeAuto})
+	assert.NoError(t, err)
+	assert.True(t, rec.Recording())
+
+	client := jira.NewClient(jira.Config{
+		Server:   server.URL,
+		Login:    "user@example.com",
+		APIToken: "secret-token",
+	}, jira.WithTimeout(3*time.Second), jira.WithTransport(rec))
+
+	info, err := client.ServerInfo()
+	assert.NoError(t, err)
+	assert.Equal(t, "9.4.0", info.Version)
+
+	issue, err := client.GetIssueV2("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Recorded", issue.Fields.Summary)
+
+	assert.NoError(t, rec.Save())
+	assert.Len(t, rec.Interactions(), 2)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-token")
+	assert.NotContains(t, string(data), "secret-session")
+
+	// Interactions are replayed without the server, regardless of its URL.
+	server.Close()
+
+	rec, err = New(path, Options{Mode: ModeAuto})
+	assert.NoError(t, err)
+	assert.False(t, rec.Recording())
+
+	client = jira.NewClient(jira.Config{
+		Server:   "https://replay.example.com",
+		Login:    "user@example.com",
+		APIToken: "other-token",
+	}, jira.WithTimeout(3*time.Second), jira.WithTransport(rec))
+
+	issue, err = client.GetIssueV2("TEST-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Recorded", issue.Fields.Summary)
+
+	_, err = client.GetIssueV2("TEST-2")
+	assert.ErrorIs(t, err, ErrNoInteraction)
+	assert.Equal(t, 2, requests)
+}
+
+func TestReplayMatching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search.json")
+	cassette := `{"interactions": [
+		{
+			"request": {"method": "POST", "url": "https://test.atlassian.net/rest/api/3/search/jql?b=2&a=1", "body": "{\"jql\": \"project = TEST\", \"password\": \"REDACTED\", \"maxResults\": 1}"},
+			"response": {"status": 200, "body": "first"}
+		},
+		{
+			"request": {"method": "POST", "url": "https://test.atlassian.net/rest/api/3/search/jql?a=1&b=2", "body": "{\"maxResults\": 1, \"jql\": \"project = TEST\"}"},
+			"response": {"status": 200, "body": "second"}
+		}
+	]}`
+	assert.NoError(t, os.WriteFile(path, []byte(cassette), 0o600))
+
+	rec, err := New(path, Options{RedactFields: []string{"password"}})
+	assert.NoError(t, err)
+
+	send := func(method, target, body string) string {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		res, err := rec.RoundTrip(req)
+		if !assert.NoError(t, err) {
+			return ""
+		}
+		out, _ := io.ReadAll(res.Body)
+		return string(out)
+	}
+
+	// Query order, key order and redacted fields don't matter.
+	body := `{"jql":"project = TEST","maxResults":1,"password":"hunter2"}`
+	assert.Equal(t, "first", send(http.MethodPost, "https://other.example.com/rest/api/3/search/jql?a=1&b=2", body))
+	assert.Equal(t, "second", send(http.MethodPost, "https://other.example.com/rest/api/3/search/jql?b=2&a=1", body))
+	// The last match is reused once all were used.
+	assert.Equal(t, "second", send(http.MethodPost, "https://other.example.com/rest/api/3/search/jql?a=1&b=2", body))
+
+	_, err = rec.RoundTrip(httptest.NewRequest(http.MethodGet, "https://other.example.com/rest/api/3/search/jql?a=1&b=2", nil))
+	assert.ErrorIs(t, err, ErrNoInteraction)
+
+	_, err = New(filepath.Join(t.TempDir(), "missing.json"), Options{})
+	assert.Error(t, err)
+}
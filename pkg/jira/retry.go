@@ -0,0 +1,186 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryMinBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff = 30 * time.Second
+	defaultRetryMaxRetries = 3
+
+	headerRetryAfter     = "Retry-After"
+	headerRateLimitReset = "X-RateLimit-Reset"
+)
+
+// RetryPolicy configures automatic retries of throttled and transiently failed requests.
+//
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT and DELETE) are retried unless
+// RetryNonIdempotent is set. A request is retried on transport errors and on the
+// status codes listed in StatusCodes.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// MinBackoff is the base backoff duration. Defaults to 500ms.
+	MinBackoff time.Duration
+	// MaxBackoff caps both the computed backoff and the wait duration
+	// requested by the server. Defaults to 30s.
+	MaxBackoff time.Duration
+	// StatusCodes are the response codes that are retried.
+	// Defaults to 429, 502, 503 and 504.
+	StatusCodes []int
+	// RetryNonIdempotent allows retrying POST requests.
+	RetryNonIdempotent bool
+	// OnRetry is called before waiting for the next attempt.
+	OnRetry func(RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried.
+type RetryAttempt struct {
+	// Attempt is the number of the upcoming retry, starting from 1.
+	Attempt    int
+	Method     string
+	URL        string
+	StatusCode int
+	Err        error
+	Wait       time.Duration
+}
+
+// DefaultRetryPolicy returns a retry policy with sensible defaults for Jira cloud.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:  defaultRetryMaxRetries,
+		MinBackoff:  defaultRetryMinBackoff,
+		MaxBackoff:  defaultRetryMaxBackoff,
+		StatusCodes: defaultRetryStatusCodes(),
+	}
+}
+
+// WithRetryPolicy is a functional opt to retry failed requests using the given policy.
+func WithRetryPolicy(p RetryPolicy) ClientFunc {
+	return func(c *Client) {
+		if p.MinBackoff <= 0 {
+			p.MinBackoff = defaultRetryMinBackoff
+		}
+		if p.MaxBackoff <= 0 {
+			p.MaxBackoff = defaultRetryMaxBackoff
+		}
+		if p.StatusCodes == nil {
+			p.StatusCodes = defaultRetryStatusCodes()
+		}
+		c.retry = &p
+	}
+}
+
+// RetryCount returns the total number of retries performed by the client.
+func (c *Client) RetryCount() uint64 {
+	return c.retries.Load()
+}
+
+func defaultRetryStatusCodes() []int {
+	return []int{
+		http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	}
+}
+
+// shouldRetry reports whether the given attempt needs to be retried and how long to wait.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, res *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(method) {
+		return 0, false
+	}
+	if err != nil {
+		return p.backoff(attempt), true
+	}
+	if res == nil || !slices.Contains(p.StatusCodes, res.StatusCode) {
+		return 0, false
+	}
+	if wait, ok := serverWait(res.Header, time.Now()); ok {
+		return min(wait, p.MaxBackoff), true
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff computes exponential backoff with equal jitter for the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for range attempt {
+		d *= 2
+		if d >= p.MaxBackoff {
+			d = p.MaxBackoff
+			break
+		}
+	}
+	half := d / 2
+	return half + rand.N(half+1) //nolint:gosec
+}
+
+// serverWait extracts the wait duration requested by the server
+// using Retry-After and X-RateLimit-Reset headers.
+func serverWait(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := strings.TrimSpace(h.Get(headerRetryAfter)); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return max(time.Duration(secs)*time.Second, 0), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+	if v := strings.TrimSpace(h.Get(headerRateLimitReset)); v != "" {
+		// Jira cloud sends an ISO 8601 timestamp, eg: 2024-01-02T15:04Z.
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return max(t.Sub(now), 0), true
+			}
+		}
+		if epoch, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return max(time.Unix(epoch, 0).Sub(now), 0), true
+		}
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// discard drains and closes the response body so that the connection can be reused.
+func discard(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+}
@@ -0,0 +1,151 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestRetry(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var attempts []RetryAttempt
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithRetryPolicy(RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		OnRetry: func(a RetryAttempt) {
+			attempts = append(attempts, a)
+		},
+	}))
+
+	resp, err := client.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, uint64(2), client.RetryCount())
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, 1, attempts[0].Attempt)
+	assert.Equal(t, http.MethodGet, attempts[0].Method)
+	assert.Equal(t, 429, attempts[0].StatusCode)
+	assert.Equal(t, time.Duration(0), attempts[0].Wait)
+}
+
+func TestRequestRetryExhausted(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithRetryPolicy(RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+	}))
+
+	_, err := client.Me()
+	assert.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, uint64(2), client.RetryCount())
+}
+
+func TestRequestRetrySkipsNonIdempotent(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(503)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithRetryPolicy(RetryPolicy{
+		MaxRetries: 2,
+		MinBackoff: time.Millisecond,
+	}))
+
+	resp, err := client.PostV2(context.Background(), "/issue", []byte("{}"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, uint64(0), client.RetryCount())
+}
+
+func TestServerWait(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 4, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		header http.Header
+		wait   time.Duration
+		ok     bool
+	}{
+		{
+			name:   "retry-after seconds",
+			header: http.Header{"Retry-After": []string{"5"}},
+			wait:   5 * time.Second,
+			ok:     true,
+		},
+		{
+			name:   "retry-after http date",
+			header: http.Header{"Retry-After": []string{"Tue, 02 Jan 2024 15:04:10 GMT"}},
+			wait:   10 * time.Second,
+			ok:     true,
+		},
+		{
+			name:   "rate limit reset iso 8601",
+			header: http.Header{"X-Ratelimit-Reset": []string{"2024-01-02T15:05Z"}},
+			wait:   time.Minute,
+			ok:     true,
+		},
+		{
+			name:   "rate limit reset in the past",
+			header: http.Header{"X-Ratelimit-Reset": []string{"2024-01-02T15:00:00Z"}},
+			wait:   0,
+			ok:     true,
+		},
+		{
+			name:   "no headers",
+			header: http.Header{},
+			ok:     false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wait, ok := serverWait(tc.header, now)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.wait, wait)
+		})
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt := range 6 {
+		d := p.backoff(attempt)
+		assert.LessOrEqual(t, d, time.Second)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+	}
+}
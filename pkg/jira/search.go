@@ -4,12 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
+
+	"github.com/eliziario/jira-lib/pkg/jira/filter"
+	"github.com/eliziario/jira-lib/pkg/jira/filter/search"
 )
 
 // SearchResult struct holds response from /search endpoint.
+//
+// The cloud /search/jql endpoint doesn't return the total, so Total is zero for
+// v3 searches. Use IsLast and NextPageToken to paginate and Client.Count to get
+// the number of matching issues.
 type SearchResult struct {
 	StartAt       int      `json:"startAt"`
 	MaxResults    int      `json:"maxResults"`
@@ -17,47 +25,230 @@ type SearchResult struct {
 	Issues        []*Issue `json:"issues"`
 	NextPageToken string   `json:"nextPageToken,omitempty"` // New field for cloud pagination
 	IsLast        bool     `json:"isLast,omitempty"`        // New field to indicate last page
+
+	// Names and Schema are only populated when expanded.
+	Names  map[string]string      `json:"names,omitempty"`
+	Schema map[string]interface{} `json:"schema,omitempty"`
+
+	// BoundedJQL is the query sent to the server when the original query
+	// was rewritten by the JQL bounding policy. It is empty otherwise.
+	BoundedJQL string `json:"-"`
 }
 
 // Search searches for issues using v3 version of the Jira GET /search endpoint.
-func (c *Client) Search(jql string, from, limit uint) (*SearchResult, error) {
-	return c.search(jql, from, limit, apiVersion3)
+//
+// The cloud /search/jql endpoint is paginated with page tokens, so the first
+// from issues are skipped by requesting their ids. Prefer SearchPage or
+// SearchIter to paginate over large results.
+//
+// Use filters from the search filter package to select fields, expand options,
+// properties and query validation. All fields are requested by default.
+func (c *Client) Search(jql string, from, limit uint, opts ...filter.Filter) (*SearchResult, error) {
+	return c.SearchContext(context.Background(), jql, from, limit, opts...)
+}
+
+// SearchContext is the same as Search but accepts a context.
+func (c *Client) SearchContext(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*SearchResult, error) {
+	return c.search(ctx, jql, from, limit, apiVersion3, opts)
 }
 
 // SearchV2 searches an issues using v2 version of the Jira GET /search endpoint.
-func (c *Client) SearchV2(jql string, from, limit uint) (*SearchResult, error) {
-	return c.search(jql, from, limit, apiVersion2)
+//
+// Use filters from the search filter package to select fields, expand options,
+// properties and query validation. Navigable fields are requested by default.
+func (c *Client) SearchV2(jql string, from, limit uint, opts ...filter.Filter) (*SearchResult, error) {
+	return c.SearchV2Context(context.Background(), jql, from, limit, opts...)
+}
+
+// SearchV2Context is the same as SearchV2 but accepts a context.
+func (c *Client) SearchV2Context(ctx context.Context, jql string, from, limit uint, opts ...filter.Filter) (*SearchResult, error) {
+	return c.search(ctx, jql, from, limit, apiVersion2, opts)
+}
+
+// SearchPage fetches a single page of issues using the Jira cloud GET /search/jql endpoint.
+//
+// Pass an empty pageToken to fetch the first page and the NextPageToken of the
+// previous result to fetch the following pages.
+func (c *Client) SearchPage(ctx context.Context, jql, pageToken string, limit uint, opts ...filter.Filter) (*SearchResult, error) {
+	bounded, err := c.bounding.apply(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/search/jql?jql=%s&maxResults=%d", url.QueryEscape(bounded), limit)
+	path += searchParams(opts, apiVersion3)
+	if pageToken != "" {
+		path += fmt.Sprintf("&nextPageToken=%s", url.QueryEscape(pageToken))
+	}
+
+	out, err := c.fetchSearch(ctx, path, apiVersion3)
+	if err != nil {
+		return nil, err
+	}
+	if bounded != jql {
+		out.BoundedJQL = bounded
+	}
+	return out, nil
 }
 
-func (c *Client) search(jql string, from, limit uint, ver string) (*SearchResult, error) {
+// SearchIter iterates over all issues matching the jql using the Jira cloud
+// GET /search/jql endpoint. Pages of size pageSize are requested lazily by
+// following nextPageToken, so the issues are never buffered all at once.
+//
+// Iteration stops on the first error, which is yielded with a nil issue,
+// including when the context is cancelled.
+func (c *Client) SearchIter(ctx context.Context, jql string, pageSize uint, opts ...filter.Filter) iter.Seq2[*Issue, error] {
+	return func(yield func(*Issue, error) bool) {
+		var token string
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			res, err := c.SearchPage(ctx, jql, token, pageSize, opts...)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, iss := range res.Issues {
+				if !yield(iss, nil) {
+					return
+				}
+			}
+			if res.IsLast || res.NextPageToken == "" || len(res.Issues) == 0 {
+				return
+			}
+			token = res.NextPageToken
+		}
+	}
+}
+
+// SearchIterV2 iterates over all issues matching the jql using v2 version
+// of the Jira GET /search endpoint. Pages of size pageSize are requested
+// lazily using startAt.
+//
+// Iteration stops on the first error, which is yielded with a nil issue,
+// including when the context is cancelled.
+func (c *Client) SearchIterV2(ctx context.Context, jql string, pageSize uint, opts ...filter.Filter) iter.Seq2[*Issue, error] {
+	return func(yield func(*Issue, error) bool) {
+		var from uint
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			res, err := c.search(ctx, jql, from, pageSize, apiVersion2, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, iss := range res.Issues {
+				if !yield(iss, nil) {
+					return
+				}
+			}
+
+			from += uint(len(res.Issues))
+			if len(res.Issues) == 0 || from >= uint(res.Total) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Client) search(ctx context.Context, jql string, from, limit uint, ver string, opts filter.Collection) (*SearchResult, error) {
 	var (
-		res *http.Response
-		err error
+		path    string
+		bounded = jql
+		err     error
 	)
 
-	// For cloud instances, use the new /search/jql endpoint
-	// The new endpoint requires bounded queries, so we add a default bound if missing
 	if ver == apiVersion3 {
-		// Check if JQL is bounded (has restrictions like created >= -Xd, project = X, etc.)
-		// If not bounded, add a default restriction to avoid "Unbounded JQL queries are not allowed" error
-		if !isJQLBounded(jql) {
-			// Add a default bound - issues created in last 90 days
-			if jql == "" {
-				jql = "created >= -90d"
-			} else {
-				jql = fmt.Sprintf("created >= -90d AND (%s)", jql)
+		// The new /search/jql endpoint rejects unbounded queries.
+		bounded, err = c.bounding.apply(jql)
+		if err != nil {
+			return nil, err
+		}
+
+		// For cloud instances, use the new /search/jql endpoint. It doesn't
+		// support offsets, so the first issues are skipped following page tokens.
+		token, ok, err := c.skipIssues(ctx, bounded, from)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			out := SearchResult{StartAt: int(from), MaxResults: int(limit), Issues: []*Issue{}, IsLast: true}
+			if bounded != jql {
+				out.BoundedJQL = bounded
 			}
+			return &out, nil
+		}
+		path = fmt.Sprintf("/search/jql?jql=%s&maxResults=%d", url.QueryEscape(bounded), limit)
+		if token != "" {
+			path += fmt.Sprintf("&nextPageToken=%s", url.QueryEscape(token))
 		}
-		
-		// Use the new search/jql endpoint with fields=*all to get all fields
-		path := fmt.Sprintf("/search/jql?jql=%s&startAt=%d&maxResults=%d&fields=*all", 
-			url.QueryEscape(jql), from, limit)
-		res, err = c.Get(context.Background(), path, nil)
 	} else {
-		// For v2 (server/datacenter), use the old endpoint
-		path := fmt.Sprintf("/search?jql=%s&startAt=%d&maxResults=%d", 
+		// For v2 (server/datacenter), use the old endpoint.
+		path = fmt.Sprintf("/search?jql=%s&startAt=%d&maxResults=%d",
 			url.QueryEscape(jql), from, limit)
-		res, err = c.GetV2(context.Background(), path, nil)
+	}
+	path += searchParams(opts, ver)
+
+	out, err := c.fetchSearch(ctx, path, ver)
+	if err != nil {
+		return nil, err
+	}
+	if ver == apiVersion3 {
+		out.StartAt = int(from)
+	}
+	if bounded != jql {
+		out.BoundedJQL = bounded
+	}
+
+	return out, nil
+}
+
+// skipIssues follows the page tokens of the /search/jql endpoint past the
+// first n issues matching the bounded jql, only requesting their ids. It
+// returns the token of the page starting after them, which is empty when n
+// is 0, and false when there are no issues after them.
+func (c *Client) skipIssues(ctx context.Context, bounded string, n uint) (string, bool, error) {
+	var token string
+
+	for skipped := uint(0); skipped < n; {
+		path := fmt.Sprintf("/search/jql?jql=%s&maxResults=%d&fields=id", url.QueryEscape(bounded), n-skipped)
+		if token != "" {
+			path += fmt.Sprintf("&nextPageToken=%s", url.QueryEscape(token))
+		}
+
+		out, err := c.fetchSearch(ctx, path, apiVersion3)
+		if err != nil {
+			return "", false, err
+		}
+		if out.IsLast || out.NextPageToken == "" || len(out.Issues) == 0 {
+			return "", false, nil
+		}
+		skipped += uint(len(out.Issues))
+		token = out.NextPageToken
+	}
+
+	return token, true, nil
+}
+
+func (c *Client) fetchSearch(ctx context.Context, path, ver string) (*SearchResult, error) {
+	var (
+		res *http.Response
+		err error
+	)
+
+	if ver == apiVersion3 {
+		res, err = c.Get(ctx, path, nil)
+	} else {
+		res, err = c.GetV2(ctx, path, nil)
 	}
 
 	if err != nil {
@@ -73,43 +264,35 @@ func (c *Client) search(jql string, from, limit uint, ver string) (*SearchResult
 	}
 
 	var out SearchResult
-	err = json.NewDecoder(res.Body).Decode(&out)
-	if err != nil {
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
 		return nil, err
 	}
-
-	// For the new endpoint, Total might not be provided, calculate it from response
-	if ver == apiVersion3 && out.Total == 0 && len(out.Issues) > 0 {
-		// If IsLast is true, total is startAt + number of issues
-		if out.IsLast {
-			out.Total = int(from) + len(out.Issues)
-		} else {
-			// Otherwise, we don't know the exact total, set to a large number
-			out.Total = 10000 // This is a reasonable upper bound
-		}
-	}
-
-	return &out, err
+	return &out, nil
 }
 
-// isJQLBounded checks if a JQL query has sufficient restrictions for the new API
-func isJQLBounded(jql string) bool {
-	// Check for common bounding conditions
-	boundingTerms := []string{
-		"created >=", "created >", "created =", "created <=", "created <",
-		"updated >=", "updated >", "updated =", "updated <=", "updated <",
-		"project =", "project in", "project IN",
-		"id =", "id in", "id IN",
-		"key =", "key in", "key IN",
-		"issuekey =", "issuekey in", "issuekey IN",
-	}
-	
-	jqlLower := strings.ToLower(jql)
-	for _, term := range boundingTerms {
-		if strings.Contains(jqlLower, strings.ToLower(term)) {
-			return true
-		}
+// searchParams builds optional query params for search requests.
+//
+// The new /search/jql endpoint only returns issue ids by default,
+// so all fields are requested unless fields are explicitly selected.
+func searchParams(opts filter.Collection, ver string) string {
+	var params strings.Builder
+
+	fields := opts.GetStrings(search.KeySearchFields)
+	if len(fields) == 0 && ver == apiVersion3 {
+		fields = []string{"*all"}
 	}
-	
-	return false
+	if len(fields) > 0 {
+		params.WriteString("&fields=" + url.QueryEscape(strings.Join(fields, ",")))
+	}
+	if expand := opts.GetStrings(search.KeySearchExpand); len(expand) > 0 {
+		params.WriteString("&expand=" + url.QueryEscape(strings.Join(expand, ",")))
+	}
+	if props := opts.GetStrings(search.KeySearchProperties); len(props) > 0 {
+		params.WriteString("&properties=" + url.QueryEscape(strings.Join(props, ",")))
+	}
+	if mode := opts.GetString(search.KeySearchValidateQuery); mode != "" {
+		params.WriteString("&validateQuery=" + url.QueryEscape(mode))
+	}
+
+	return params.String()
 }
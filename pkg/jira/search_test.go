@@ -2,6 +2,8 @@
 package jira
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -10,6 +12,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/eliziario/jira-lib/pkg/jira/filter/search"
 )
 
 func TestSearch(t *testing.T) {
@@ -22,7 +26,7 @@ func TestSearch(t *testing.T) {
 		if apiVersion2 {
 			assert.Equal(t, "/rest/api/2/search", r.URL.Path)
 		} else {
-			assert.Equal(t, "/rest/api/3/search", r.URL.Path)
+			assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
 		}
 
 		qs := r.URL.Query()
@@ -32,8 +36,8 @@ func TestSearch(t *testing.T) {
 		} else {
 			assert.Equal(t, url.Values{
 				"jql":        []string{"project=TEST AND status=Done ORDER BY created DESC"},
-				"startAt":    []string{"0"},
 				"maxResults": []string{"100"},
+				"fields":     []string{"*all"},
 			}, qs)
 
 			resp, err := os.ReadFile("./testdata/search.json")
@@ -141,3 +145,291 @@ func TestSearch(t *testing.T) {
 	_, err = client.SearchV2("project=TEST", 0, 100)
 	assert.Error(t, &ErrUnexpectedResponse{}, err)
 }
+
+func TestSearchFrom(t *testing.T) {
+	var pages []url.Values
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+
+		qs := r.URL.Query()
+		assert.Empty(t, qs.Get("startAt"))
+		pages = append(pages, qs)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch qs.Get("nextPageToken") {
+		case "":
+			_, _ = w.Write([]byte(`{"issues": [{"id": "1"}, {"id": "2"}], "nextPageToken": "page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"issues": [{"id": "3"}], "nextPageToken": "page-3"}`))
+		case "page-3":
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-4"}], "isLast": true}`))
+		default:
+			t.Errorf("unexpected page token: %s", qs.Get("nextPageToken"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	actual, err := client.Search("project=TEST", 3, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, &SearchResult{StartAt: 3, Issues: []*Issue{{Key: "TEST-4"}}, IsLast: true}, actual)
+
+	// The skipped issues are requested without fields.
+	assert.Len(t, pages, 3)
+	assert.Equal(t, []string{"3", "1", "10"}, []string{pages[0].Get("maxResults"), pages[1].Get("maxResults"), pages[2].Get("maxResults")})
+	assert.Equal(t, []string{"id", "id", "*all"}, []string{pages[0].Get("fields"), pages[1].Get("fields"), pages[2].Get("fields")})
+
+	// There are no issues after the skipped ones.
+	pages = nil
+	actual, err = client.Search("project=TEST", 5, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, actual.Issues)
+	assert.True(t, actual.IsLast)
+	assert.Equal(t, 0, actual.Total)
+	assert.Len(t, pages, 3)
+}
+
+func TestSearchIter(t *testing.T) {
+	var calls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+
+		qs := r.URL.Query()
+		assert.Equal(t, "2", qs.Get("maxResults"))
+		assert.Empty(t, qs.Get("startAt"))
+
+		calls++
+
+		w.Header().Set("Content-Type", "application/json")
+		switch qs.Get("nextPageToken") {
+		case "":
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-1"}, {"key": "TEST-2"}], "nextPageToken": "page-2"}`))
+		case "page-2":
+			_, _ = w.Write([]byte(`{"issues": [{"key": "TEST-3"}], "isLast": true}`))
+		default:
+			t.Errorf("unexpected page token: %s", qs.Get("nextPageToken"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	var keys []string
+	for iss, err := range client.SearchIter(context.Background(), "project=TEST", 2) {
+		assert.NoError(t, err)
+		keys = append(keys, iss.Key)
+	}
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "TEST-3"}, keys)
+	assert.Equal(t, 2, calls)
+
+	// Breaking early doesn't fetch the following pages.
+	calls = 0
+	for range client.SearchIter(context.Background(), "project=TEST", 2) {
+		break
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestSearchIterV2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/search", r.URL.Path)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			_, _ = w.Write([]byte(`{"startAt": 0, "total": 3, "issues": [{"key": "TEST-1"}, {"key": "TEST-2"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"startAt": 2, "total": 3, "issues": [{"key": "TEST-3"}]}`))
+		default:
+			t.Errorf("unexpected offset: %s", r.URL.Query().Get("startAt"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	var keys []string
+	for iss, err := range client.SearchIterV2(context.Background(), "project=TEST", 2) {
+		assert.NoError(t, err)
+		keys = append(keys, iss.Key)
+	}
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "TEST-3"}, keys)
+}
+
+func TestSearchIterStopsOnCancel(t *testing.T) {
+	var calls int
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"issues": [{"key": "TEST-%d"}], "nextPageToken": "page-%d"}`, calls, calls+1)
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	var (
+		keys    []string
+		lastErr error
+	)
+	for iss, err := range client.SearchIter(ctx, "project=TEST", 1) {
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		keys = append(keys, iss.Key)
+		if len(keys) == 2 {
+			cancel()
+		}
+	}
+	assert.Equal(t, []string{"TEST-1", "TEST-2"}, keys)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSearchWithOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		qs := r.URL.Query()
+
+		if r.URL.Path == "/rest/api/2/search" {
+			assert.Equal(t, "summary,status", qs.Get("fields"))
+			assert.Equal(t, "warn", qs.Get("validateQuery"))
+		} else {
+			assert.Equal(t, "/rest/api/3/search/jql", r.URL.Path)
+			assert.Equal(t, "*all", qs.Get("fields"))
+		}
+		assert.Equal(t, "names,schema", qs.Get("expand"))
+		assert.Equal(t, "prop1,prop2", qs.Get("properties"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total": 1, "issues": [{"key": "TEST-1"}], "names": {"summary": "Summary"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	actual, err := client.SearchV2(
+		"project=TEST", 0, 10,
+		search.NewFieldsFilter("summary", "status"),
+		search.NewExpandFilter(ExpandNames, ExpandSchema),
+		search.NewPropertiesFilter("prop1", "prop2"),
+		search.NewValidateQueryFilter(search.ValidateQueryWarn),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"summary": "Summary"}, actual.Names)
+
+	_, err = client.Search(
+		"project=TEST", 0, 10,
+		search.NewExpandFilter(ExpandNames, ExpandSchema),
+		search.NewPropertiesFilter("prop1", "prop2"),
+	)
+	assert.NoError(t, err)
+}
+
+func TestSearchJQLBounding(t *testing.T) {
+	var gotJQL string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotJQL = r.URL.Query().Get("jql")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(200)
+		_, _ = w.Write([]byte(`{"issues": [], "isLast": true}`))
+	}))
+	defer server.Close()
+
+	// Queries are sent as is by default.
+	client := NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second))
+
+	actual, err := client.Search("ORDER BY created DESC", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "ORDER BY created DESC", gotJQL)
+	assert.Empty(t, actual.BoundedJQL)
+
+	// Unbounded queries are rejected without hitting the server.
+	gotJQL = ""
+	client = NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithJQLBoundingPolicy(JQLBoundingPolicy{
+		Mode: JQLBoundingError,
+	}))
+
+	_, err = client.Search("ORDER BY created DESC", 0, 10)
+	assert.ErrorIs(t, err, ErrUnboundedJQL)
+	assert.Empty(t, gotJQL)
+
+	_, err = client.SearchPage(context.Background(), "", "", 10)
+	assert.ErrorIs(t, err, ErrUnboundedJQL)
+
+	_, err = client.Search("project = TEST ORDER BY created DESC", 0, 10)
+	assert.NoError(t, err)
+
+	// Unbounded queries are restricted to the configured window.
+	client = NewClient(Config{Server: server.URL}, WithTimeout(3*time.Second), WithJQLBoundingPolicy(JQLBoundingPolicy{
+		Mode:   JQLBoundingAuto,
+		Window: 7 * 24 * time.Hour,
+		Field:  "updated",
+	}))
+
+	actual, err = client.Search("ORDER BY created DESC", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "updated >= -7d ORDER BY created DESC", gotJQL)
+	assert.Equal(t, "updated >= -7d ORDER BY created DESC", actual.BoundedJQL)
+
+	actual, err = client.SearchPage(context.Background(), "project = TEST", "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, "project = TEST", gotJQL)
+	assert.Empty(t, actual.BoundedJQL)
+}
+
+func TestJQLBoundingPolicyApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		policy   JQLBoundingPolicy
+		input    string
+		expected string
+	}{
+		{
+			name:     "defaults",
+			policy:   JQLBoundingPolicy{Mode: JQLBoundingAuto},
+			input:    "order by key desc",
+			expected: "created >= -90d order by key desc",
+		},
+		{
+			name:     "empty query",
+			policy:   JQLBoundingPolicy{Mode: JQLBoundingAuto},
+			input:    "",
+			expected: "created >= -90d",
+		},
+		{
+			name:     "hours",
+			policy:   JQLBoundingPolicy{Mode: JQLBoundingAuto, Window: 36 * time.Hour},
+			input:    "()",
+			expected: "created >= -36h",
+		},
+		{
+			name:     "minutes",
+			policy:   JQLBoundingPolicy{Mode: JQLBoundingAuto, Window: 90 * time.Second},
+			input:    "ORDER BY created",
+			expected: "created >= -2m ORDER BY created",
+		},
+		{
+			name:     "bounded",
+			policy:   JQLBoundingPolicy{Mode: JQLBoundingAuto},
+			input:    `summary ~ "order by" ORDER BY key`,
+			expected: `summary ~ "order by" ORDER BY key`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.policy.apply(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -27,8 +27,13 @@ type SprintResult struct {
 //
 // qp is an additional query parameters in key, value pair format, eg: state=closed.
 func (c *Client) Sprints(boardID int, qp string, from, limit int) (*SprintResult, error) {
+	return c.SprintsContext(context.Background(), boardID, qp, from, limit)
+}
+
+// SprintsContext is the same as Sprints but accepts a context.
+func (c *Client) SprintsContext(ctx context.Context, boardID int, qp string, from, limit int) (*SprintResult, error) {
 	res, err := c.GetV1(
-		context.Background(),
+		ctx,
 		fmt.Sprintf("/board/%d/sprint?%s&startAt=%d&maxResults=%d", boardID, qp, from, limit),
 		nil,
 	)
@@ -53,8 +58,13 @@ func (c *Client) Sprints(boardID int, qp string, from, limit int) (*SprintResult
 
 // GetSprint returns a single sprint given an ID.
 func (c *Client) GetSprint(sprintID int) (*Sprint, error) {
+	return c.GetSprintContext(context.Background(), sprintID)
+}
+
+// GetSprintContext is the same as GetSprint but accepts a context.
+func (c *Client) GetSprintContext(ctx context.Context, sprintID int) (*Sprint, error) {
 	res, err := c.GetV1(
-		context.Background(),
+		ctx,
 		fmt.Sprintf("/sprint/%d", sprintID),
 		nil,
 	)
@@ -83,8 +93,13 @@ func (c *Client) GetSprint(sprintID int) (*Sprint, error) {
 // full updates the sprint with new status of closed.
 // Default behavior is all open tasks are sent to backlog.
 func (c *Client) EndSprint(sprintID int) error {
+	return c.EndSprintContext(context.Background(), sprintID)
+}
+
+// EndSprintContext is the same as EndSprint but accepts a context.
+func (c *Client) EndSprintContext(ctx context.Context, sprintID int) error {
 	// get the sprint
-	sprint, err := c.GetSprint(sprintID)
+	sprint, err := c.GetSprintContext(ctx, sprintID)
 	if err != nil {
 		return err
 	}
@@ -100,7 +115,7 @@ func (c *Client) EndSprint(sprintID int) error {
 	}
 
 	res, err := c.PutV1(
-		context.Background(),
+		ctx,
 		fmt.Sprintf("/sprint/%d", sprintID),
 		body,
 		Header{
@@ -127,12 +142,17 @@ func (c *Client) EndSprint(sprintID int) error {
 //
 // qp is an additional query parameters in key, value pair format, eg: state=closed.
 func (c *Client) SprintsInBoards(boardIDs []int, qp string, limit int) []*Sprint {
+	return c.SprintsInBoardsContext(context.Background(), boardIDs, qp, limit)
+}
+
+// SprintsInBoardsContext is the same as SprintsInBoards but accepts a context.
+func (c *Client) SprintsInBoardsContext(ctx context.Context, boardIDs []int, qp string, limit int) []*Sprint {
 	n := len(boardIDs)
 	ch := make(chan []*Sprint, n)
 
 	for _, boardID := range boardIDs {
 		go func(id int) {
-			s, err := c.lastNSprints(id, qp, limit)
+			s, err := c.lastNSprints(ctx, id, qp, limit)
 			if err != nil {
 				ch <- nil
 				return
@@ -166,12 +186,17 @@ func (c *Client) SprintsInBoards(boardIDs []int, qp string, limit int) []*Sprint
 
 // SprintIssues fetches issues in the given sprint.
 func (c *Client) SprintIssues(sprintID int, jql string, from, limit uint) (*SearchResult, error) {
+	return c.SprintIssuesContext(context.Background(), sprintID, jql, from, limit)
+}
+
+// SprintIssuesContext is the same as SprintIssues but accepts a context.
+func (c *Client) SprintIssuesContext(ctx context.Context, sprintID int, jql string, from, limit uint) (*SearchResult, error) {
 	path := fmt.Sprintf("/sprint/%d/issue?startAt=%d&maxResults=%d", sprintID, from, limit)
 	if jql != "" {
 		path += fmt.Sprintf("&jql=%s", url.QueryEscape(jql))
 	}
 
-	res, err := c.GetV1(context.Background(), path, nil)
+	res, err := c.GetV1(ctx, path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +218,11 @@ func (c *Client) SprintIssues(sprintID int, jql string, from, limit uint) (*Sear
 
 // SprintIssuesAdd adds issues to the sprint.
 func (c *Client) SprintIssuesAdd(id string, issues ...string) error {
+	return c.SprintIssuesAddContext(context.Background(), id, issues...)
+}
+
+// SprintIssuesAddContext is the same as SprintIssuesAdd but accepts a context.
+func (c *Client) SprintIssuesAddContext(ctx context.Context, id string, issues ...string) error {
 	path := fmt.Sprintf("/sprint/%s/issue", id)
 
 	data := struct {
@@ -204,7 +234,7 @@ func (c *Client) SprintIssuesAdd(id string, issues ...string) error {
 		return err
 	}
 
-	res, err := c.PostV1(context.Background(), path, body, Header{
+	res, err := c.PostV1(ctx, path, body, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -227,7 +257,7 @@ func (c *Client) SprintIssuesAdd(id string, issues ...string) error {
 // Jira api to get all sprints doesn't provide an option to sort results and
 // returns result in ascending order by default. So, we will have to send
 // multiple requests to get the results we are interested in.
-func (c *Client) lastNSprints(boardID int, qp string, limit int) (*SprintResult, error) {
+func (c *Client) lastNSprints(ctx context.Context, boardID int, qp string, limit int) (*SprintResult, error) {
 	var (
 		s        *SprintResult
 		err      error
@@ -235,7 +265,7 @@ func (c *Client) lastNSprints(boardID int, qp string, limit int) (*SprintResult,
 	)
 
 	for {
-		s, err = c.Sprints(boardID, qp, n, limit)
+		s, err = c.SprintsContext(ctx, boardID, qp, n, limit)
 		if err != nil {
 			break
 		}
@@ -257,7 +287,7 @@ func (c *Client) lastNSprints(boardID int, qp string, limit int) (*SprintResult,
 	if n < 0 {
 		return s, err
 	}
-	return c.Sprints(boardID, qp, n, limit)
+	return c.SprintsContext(ctx, boardID, qp, n, limit)
 }
 
 func injectBoardID(sprints []*Sprint, boardID int) {
@@ -46,15 +46,25 @@ type transitionResponse struct {
 
 // Transitions fetches valid transitions for an issue using v3 version of the GET /issue/{key}/transitions endpoint.
 func (c *Client) Transitions(key string) ([]*Transition, error) {
-	return c.transitions(key, apiVersion3)
+	return c.TransitionsContext(context.Background(), key)
+}
+
+// TransitionsContext is the same as Transitions but accepts a context.
+func (c *Client) TransitionsContext(ctx context.Context, key string) ([]*Transition, error) {
+	return c.transitions(ctx, key, apiVersion3)
 }
 
 // TransitionsV2 fetches valid transitions for an issue using v2 version of the GET /issue/{key}/transitions endpoint.
 func (c *Client) TransitionsV2(key string) ([]*Transition, error) {
-	return c.transitions(key, apiVersion2)
+	return c.TransitionsV2Context(context.Background(), key)
+}
+
+// TransitionsV2Context is the same as TransitionsV2 but accepts a context.
+func (c *Client) TransitionsV2Context(ctx context.Context, key string) ([]*Transition, error) {
+	return c.transitions(ctx, key, apiVersion2)
 }
 
-func (c *Client) transitions(key, ver string) ([]*Transition, error) {
+func (c *Client) transitions(ctx context.Context, key, ver string) ([]*Transition, error) {
 	path := fmt.Sprintf("/issue/%s/transitions", key)
 
 	var (
@@ -64,9 +74,9 @@ func (c *Client) transitions(key, ver string) ([]*Transition, error) {
 
 	switch ver {
 	case apiVersion2:
-		res, err = c.GetV2(context.Background(), path, nil)
+		res, err = c.GetV2(ctx, path, nil)
 	default:
-		res, err = c.Get(context.Background(), path, nil)
+		res, err = c.Get(ctx, path, nil)
 	}
 
 	if err != nil {
@@ -90,6 +100,11 @@ func (c *Client) transitions(key, ver string) ([]*Transition, error) {
 
 // Transition moves issue from one state to another using POST /issue/{key}/transitions endpoint.
 func (c *Client) Transition(key string, data *TransitionRequest) (int, error) {
+	return c.TransitionContext(context.Background(), key, data)
+}
+
+// TransitionContext is the same as Transition but accepts a context.
+func (c *Client) TransitionContext(ctx context.Context, key string, data *TransitionRequest) (int, error) {
 	body, err := json.Marshal(&data)
 	if err != nil {
 		return 0, err
@@ -97,7 +112,7 @@ func (c *Client) Transition(key string, data *TransitionRequest) (int, error) {
 
 	path := fmt.Sprintf("/issue/%s/transitions", key)
 
-	res, err := c.PostV2(context.Background(), path, body, Header{
+	res, err := c.PostV2(ctx, path, body, Header{
 		"Accept":       "application/json",
 		"Content-Type": "application/json",
 	})
@@ -0,0 +1,98 @@
+package jira
+
+import "net/http"
+
+// Middleware wraps a round tripper to observe or modify requests and responses,
+// eg: for tracing, metrics, caching or recording.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as round trippers.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithHTTPClient is a functional opt to send requests using the given http client.
+//
+// The client is copied, so the original one is never modified. Its transport is
+// used unless a transport is set with WithTransport, in which case TLS related
+// options like WithInsecureTLS and mTLS config are not applied.
+func WithHTTPClient(hc *http.Client) ClientFunc {
+	return func(c *Client) {
+		cp := *hc
+		c.httpClient = &cp
+	}
+}
+
+// WithTransport is a functional opt to send requests using the given round tripper
+// instead of the default transport. TLS related options like WithInsecureTLS and
+// mTLS config are not applied to a custom transport.
+func WithTransport(rt http.RoundTripper) ClientFunc {
+	return func(c *Client) {
+		c.transport = rt
+	}
+}
+
+// WithMiddleware is a functional opt to wrap the transport with the given middlewares.
+//
+// Middlewares are applied in order, so the first one is the outermost and sees
+// the requests first. Authentication is applied after all middlewares, so they
+// never see the credentials.
+func WithMiddleware(mw ...Middleware) ClientFunc {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// chain wraps the transport with the auth, cache and user provided middlewares.
+func (c *Client) chain(rt http.RoundTripper) http.RoundTripper {
+	rt = c.authMiddleware()(rt)
+	if c.cache != nil {
+		rt = c.cacheMiddleware()(rt)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	return rt
+}
+
+// authMiddleware sets the authorization header based on the auth type.
+func (c *Client) authMiddleware() Middleware {
+	authType, ts := AuthTypeBasic, c.tokenSource
+	if c.authType != nil {
+		authType = *c.authType
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// A round tripper should not modify the original request.
+			req = req.Clone(req.Context())
+
+			if authType == AuthTypeOAuth2 {
+				return oauth2RoundTrip(ts, next, req)
+			}
+
+			login, token, err := c.credentials(req.Context())
+			if err != nil {
+				return nil, err
+			}
+
+			// When need to compare using `String()` here, it is used to handle cases where the
+			// authentication type might be empty, ensuring it defaults to the appropriate value.
+			switch authType.String() {
+			case string(AuthTypeMTLS):
+				if token != "" {
+					req.Header.Set("Authorization", "Bearer "+token)
+				}
+			case string(AuthTypeBearer):
+				req.Header.Set("Authorization", "Bearer "+token)
+			case string(AuthTypeBasic):
+				req.SetBasicAuth(login, token)
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		login, token, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", login)
+		assert.Equal(t, "secret", token)
+		assert.Equal(t, "outer,inner", r.Header.Get("X-Trace"))
+
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	var calls []string
+
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+
+				// Credentials are added after all middlewares.
+				assert.Empty(t, req.Header.Get("Authorization"))
+
+				req = req.Clone(req.Context())
+				if v := req.Header.Get("X-Trace"); v != "" {
+					name = v + "," + name
+				}
+				req.Header.Set("X-Trace", name)
+
+				return next.RoundTrip(req)
+			})
+		}
+	}
+
+	client := NewClient(
+		Config{Server: server.URL, Login: "user", APIToken: "secret"},
+		WithTimeout(3*time.Second),
+		WithMiddleware(trace("outer")),
+		WithMiddleware(trace("inner")),
+	)
+
+	res, err := client.GetV2(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, []string{"outer", "inner"}, calls)
+}
+
+func TestWithHTTPClientAndTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		w.WriteHeader(200)
+	}))
+	defer server.Close()
+
+	bearer := AuthTypeBearer
+
+	transport := &countingTransport{}
+
+	// The transport of the provided http client is used and the client itself is not modified.
+	hc := &http.Client{Transport: transport, Timeout: 3 * time.Second}
+	client := NewClient(Config{Server: server.URL, APIToken: "secret", AuthType: &bearer}, WithHTTPClient(hc))
+
+	res, err := client.Get(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, 1, transport.sent)
+	assert.Equal(t, 3*time.Second, client.httpClient.Timeout)
+	assert.Same(t, transport, hc.Transport)
+
+	// An explicit transport takes precedence over the one of the http client.
+	custom := &countingTransport{}
+	client = NewClient(
+		Config{Server: server.URL, APIToken: "secret", AuthType: &bearer},
+		WithHTTPClient(hc),
+		WithTransport(custom),
+	)
+
+	res, err = client.Get(context.Background(), "/myself", nil)
+	assert.NoError(t, err)
+	_ = res.Body.Close()
+
+	assert.Equal(t, 1, transport.sent)
+	assert.Equal(t, 1, custom.sent)
+}
+
+type countingTransport struct {
+	sent int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.sent++
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -11,10 +11,21 @@ const (
 	AuthTypeBearer AuthType = "bearer"
 	// AuthTypeMTLS is a mTLS auth.
 	AuthTypeMTLS AuthType = "mtls"
+	// AuthTypeOAuth2 is an OAuth 2.0 (3LO) auth.
+	AuthTypeOAuth2 AuthType = "oauth2"
+)
+
+// Expand options for issue and search requests.
+const (
+	ExpandChangelog      = "changelog"
+	ExpandRenderedFields = "renderedFields"
+	ExpandNames          = "names"
+	ExpandSchema         = "schema"
+	ExpandTransitions    = "transitions"
 )
 
 // AuthType is a jira authentication type.
-// Currently supports basic and bearer (PAT).
+// Currently supports basic, bearer (PAT), mtls and oauth2.
 // Defaults to basic for empty or invalid value.
 type AuthType string
 
@@ -63,6 +74,40 @@ type Epic struct {
 type Issue struct {
 	Key    string      `json:"key"`
 	Fields IssueFields `json:"fields"`
+
+	// Following fields are only populated when requested using expand and properties options.
+	RenderedFields map[string]interface{} `json:"renderedFields,omitempty"`
+	Names          map[string]string      `json:"names,omitempty"`
+	Schema         map[string]interface{} `json:"schema,omitempty"`
+	Transitions    []*Transition          `json:"transitions,omitempty"`
+	Changelog      *Changelog             `json:"changelog,omitempty"`
+	Properties     map[string]interface{} `json:"properties,omitempty"`
+}
+
+// Changelog holds issue change history.
+type Changelog struct {
+	StartAt    int                 `json:"startAt"`
+	MaxResults int                 `json:"maxResults"`
+	Total      int                 `json:"total"`
+	Histories  []*ChangelogHistory `json:"histories"`
+}
+
+// ChangelogHistory holds a group of changes made to an issue at once.
+type ChangelogHistory struct {
+	ID      string           `json:"id"`
+	Author  User             `json:"author"`
+	Created string           `json:"created"`
+	Items   []*ChangelogItem `json:"items"`
+}
+
+// ChangelogItem holds a single field change.
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
 }
 
 // IssueFields holds issue fields.
@@ -24,11 +24,21 @@ type UserSearchOptions struct {
 
 // UserSearch search for user details using v3 version of the GET /user/assignable/search endpoint.
 func (c *Client) UserSearch(opt *UserSearchOptions) ([]*User, error) {
-	return c.userSearch(opt, apiVersion3)
+	return c.UserSearchContext(context.Background(), opt)
+}
+
+// UserSearchContext is the same as UserSearch but accepts a context.
+func (c *Client) UserSearchContext(ctx context.Context, opt *UserSearchOptions) ([]*User, error) {
+	return c.userSearch(ctx, opt, apiVersion3)
 }
 
 // UserSearchV2 search for user details using v2 version of the GET /user/assignable/search endpoint.
 func (c *Client) UserSearchV2(opt *UserSearchOptions) ([]*User, error) {
+	return c.UserSearchV2Context(context.Background(), opt)
+}
+
+// UserSearchV2Context is the same as UserSearchV2 but accepts a context.
+func (c *Client) UserSearchV2Context(ctx context.Context, opt *UserSearchOptions) ([]*User, error) {
 	// The `username` query param is deprecated since Jira API v2 and is not available in v3.
 	// Since the` query` parameter doesn't seem to return expected results, we will use the
 	// `username` param in call to v2. Chances are the `query` param may stop working in
@@ -40,10 +50,10 @@ func (c *Client) UserSearchV2(opt *UserSearchOptions) ([]*User, error) {
 		opt.Username = opt.Query
 		opt.Query = ""
 	}
-	return c.userSearch(opt, apiVersion2)
+	return c.userSearch(ctx, opt, apiVersion2)
 }
 
-func (c *Client) userSearch(opt *UserSearchOptions, ver string) ([]*User, error) {
+func (c *Client) userSearch(ctx context.Context, opt *UserSearchOptions, ver string) ([]*User, error) {
 	if opt == nil {
 		return nil, ErrInvalidSearchOption
 	}
@@ -80,9 +90,9 @@ func (c *Client) userSearch(opt *UserSearchOptions, ver string) ([]*User, error)
 
 	switch ver {
 	case apiVersion2:
-		res, err = c.GetV2(context.Background(), path, nil)
+		res, err = c.GetV2(ctx, path, nil)
 	default:
-		res, err = c.Get(context.Background(), path, nil)
+		res, err = c.Get(ctx, path, nil)
 	}
 
 	if err != nil {
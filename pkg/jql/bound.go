@@ -0,0 +1,110 @@
+package jql
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenOther
+)
+
+type token struct {
+	kind tokenKind
+	val  string
+	pos  int
+}
+
+// IsBounded reports whether the query has a search restriction.
+//
+// Jira cloud rejects queries without a search restriction, like `ORDER BY key DESC`,
+// with "Unbounded JQL queries are not allowed" error. The query is tokenized so that
+// keywords inside quoted values and nested parentheses are handled correctly.
+func IsBounded(q string) bool {
+	where, _ := SplitOrderBy(q)
+	for _, t := range tokenize(where) {
+		if t.kind != tokenLParen && t.kind != tokenRParen {
+			return true
+		}
+	}
+	return false
+}
+
+// SplitOrderBy splits the query into the search restriction and the ORDER BY clause.
+// The returned order by clause includes the ORDER BY keywords.
+func SplitOrderBy(q string) (string, string) {
+	tokens := tokenize(q)
+
+	depth := 0
+	for i, t := range tokens {
+		switch t.kind {
+		case tokenLParen:
+			depth++
+		case tokenRParen:
+			depth--
+		case tokenWord:
+			if depth != 0 || !strings.EqualFold(t.val, "order") {
+				continue
+			}
+			if i+1 < len(tokens) && tokens[i+1].kind == tokenWord && strings.EqualFold(tokens[i+1].val, "by") {
+				return strings.TrimSpace(q[:t.pos]), strings.TrimSpace(q[t.pos:])
+			}
+		}
+	}
+
+	return strings.TrimSpace(q), ""
+}
+
+func tokenize(q string) []token {
+	var tokens []token
+
+	for i := 0; i < len(q); {
+		r, size := utf8.DecodeRuneInString(q[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, val: "(", pos: i})
+			i += size
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, val: ")", pos: i})
+			i += size
+		case r == '"' || r == '\'':
+			start := i
+			for i += size; i < len(q) && rune(q[i]) != r; i++ {
+				if q[i] == '\\' {
+					i++
+				}
+			}
+			i = min(i+1, len(q))
+			tokens = append(tokens, token{kind: tokenString, val: q[start:i], pos: start})
+		case isWordRune(r):
+			start := i
+			for i < len(q) {
+				r, size = utf8.DecodeRuneInString(q[i:])
+				if !isWordRune(r) {
+					break
+				}
+				i += size
+			}
+			tokens = append(tokens, token{kind: tokenWord, val: q[start:i], pos: start})
+		default:
+			tokens = append(tokens, token{kind: tokenOther, val: string(r), pos: i})
+			i += size
+		}
+	}
+
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.' || r == '@'
+}
@@ -0,0 +1,55 @@
+package jql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBounded(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected bool
+	}{
+		{query: "", expected: false},
+		{query: "   ", expected: false},
+		{query: "ORDER BY key DESC", expected: false},
+		{query: "order by created", expected: false},
+		{query: "() ORDER BY created", expected: false},
+		{query: "assignee = currentUser() ORDER BY key", expected: true},
+		{query: "project = TEST", expected: true},
+		{query: `summary ~ "order by" ORDER BY created`, expected: true},
+		{query: "(status = Done OR status = 'In Progress')", expected: true},
+		{query: "status WAS Open BEFORE -1w", expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsBounded(tc.query))
+		})
+	}
+}
+
+func TestSplitOrderBy(t *testing.T) {
+	cases := []struct {
+		query   string
+		where   string
+		orderBy string
+	}{
+		{query: "project = TEST", where: "project = TEST", orderBy: ""},
+		{query: "ORDER BY created DESC", where: "", orderBy: "ORDER BY created DESC"},
+		{query: "project = TEST order by created", where: "project = TEST", orderBy: "order by created"},
+		{query: `summary ~ "order by" ORDER BY rank`, where: `summary ~ "order by"`, orderBy: "ORDER BY rank"},
+		{query: `summary ~ 'it\'s ORDER BY' ORDER BY rank`, where: `summary ~ 'it\'s ORDER BY'`, orderBy: "ORDER BY rank"},
+		{query: "summary ~ ordered", where: "summary ~ ordered", orderBy: ""},
+		{query: "résumé = ü ORDER BY key", where: "résumé = ü", orderBy: "ORDER BY key"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			where, orderBy := SplitOrderBy(tc.query)
+			assert.Equal(t, tc.where, where)
+			assert.Equal(t, tc.orderBy, orderBy)
+		})
+	}
+}
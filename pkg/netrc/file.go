@@ -0,0 +1,523 @@
+package netrc
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// File is a parsed netrc file.
+//
+// Entries can be added, updated and removed in place. Comments, macros and the
+// formatting of untouched entries are preserved when the file is saved.
+// A File isn't safe for concurrent use.
+type File struct {
+	path    string
+	data    []byte
+	entries []*entry
+}
+
+// entry is an Entry with the position of its tokens in the file.
+type entry struct {
+	Entry
+
+	start, end int
+	// valEnd is the end of the last token before macro definitions.
+	valEnd int
+	fields map[string]field
+}
+
+// field is a key value pair, eg: login user.
+type field struct {
+	keyStart int
+	val      token
+}
+
+type token struct {
+	val        string
+	start, end int
+}
+
+// DefaultPath returns the path of the user's netrc file, $NETRC or ~/.netrc.
+func DefaultPath() (string, error) {
+	if env := os.Getenv("NETRC"); env != "" {
+		return env, nil
+	}
+	dir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	base := ".netrc"
+	if runtime.GOOS == "windows" {
+		base = "_netrc"
+	}
+	return filepath.Join(dir, base), nil
+}
+
+// Load reads the netrc file at path, or the default netrc file if path is empty.
+// A missing file is treated as an empty one so that entries can be added to it.
+func Load(path string) (*File, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+
+	f := File{path: path}
+	if err := f.Reload(); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Parse parses netrc data. The returned file can't be saved.
+func Parse(data []byte) (*File, error) {
+	entries, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return &File{data: slices.Clone(data), entries: entries}, nil
+}
+
+// Path returns the path the file was loaded from.
+func (f *File) Path() string {
+	return f.path
+}
+
+// Reload reads the file again, discarding unsaved changes.
+func (f *File) Reload() error {
+	data, err := os.ReadFile(f.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	entries, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", f.path, err)
+	}
+	f.data, f.entries = data, entries
+
+	return nil
+}
+
+// Entries returns all entries in the order they appear in the file.
+func (f *File) Entries() []Entry {
+	out := make([]Entry, 0, len(f.entries))
+	for _, e := range f.entries {
+		out = append(out, e.copy())
+	}
+	return out
+}
+
+// Find returns the entry for the machine, eg: example.com or example.com:8080.
+// An empty login matches the first entry of the machine.
+//
+// Entries with the same host and port are preferred, followed by entries
+// for the host without a port and finally the default entry.
+func (f *File) Find(machine string, login string) (*Entry, error) {
+	hostname := machine
+	if h, _, err := net.SplitHostPort(machine); err == nil {
+		hostname = h
+	}
+
+	matches := []func(e *entry) bool{
+		func(e *entry) bool { return !e.Default && strings.EqualFold(e.Machine, machine) },
+		func(e *entry) bool { return !e.Default && strings.EqualFold(e.Machine, hostname) },
+		func(e *entry) bool { return e.Default },
+	}
+	for _, match := range matches {
+		for _, e := range f.entries {
+			if match(e) && (login == "" || e.Login == login) {
+				out := e.copy()
+				return &out, nil
+			}
+		}
+	}
+
+	return nil, ErrNetrcEntryNotFound
+}
+
+// Set adds the entry or updates the login, password and account of the existing
+// entry with the same machine and login. There is only one default entry, so it
+// is updated regardless of its login. Macros are not written.
+func (f *File) Set(e Entry) error {
+	i := f.index(e)
+	if i < 0 {
+		return f.insert(e)
+	}
+
+	cur := f.entries[i]
+	values := map[string]string{"login": e.Login, "password": e.Password, "account": e.Account}
+
+	var edits []edit
+	for _, key := range []string{"login", "password", "account"} {
+		val := values[key]
+		fd, ok := cur.fields[key]
+
+		switch {
+		case ok && val == "":
+			start := fd.keyStart
+			for start > cur.start && (isSpace(f.data[start-1]) || f.data[start-1] == '\n') {
+				start--
+			}
+			edits = append(edits, edit{start: start, end: fd.val.end})
+		case ok && val != fd.val.val:
+			edits = append(edits, edit{start: fd.val.start, end: fd.val.end, text: quote(val)})
+		case !ok && val != "":
+			edits = append(edits, edit{start: cur.valEnd, end: cur.valEnd, text: " " + key + " " + quote(val)})
+		}
+	}
+
+	return f.apply(edits)
+}
+
+// Remove removes the entries of the machine with the given login and reports
+// whether any entry was removed. An empty login removes all entries of the
+// machine and an empty machine removes the default entry.
+func (f *File) Remove(machine string, login string) bool {
+	var edits []edit
+	for _, e := range f.entries {
+		if machine == "" && !e.Default {
+			continue
+		}
+		if machine != "" && (e.Default || !strings.EqualFold(e.Machine, machine)) {
+			continue
+		}
+		if login != "" && e.Login != login {
+			continue
+		}
+		edits = append(edits, f.removal(e))
+	}
+
+	if len(edits) == 0 || f.apply(edits) != nil {
+		return false
+	}
+	return true
+}
+
+// Bytes returns the content of the file including unsaved changes.
+func (f *File) Bytes() []byte {
+	return slices.Clone(f.data)
+}
+
+// Save writes the file atomically. The permissions of an existing file are
+// preserved, new files are only readable by the user.
+func (f *File) Save() error {
+	if f.path == "" {
+		return fmt.Errorf("netrc: file has no path")
+	}
+
+	mode := os.FileMode(0o600)
+	if fi, err := os.Stat(f.path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), "."+filepath.Base(f.path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(f.data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (f *File) index(e Entry) int {
+	return slices.IndexFunc(f.entries, func(cur *entry) bool {
+		if e.Default || cur.Default {
+			return e.Default && cur.Default
+		}
+		return strings.EqualFold(cur.Machine, e.Machine) && cur.Login == e.Login
+	})
+}
+
+func (f *File) insert(e Entry) error {
+	var b strings.Builder
+	if e.Default {
+		b.WriteString("default")
+	} else {
+		b.WriteString("machine " + quote(e.Machine))
+	}
+	if e.Login != "" {
+		b.WriteString(" login " + quote(e.Login))
+	}
+	if e.Password != "" {
+		b.WriteString(" password " + quote(e.Password))
+	}
+	if e.Account != "" {
+		b.WriteString(" account " + quote(e.Account))
+	}
+	b.WriteString("\n")
+
+	// The default entry must come after all machine entries.
+	if i := slices.IndexFunc(f.entries, func(cur *entry) bool { return cur.Default }); i >= 0 && !e.Default {
+		pos := bytes.LastIndexByte(f.data[:f.entries[i].start], '\n') + 1
+		return f.apply([]edit{{start: pos, end: pos, text: b.String()}})
+	}
+
+	var prefix string
+	if n := len(f.data); n > 0 {
+		if f.data[n-1] != '\n' {
+			prefix = "\n"
+		}
+		// A macro definition ends with an empty line.
+		if len(f.entries) > 0 && len(f.entries[len(f.entries)-1].Macros) > 0 && !bytes.HasSuffix(f.data, []byte("\n\n")) {
+			prefix += "\n"
+		}
+	}
+	return f.apply([]edit{{start: len(f.data), end: len(f.data), text: prefix + b.String()}})
+}
+
+// removal returns the edit removing the entry along with its line
+// if there is nothing else on it.
+func (f *File) removal(e *entry) edit {
+	start, end := e.start, e.end
+
+	lineStart := start
+	for lineStart > 0 && isSpace(f.data[lineStart-1]) {
+		lineStart--
+	}
+	atLineStart := lineStart == 0 || f.data[lineStart-1] == '\n'
+	for end < len(f.data) && isSpace(f.data[end]) {
+		end++
+	}
+	// The newline is either the end of the line or
+	// the empty line ending the macro definitions.
+	if atLineStart {
+		start = lineStart
+		if end < len(f.data) && f.data[end] == '\n' {
+			end++
+		}
+	}
+	return edit{start: start, end: end}
+}
+
+type edit struct {
+	start, end int
+	text       string
+}
+
+// apply applies non-overlapping edits and parses the result.
+// The file is left untouched if the result is invalid.
+func (f *File) apply(edits []edit) error {
+	if len(edits) == 0 {
+		return nil
+	}
+	slices.SortFunc(edits, func(a, b edit) int { return b.start - a.start })
+
+	data := slices.Clone(f.data)
+	for _, e := range edits {
+		data = slices.Concat(data[:e.start], []byte(e.text), data[e.end:])
+	}
+
+	entries, err := parse(data)
+	if err != nil {
+		return err
+	}
+	f.data, f.entries = data, entries
+
+	return nil
+}
+
+func (e *entry) copy() Entry {
+	out := e.Entry
+	out.Macros = maps.Clone(e.Macros)
+	return out
+}
+
+// parse parses the tokens of the GNU netrc grammar.
+// See https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html
+func parse(data []byte) ([]*entry, error) {
+	var (
+		entries []*entry
+		cur     *entry
+	)
+
+	s := scanner{data: data}
+	for {
+		tok, ok, err := s.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return entries, nil
+		}
+
+		switch tok.val {
+		case "machine":
+			name, err := s.value(tok)
+			if err != nil {
+				return nil, err
+			}
+			cur = &entry{Entry: Entry{Machine: name.val}, start: tok.start, end: name.end, valEnd: name.end}
+			entries = append(entries, cur)
+		case "default":
+			cur = &entry{Entry: Entry{Default: true}, start: tok.start, end: tok.end, valEnd: tok.end}
+			entries = append(entries, cur)
+		case "login", "password", "account":
+			val, err := s.value(tok)
+			if err != nil {
+				return nil, err
+			}
+			if cur == nil {
+				return nil, s.errorf(tok.start, "%s outside of a machine entry", tok.val)
+			}
+			switch tok.val {
+			case "login":
+				cur.Login = val.val
+			case "password":
+				cur.Password = val.val
+			case "account":
+				cur.Account = val.val
+			}
+			if cur.fields == nil {
+				cur.fields = make(map[string]field)
+			}
+			cur.fields[tok.val] = field{keyStart: tok.start, val: val}
+			cur.end = max(cur.end, val.end)
+			if len(cur.Macros) == 0 {
+				cur.valEnd = val.end
+			}
+		case "macdef":
+			// A macro's contents begin with the next line and continue
+			// until an empty line is encountered.
+			name, err := s.value(tok)
+			if err != nil {
+				return nil, err
+			}
+			if cur == nil {
+				return nil, s.errorf(tok.start, "macdef outside of a machine entry")
+			}
+			if cur.Macros == nil {
+				cur.Macros = make(map[string]string)
+			}
+			cur.Macros[name.val], cur.end = s.macro()
+		}
+	}
+}
+
+type scanner struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next token skipping whitespace and comments.
+func (s *scanner) next() (token, bool, error) {
+	return s.token(true)
+}
+
+// token returns the next token skipping whitespace, and comments if asked.
+// Values may start with #, so comments are only skipped where a keyword
+// is expected.
+func (s *scanner) token(comments bool) (token, bool, error) {
+	for s.pos < len(s.data) {
+		switch c := s.data[s.pos]; {
+		case isSpace(c) || c == '\n':
+			s.pos++
+		case c == '#' && comments:
+			for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+		case c == '"':
+			return s.quoted()
+		default:
+			start := s.pos
+			for s.pos < len(s.data) && !isSpace(s.data[s.pos]) && s.data[s.pos] != '\n' {
+				s.pos++
+			}
+			return token{val: string(s.data[start:s.pos]), start: start, end: s.pos}, true, nil
+		}
+	}
+	return token{}, false, nil
+}
+
+func (s *scanner) quoted() (token, bool, error) {
+	start := s.pos
+	s.pos++
+
+	var b strings.Builder
+	for s.pos < len(s.data) {
+		c := s.data[s.pos]
+		s.pos++
+
+		switch c {
+		case '"':
+			return token{val: b.String(), start: start, end: s.pos}, true, nil
+		case '\\':
+			if s.pos < len(s.data) {
+				c = s.data[s.pos]
+				s.pos++
+			}
+		}
+		b.WriteByte(c)
+	}
+	return token{}, false, s.errorf(start, "unterminated quoted value")
+}
+
+// value returns the value following the keyword.
+func (s *scanner) value(key token) (token, error) {
+	tok, ok, err := s.token(false)
+	if err != nil {
+		return token{}, err
+	}
+	if !ok {
+		return token{}, s.errorf(key.start, "missing value for %s", key.val)
+	}
+	return tok, nil
+}
+
+// macro returns the body of the macro starting on the next line and the end of the body.
+func (s *scanner) macro() (string, int) {
+	for s.pos < len(s.data) && s.data[s.pos] != '\n' {
+		s.pos++
+	}
+	if s.pos == len(s.data) {
+		return "", s.pos
+	}
+
+	start := s.pos + 1
+	i := bytes.Index(s.data[s.pos:], []byte("\n\n"))
+	if i < 0 {
+		s.pos = len(s.data)
+		return string(s.data[start:]), s.pos
+	}
+
+	end := s.pos + i + 1
+	s.pos = end + 1
+	return string(s.data[start:end]), end
+}
+
+func (s *scanner) errorf(pos int, format string, args ...any) error {
+	line := bytes.Count(s.data[:pos], []byte("\n")) + 1
+	return fmt.Errorf("netrc: line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r'
+}
+
+// quote quotes values that can't be written as a bare token.
+func quote(s string) string {
+	if s != "" && s[0] != '#' && !strings.ContainsAny(s, " \t\r\n\"\\") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + r.Replace(s) + `"`
+}
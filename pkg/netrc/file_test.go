@@ -0,0 +1,217 @@
+package netrc
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want []Entry
+		err  string
+	}{
+		{
+			name: "parses netrc file properly",
+			data: "machine test.sample.org\nlogin mylogin@sample.org\npassword mypassword",
+			want: []Entry{{Machine: "test.sample.org", Login: "mylogin@sample.org", Password: "mypassword"}},
+		},
+		{
+			name: "parses entry without login",
+			data: "machine test.sample.org\npassword mypassword",
+			want: []Entry{{Machine: "test.sample.org", Password: "mypassword"}},
+		},
+		{
+			name: "parses default, account and ports",
+			data: "machine test.sample.org:8080 login user password secret account acme\ndefault login anonymous password guest\n",
+			want: []Entry{
+				{Machine: "test.sample.org:8080", Login: "user", Password: "secret", Account: "acme"},
+				{Default: true, Login: "anonymous", Password: "guest"},
+			},
+		},
+		{
+			name: "parses quoted values",
+			data: `machine test.sample.org login "my user" password "p@ss \"word\" \\ #1"`,
+			want: []Entry{{Machine: "test.sample.org", Login: "my user", Password: `p@ss "word" \ #1`}},
+		},
+		{
+			name: "skips comments",
+			data: "# jira\nmachine test.sample.org login user # inline\npassword secret\n",
+			want: []Entry{{Machine: "test.sample.org", Login: "user", Password: "secret"}},
+		},
+		{
+			name: "parses values starting with #",
+			data: "machine a.example.com login bob password #s3cret\nmachine b.example.com login amy password x\n",
+			want: []Entry{
+				{Machine: "a.example.com", Login: "bob", Password: "#s3cret"},
+				{Machine: "b.example.com", Login: "amy", Password: "x"},
+			},
+		},
+		{
+			name: "parses macros",
+			data: "machine test.sample.org login user password secret\nmacdef init\ncd /tmp\nls\n\nmachine other.sample.org login other password token\n",
+			want: []Entry{
+				{Machine: "test.sample.org", Login: "user", Password: "secret", Macros: map[string]string{"init": "cd /tmp\nls\n"}},
+				{Machine: "other.sample.org", Login: "other", Password: "token"},
+			},
+		},
+		{
+			name: "fails on unterminated quote",
+			data: "machine test.sample.org\nlogin \"user",
+			err:  "netrc: line 2: unterminated quoted value",
+		},
+		{
+			name: "fails on missing value",
+			data: "machine test.sample.org login",
+			err:  "netrc: line 1: missing value for login",
+		},
+		{
+			name: "fails on login outside of entry",
+			data: "login user password secret",
+			err:  "netrc: line 1: login outside of a machine entry",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := Parse([]byte(tc.data))
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, f.Entries())
+		})
+	}
+}
+
+func TestFileFind(t *testing.T) {
+	f, err := Parse([]byte("machine test.sample.org:8080 login port password port-token\n" +
+		"machine test.sample.org login user password user-token\n" +
+		"default login anonymous password guest\n"))
+	assert.NoError(t, err)
+
+	entry, err := f.Find("test.sample.org:8080", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "port-token", entry.Password)
+
+	entry, err = f.Find("TEST.sample.org:9090", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-token", entry.Password)
+
+	entry, err = f.Find("test.sample.org:8080", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "user-token", entry.Password)
+
+	entry, err = f.Find("other.sample.org", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &Entry{Default: true, Login: "anonymous", Password: "guest"}, entry)
+
+	_, err = f.Find("other.sample.org", "user")
+	assert.ErrorIs(t, err, ErrNetrcEntryNotFound)
+}
+
+func TestFileSet(t *testing.T) {
+	data := "# jira\n" +
+		"machine test.sample.org\n" +
+		"\tlogin user\n" +
+		"\tpassword old # rotated monthly\n" +
+		"\n" +
+		"default login anonymous password guest\n"
+
+	f, err := Parse([]byte(data))
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.Set(Entry{Machine: "test.sample.org", Login: "user", Password: "new token", Account: "acme"}))
+	assert.NoError(t, f.Set(Entry{Machine: "other.sample.org", Login: "other", Password: "secret"}))
+	assert.NoError(t, f.Set(Entry{Default: true, Login: "anonymous"}))
+
+	assert.Equal(t, "# jira\n"+
+		"machine test.sample.org\n"+
+		"\tlogin user\n"+
+		"\tpassword \"new token\" account acme # rotated monthly\n"+
+		"\n"+
+		"machine other.sample.org login other password secret\n"+
+		"default login anonymous\n", string(f.Bytes()))
+
+	// Entries are appended to files without default entry.
+	f, err = Parse([]byte("machine test.sample.org login user password secret"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Set(Entry{Machine: "other.sample.org", Login: "other", Password: "#1"}))
+	assert.Equal(t, "machine test.sample.org login user password secret\n"+
+		"machine other.sample.org login other password \"#1\"\n", string(f.Bytes()))
+}
+
+func TestFileRemove(t *testing.T) {
+	data := "# jira\n" +
+		"machine test.sample.org login first password first-token\n" +
+		"machine test.sample.org login second password second-token\n" +
+		"macdef init\n" +
+		"cd /tmp\n" +
+		"\n" +
+		"# other\n" +
+		"machine other.sample.org login other password secret\n" +
+		"default login anonymous password guest\n"
+
+	f, err := Parse([]byte(data))
+	assert.NoError(t, err)
+
+	assert.False(t, f.Remove("test.sample.org", "third"))
+	assert.True(t, f.Remove("test.sample.org", "second"))
+	assert.Equal(t, "# jira\n"+
+		"machine test.sample.org login first password first-token\n"+
+		"# other\n"+
+		"machine other.sample.org login other password secret\n"+
+		"default login anonymous password guest\n", string(f.Bytes()))
+
+	assert.True(t, f.Remove("", ""))
+	assert.True(t, f.Remove("TEST.sample.org", ""))
+	assert.Equal(t, "# jira\n"+
+		"# other\n"+
+		"machine other.sample.org login other password secret\n", string(f.Bytes()))
+}
+
+func TestFileSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".netrc")
+
+	// A missing file is created with restricted permissions.
+	f, err := Load(path)
+	assert.NoError(t, err)
+	assert.Empty(t, f.Entries())
+	assert.NoError(t, f.Set(Entry{Machine: "test.sample.org", Login: "user", Password: "secret"}))
+	assert.NoError(t, f.Save())
+
+	fi, err := os.Stat(path)
+	assert.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+	}
+
+	// Permissions of existing files are preserved.
+	assert.NoError(t, os.Chmod(path, 0o640))
+	assert.True(t, f.Remove("test.sample.org", "user"))
+	assert.NoError(t, f.Save())
+
+	fi, err = os.Stat(path)
+	assert.NoError(t, err)
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, os.FileMode(0o640), fi.Mode().Perm())
+	}
+
+	// Changes made by others are picked up on reload.
+	assert.NoError(t, os.WriteFile(path, []byte("machine test.sample.org login other password token\n"), 0o640))
+	assert.NoError(t, f.Reload())
+
+	entry, err := f.Find("test.sample.org", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", entry.Login)
+
+	_, err = Parse(nil)
+	assert.NoError(t, err)
+	assert.Error(t, (&File{}).Save())
+}
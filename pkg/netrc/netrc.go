@@ -1,6 +1,4 @@
 // Package netrc implements GNU .netrc specification.
-// This implementation is borrowed from the original implementation by the go authors.
-// See https://github.com/golang/go/blob/master/src/cmd/go/internal/auth/netrc.go
 // See https://www.gnu.org/software/inetutils/manual/html_node/The-_002enetrc-file.html
 package netrc
 
@@ -14,32 +12,34 @@ var ErrNetrcEntryNotFound = fmt.Errorf("netrc config: entry not found")
 
 // Entry is a netrc config entry.
 type Entry struct {
+	// Machine is empty for the default entry.
 	Machine  string
 	Login    string
 	Password string
+	Account  string
+	// Default denotes the default entry matching any machine.
+	Default bool
+	// Macros are the macros defined by the entry indexed by name.
+	Macros map[string]string
 }
 
-// Read reads config for the given machine.
+// Read reads config for the given machine from the default netrc file.
+// An empty login matches the first entry of the machine.
 func Read(machine string, login string) (*Entry, error) {
-	netrcOnce.Do(readNetrc)
-	if netrcErr != nil {
-		return nil, netrcErr
-	}
+	return ReadFile("", machine, login)
+}
 
+// ReadFile reads config for the given machine from the file at path.
+// The default netrc file is used if path is empty.
+func ReadFile(path string, machine string, login string) (*Entry, error) {
 	serverURL, err := url.ParseRequestURI(machine)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, line := range netrc {
-		if line.machine == serverURL.Host && line.login == login {
-			return &Entry{
-				Machine:  line.machine,
-				Login:    line.login,
-				Password: line.password,
-			}, nil
-		}
+	f, err := Load(path)
+	if err != nil {
+		return nil, err
 	}
-
-	return nil, ErrNetrcEntryNotFound
+	return f.Find(serverURL.Host, login)
 }
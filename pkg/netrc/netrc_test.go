@@ -0,0 +1,31 @@
+package netrc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".netrc")
+	data := "machine test.atlassian.net login first password first-token\n" +
+		"machine test.atlassian.net login second password second-token\n"
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+
+	entry, err := ReadFile(path, "https://test.atlassian.net", "second")
+	assert.NoError(t, err)
+	assert.Equal(t, &Entry{Machine: "test.atlassian.net", Login: "second", Password: "second-token"}, entry)
+
+	// An empty login matches the first entry of the machine.
+	entry, err = ReadFile(path, "https://test.atlassian.net", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "first-token", entry.Password)
+
+	_, err = ReadFile(path, "https://other.atlassian.net", "first")
+	assert.ErrorIs(t, err, ErrNetrcEntryNotFound)
+
+	_, err = ReadFile(filepath.Join(t.TempDir(), "missing"), "https://test.atlassian.net", "first")
+	assert.ErrorIs(t, err, ErrNetrcEntryNotFound)
+}